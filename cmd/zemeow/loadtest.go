@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// runLoadTest implements the `zemeow loadtest` subcommand: a synthetic
+// traffic generator that fires text (and optionally image) sends at a
+// running server at a fixed rate and reports latency percentiles. Point
+// it at a sandbox session so the traffic never reaches WhatsApp; with
+// --webhook-port it also runs a stub consumer to receive the echoed
+// webhooks, so the session's webhook_url can target this process.
+func runLoadTest(args []string) int {
+	fs := flag.NewFlagSet("loadtest", flag.ExitOnError)
+	baseURL := fs.String("url", "http://localhost:8080", "base URL of the zemeow server")
+	sessionID := fs.String("session", "", "session id to send through (use a sandbox session)")
+	apiKey := fs.String("api-key", "", "API key of the session")
+	to := fs.String("to", "5511999999999@s.whatsapp.net", "destination chat JID")
+	rps := fs.Int("rps", 10, "target requests per second")
+	duration := fs.Duration("duration", 30*time.Second, "how long to generate traffic")
+	workers := fs.Int("workers", 0, "concurrent senders (default: rps, min 1)")
+	mediaRatio := fs.Float64("media-ratio", 0, "fraction of sends that are image sends, in [0, 1]")
+	mediaBytes := fs.Int("media-bytes", 64<<10, "payload size for image sends")
+	webhookPort := fs.Int("webhook-port", 0, "run a stub webhook consumer on this port (0 disables)")
+	fs.Parse(args)
+
+	if *sessionID == "" || *apiKey == "" {
+		fmt.Fprintln(os.Stderr, "loadtest: --session and --api-key are required")
+		fs.Usage()
+		return 2
+	}
+	if *rps < 1 {
+		fmt.Fprintln(os.Stderr, "loadtest: --rps must be >= 1")
+		return 2
+	}
+	if *mediaRatio < 0 || *mediaRatio > 1 {
+		fmt.Fprintln(os.Stderr, "loadtest: --media-ratio must be within [0, 1]")
+		return 2
+	}
+	if *workers <= 0 {
+		*workers = *rps
+	}
+
+	var webhooks atomic.Int64
+	if *webhookPort > 0 {
+		stub := &http.Server{
+			Addr: fmt.Sprintf(":%d", *webhookPort),
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				webhooks.Add(1)
+				w.WriteHeader(http.StatusOK)
+			}),
+		}
+		go stub.ListenAndServe()
+		defer stub.Close()
+		fmt.Printf("webhook stub listening on :%d (set the session's webhook_url to reach it)\n", *webhookPort)
+	}
+
+	// The image payload is random so server-side upload dedup cannot make
+	// media sends artificially cheap; one blob per run is enough since
+	// sandbox sessions skip the upload anyway.
+	imageB64 := ""
+	if *mediaRatio > 0 {
+		blob := make([]byte, *mediaBytes)
+		rand.Read(blob)
+		imageB64 = base64.StdEncoding.EncodeToString(blob)
+	}
+
+	textBody, _ := json.Marshal(map[string]any{
+		"to":   *to,
+		"body": "zemeow loadtest message",
+	})
+	imageBody, _ := json.Marshal(map[string]any{
+		"to":        *to,
+		"base64":    imageB64,
+		"mime_type": "image/jpeg",
+		"caption":   "zemeow loadtest image",
+	})
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	textURL := *baseURL + "/sessions/" + *sessionID + "/send/text"
+	imageURL := *baseURL + "/sessions/" + *sessionID + "/send/image"
+
+	var (
+		mu        sync.Mutex
+		latencies []time.Duration
+		failures  int
+	)
+	ticks := make(chan int, *rps)
+	var wg sync.WaitGroup
+	for i := 0; i < *workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range ticks {
+				url, body := textURL, textBody
+				// Interleave media sends deterministically at the requested
+				// ratio instead of rolling dice per request.
+				if *mediaRatio > 0 && float64(n%100) < *mediaRatio*100 {
+					url, body = imageURL, imageBody
+				}
+				req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+				if err != nil {
+					continue
+				}
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("Authorization", *apiKey)
+				start := time.Now()
+				resp, err := client.Do(req)
+				elapsed := time.Since(start)
+				ok := err == nil && resp.StatusCode < 300
+				if resp != nil {
+					resp.Body.Close()
+				}
+				mu.Lock()
+				if ok {
+					latencies = append(latencies, elapsed)
+				} else {
+					failures++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	fmt.Printf("sending at %d rps for %s...\n", *rps, *duration)
+	started := time.Now()
+	ticker := time.NewTicker(time.Second / time.Duration(*rps))
+	deadline := time.After(*duration)
+	n := 0
+loop:
+	for {
+		select {
+		case <-ticker.C:
+			select {
+			case ticks <- n:
+				n++
+			default:
+				// Workers are saturated; dropping the tick keeps the target
+				// rate honest instead of building an unbounded backlog.
+			}
+		case <-deadline:
+			break loop
+		}
+	}
+	ticker.Stop()
+	close(ticks)
+	wg.Wait()
+	elapsed := time.Since(started)
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	fmt.Printf("\nsent %d requests in %s (%.1f rps achieved), %d failed\n",
+		len(latencies)+failures, elapsed.Round(time.Millisecond),
+		float64(len(latencies)+failures)/elapsed.Seconds(), failures)
+	if len(latencies) > 0 {
+		fmt.Printf("latency  p50 %s  p90 %s  p99 %s  max %s\n",
+			percentile(latencies, 50), percentile(latencies, 90),
+			percentile(latencies, 99), latencies[len(latencies)-1].Round(time.Millisecond))
+	}
+	if *webhookPort > 0 {
+		// Give in-flight webhook deliveries a moment to drain.
+		time.Sleep(2 * time.Second)
+		fmt.Printf("webhook stub received %d deliveries\n", webhooks.Load())
+	}
+	if failures > 0 {
+		return 1
+	}
+	return 0
+}
+
+// percentile returns the p-th percentile of sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	idx := len(sorted) * p / 100
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx].Round(time.Millisecond)
+}
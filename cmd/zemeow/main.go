@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.mau.fi/whatsmeow/store/sqlstore"
+	waLog "go.mau.fi/whatsmeow/util/log"
+
+	"github.com/fgsgalvaosz/zemeow/internal/announce"
+	"github.com/fgsgalvaosz/zemeow/internal/api"
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+	"github.com/fgsgalvaosz/zemeow/internal/database"
+	"github.com/fgsgalvaosz/zemeow/internal/egress"
+	"github.com/fgsgalvaosz/zemeow/internal/enrich"
+	"github.com/fgsgalvaosz/zemeow/internal/extract"
+	"github.com/fgsgalvaosz/zemeow/internal/features"
+	"github.com/fgsgalvaosz/zemeow/internal/links"
+	"github.com/fgsgalvaosz/zemeow/internal/logging"
+	"github.com/fgsgalvaosz/zemeow/internal/media"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/policy"
+	"github.com/fgsgalvaosz/zemeow/internal/privacy"
+	"github.com/fgsgalvaosz/zemeow/internal/queue"
+	"github.com/fgsgalvaosz/zemeow/internal/repository"
+	"github.com/fgsgalvaosz/zemeow/internal/retention"
+	"github.com/fgsgalvaosz/zemeow/internal/scan"
+	"github.com/fgsgalvaosz/zemeow/internal/session"
+	"github.com/fgsgalvaosz/zemeow/internal/template"
+	"github.com/fgsgalvaosz/zemeow/internal/transcribe"
+	"github.com/fgsgalvaosz/zemeow/internal/translate"
+	"github.com/fgsgalvaosz/zemeow/internal/webhook"
+)
+
+func main() {
+	// Subcommands that do not need the server environment run before any
+	// config or database setup.
+	if len(os.Args) > 1 && os.Args[1] == "loadtest" {
+		os.Exit(runLoadTest(os.Args[2:]))
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		println("fatal:", err.Error())
+		os.Exit(1)
+	}
+	log, closeLogs, err := newLogger(cfg.Log)
+	if err != nil {
+		println("fatal:", err.Error())
+		os.Exit(1)
+	}
+	defer closeLogs()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	db, err := database.Connect(ctx, cfg.Database)
+	cancel()
+	if err != nil {
+		log.Fatal().Err(err).Msg("database connect")
+	}
+	defer db.Close()
+
+	if err := database.Migrate(context.Background(), db); err != nil {
+		log.Fatal().Err(err).Msg("database migrate")
+	}
+
+	container := sqlstore.NewWithDB(db, "postgres", waLog.Noop)
+	if err := container.Upgrade(); err != nil {
+		log.Fatal().Err(err).Msg("whatsmeow store upgrade")
+	}
+
+	repos := repository.NewSet(db)
+
+	storage, err := media.NewStorage(context.Background(), cfg.Storage)
+	if err != nil {
+		log.Fatal().Err(err).Msg("storage connect")
+	}
+
+	egressResolver := egress.NewResolver(cfg.Egress.ProxyURL, cfg.Egress.Timeout)
+	redactor := privacy.NewRedactor(cfg.Privacy)
+	deliverer := webhook.NewDeliverer(cfg.Webhook, egressResolver, redactor, log)
+	publisher, err := queue.NewPublisher(cfg.Queue, log)
+	if err != nil {
+		log.Fatal().Err(err).Msg("queue connect")
+	}
+	defer publisher.Close()
+
+	featureSvc := features.NewService(repos.Features, cfg.Features)
+	if err := featureSvc.Load(context.Background()); err != nil {
+		log.Fatal().Err(err).Msg("load feature flags")
+	}
+
+	translator := translate.NewTranslator(cfg.Translate, log)
+	scanner := scan.NewScanner(cfg.Scan, log)
+	extractor := extract.NewExtractor(cfg.Extract, log)
+	transcriber := transcribe.NewTranscriber(cfg.Transcribe, log)
+	policies := policy.NewEngine(cfg.Policy, log)
+
+	session.ConfigureKeepalive(cfg.WhatsApp)
+	manager := session.NewManager(container, repos, deliverer, publisher, featureSvc, redactor, translator, scanner, extractor, transcriber, policies, log)
+	if len(cfg.TagKeywords) > 0 {
+		manager.Enrichers().Register(enrich.NewKeywordEnricher(cfg.TagKeywords))
+	}
+
+	if _, err := manager.VerifyStoreIntegrity(context.Background(), true); err != nil {
+		log.Fatal().Err(err).Msg("store integrity check")
+	}
+
+	// Register every persisted session so API keys resolve immediately;
+	// previously connected sessions are reconnected in the background.
+	persisted, err := repos.Sessions.List(context.Background())
+	if err != nil {
+		log.Fatal().Err(err).Msg("list sessions")
+	}
+	for _, s := range persisted {
+		manager.Register(s)
+		if s.Status == models.SessionStatusConnected || s.Status == models.SessionStatusConnecting {
+			go func(id string) {
+				if err := manager.Connect(context.Background(), id); err != nil {
+					log.Error().Err(err).Str("session_id", id).Msg("reconnect on startup")
+				}
+			}(s.ID)
+		}
+	}
+
+	retentionCtx, stopRetention := context.WithCancel(context.Background())
+	defer stopRetention()
+	go retention.NewRunner(repos, storage, cfg.Retention, log).Run(retentionCtx)
+	go manager.RunMaintenanceReminders(retentionCtx, cfg.Maintenance)
+	go announce.NewRunner(repos, manager, template.NewRenderer(egressResolver, log), log).Run(retentionCtx)
+
+	linkSvc := links.NewService(cfg.Links, repos.Links)
+
+	server := api.NewServer(cfg, manager, repos, storage, egressResolver, featureSvc, linkSvc, translator, scanner, log)
+
+	go func() {
+		if err := server.Listen(); err != nil {
+			log.Fatal().Err(err).Msg("http server")
+		}
+	}()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	<-sig
+	log.Info().Msg("shutting down")
+	if err := server.Shutdown(); err != nil {
+		log.Error().Err(err).Msg("server shutdown")
+	}
+}
+
+func newLogger(cfg config.LogConfig) (zerolog.Logger, func(), error) {
+	level, err := zerolog.ParseLevel(cfg.Level)
+	if err != nil {
+		level = zerolog.InfoLevel
+	}
+	writer, closeSinks, err := logging.NewWriter(cfg)
+	if err != nil {
+		return zerolog.Logger{}, nil, err
+	}
+	return zerolog.New(writer).Level(level).With().Timestamp().Logger(), closeSinks, nil
+}
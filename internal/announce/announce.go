@@ -0,0 +1,125 @@
+// Package announce fires recurring group announcements: cron-scheduled
+// template sends to a group, evaluated in each announcement's own time
+// zone so "every Monday 09:00" means local time for that community.
+package announce
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/repository"
+	"github.com/fgsgalvaosz/zemeow/internal/session"
+	"github.com/fgsgalvaosz/zemeow/internal/template"
+)
+
+// Runner wakes every minute, matches enabled announcements against the
+// current minute in their time zone and sends the rendered template.
+type Runner struct {
+	repos     *repository.Set
+	manager   *session.Manager
+	templates *template.Renderer
+	log       zerolog.Logger
+}
+
+// NewRunner builds a Runner over the shared repositories and session
+// manager; templates renders announcement bodies per group.
+func NewRunner(repos *repository.Set, manager *session.Manager, templates *template.Renderer, log zerolog.Logger) *Runner {
+	return &Runner{
+		repos:     repos,
+		manager:   manager,
+		templates: templates,
+		log:       log.With().Str("component", "announce").Logger(),
+	}
+}
+
+// Run ticks until ctx is cancelled. Ticks align to the top of the minute
+// so a schedule never skips or double-fires across restarts of the loop.
+func (r *Runner) Run(ctx context.Context) {
+	for {
+		now := time.Now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(next.Sub(now)):
+			r.sweep(ctx, next)
+		}
+	}
+}
+
+func (r *Runner) sweep(ctx context.Context, minute time.Time) {
+	announcements, err := r.repos.Announces.ListEnabled(ctx)
+	if err != nil {
+		r.log.Error().Err(err).Msg("list announcements")
+		return
+	}
+	if len(announcements) == 0 {
+		return
+	}
+	optouts, err := r.repos.Announces.OptOutSet(ctx)
+	if err != nil {
+		r.log.Error().Err(err).Msg("load announcement opt-outs")
+		return
+	}
+	for _, a := range announcements {
+		if optouts[a.SessionID][a.GroupJID] {
+			continue
+		}
+		if !r.due(a, minute) {
+			continue
+		}
+		if err := r.fire(ctx, a); err != nil {
+			r.log.Error().Err(err).Str("session_id", a.SessionID).
+				Str("announcement_id", a.ID).Msg("announcement send failed")
+		}
+	}
+}
+
+// due evaluates the cron spec at the swept minute in the announcement's
+// time zone. last_run_at guards against a double fire when a sweep is
+// retried within the same minute.
+func (r *Runner) due(a *models.GroupAnnouncement, minute time.Time) bool {
+	loc, err := time.LoadLocation(a.Timezone)
+	if err != nil {
+		r.log.Warn().Str("announcement_id", a.ID).Str("timezone", a.Timezone).
+			Msg("unknown time zone, skipping announcement")
+		return false
+	}
+	sched, err := ParseSpec(a.Cron)
+	if err != nil {
+		r.log.Warn().Err(err).Str("announcement_id", a.ID).
+			Msg("invalid cron spec, skipping announcement")
+		return false
+	}
+	if !sched.Matches(minute.In(loc)) {
+		return false
+	}
+	return a.LastRunAt == nil || minute.Sub(*a.LastRunAt) >= time.Minute
+}
+
+func (r *Runner) fire(ctx context.Context, a *models.GroupAnnouncement) error {
+	client, ok := r.manager.Get(a.SessionID)
+	if !ok {
+		return session.ErrSessionNotFound
+	}
+	to, err := types.ParseJID(a.GroupJID)
+	if err != nil {
+		return err
+	}
+	tpl, err := r.repos.Templates.GetByName(ctx, a.SessionID, a.Template)
+	if err != nil {
+		return err
+	}
+	body := r.templates.Render(ctx, client.Session, tpl, a.GroupJID, nil)
+	if _, err := r.manager.SendText(ctx, client, to, body); err != nil {
+		return err
+	}
+	if err := r.repos.Announces.MarkRan(ctx, a.ID, time.Now().UTC()); err != nil {
+		r.log.Error().Err(err).Str("announcement_id", a.ID).Msg("record announcement run")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package announce
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed five-field cron spec: minute, hour, day of month,
+// month, day of week. Each field is a set of allowed values; Sunday is 0
+// (7 is accepted and normalized).
+type Schedule struct {
+	minute, hour, dom, month, dow map[int]bool
+}
+
+// cron field bounds, in field order.
+var cronBounds = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+
+// ParseSpec parses a cron spec supporting *, */step, ranges (a-b, with
+// optional /step) and comma lists. It deliberately leaves out the
+// non-portable extensions (@daily, L, W, #).
+func ParseSpec(spec string) (*Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron spec needs 5 fields, got %d", len(fields))
+	}
+	sets := make([]map[int]bool, 5)
+	for i, f := range fields {
+		set, err := parseCronField(f, cronBounds[i][0], cronBounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("cron field %d (%q): %w", i+1, f, err)
+		}
+		sets[i] = set
+	}
+	return &Schedule{minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4]}, nil
+}
+
+func parseCronField(field string, lo, hi int) (map[int]bool, error) {
+	set := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		rangeExpr, step := part, 1
+		if idx := strings.IndexByte(part, '/'); idx >= 0 {
+			n, err := strconv.Atoi(part[idx+1:])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step %q", part[idx+1:])
+			}
+			rangeExpr, step = part[:idx], n
+		}
+		start, end := lo, hi
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err1, err2 error
+			start, err1 = strconv.Atoi(bounds[0])
+			end, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return nil, fmt.Errorf("invalid range %q", rangeExpr)
+			}
+		default:
+			n, err := strconv.Atoi(rangeExpr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q", rangeExpr)
+			}
+			start, end = n, n
+		}
+		// Day-of-week 7 is an alias for Sunday.
+		if hi == 6 && start == 7 {
+			start = 0
+		}
+		if hi == 6 && end == 7 {
+			end = 0
+		}
+		if start < lo || end > hi || start > end {
+			return nil, fmt.Errorf("value out of range %d-%d", lo, hi)
+		}
+		for v := start; v <= end; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+// Matches reports whether t (already in the schedule's intended time
+// zone) falls on the schedule, at minute granularity.
+func (s *Schedule) Matches(t time.Time) bool {
+	return s.minute[t.Minute()] && s.hour[t.Hour()] &&
+		s.dom[t.Day()] && s.month[int(t.Month())] &&
+		s.dow[int(t.Weekday())]
+}
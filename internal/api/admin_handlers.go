@@ -0,0 +1,31 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// handleGetConfig dumps the effective runtime configuration with
+// credentials masked, for debugging layered deployments.
+func (s *Server) handleGetConfig(c *fiber.Ctx) error {
+	return respond(c, fiber.StatusOK, s.cfg.Redacted())
+}
+
+// handleIntegrityCheck reports mismatches between the sessions table and
+// the whatsmeow device store without touching either.
+func (s *Server) handleIntegrityCheck(c *fiber.Ctx) error {
+	report, err := s.manager.VerifyStoreIntegrity(c.Context(), false)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, report)
+}
+
+// handleIntegrityRepair runs the same cross-check and repairs what it
+// finds: sessions with missing device credentials are marked logged_out.
+func (s *Server) handleIntegrityRepair(c *fiber.Ctx) error {
+	report, err := s.manager.VerifyStoreIntegrity(c.Context(), true)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, report)
+}
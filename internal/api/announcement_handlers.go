@@ -0,0 +1,159 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/announce"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// handleListAnnouncements lists the session's recurring announcements.
+func (s *Server) handleListAnnouncements(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	list, err := s.repos.Announces.List(c.Context(), sess.ID)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if list == nil {
+		list = []*models.GroupAnnouncement{}
+	}
+	return respond(c, fiber.StatusOK, list)
+}
+
+type createAnnouncementRequest struct {
+	GroupJID string `json:"group_jid"`
+	Cron     string `json:"cron"`
+	// Timezone is an IANA zone name the cron spec is evaluated in;
+	// defaults to UTC.
+	Timezone string `json:"timezone"`
+	Template string `json:"template"`
+	// Enabled defaults to true; pass false to stage an announcement
+	// without arming it.
+	Enabled *bool `json:"enabled"`
+}
+
+// handleCreateAnnouncement registers a recurring announcement after
+// validating the cron spec, the time zone, the group JID and that the
+// named template exists.
+func (s *Server) handleCreateAnnouncement(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	var req createAnnouncementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	jid, err := types.ParseJID(req.GroupJID)
+	if err != nil || jid.Server != types.GroupServer {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", "group_jid must be a group jid")
+	}
+	if _, err := announce.ParseSpec(req.Cron); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_CRON", err.Error())
+	}
+	if req.Timezone == "" {
+		req.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_TIMEZONE", err.Error())
+	}
+	if req.Template == "" {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "template is required")
+	}
+	if _, err := s.repos.Templates.GetByName(c.Context(), sess.ID, req.Template); errors.Is(err, sql.ErrNoRows) {
+		return respondError(c, fiber.StatusNotFound, "TEMPLATE_NOT_FOUND", "template not found")
+	} else if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+
+	a := &models.GroupAnnouncement{
+		ID:        uuid.NewString(),
+		SessionID: sess.ID,
+		GroupJID:  jid.String(),
+		Cron:      req.Cron,
+		Timezone:  req.Timezone,
+		Template:  req.Template,
+		Enabled:   req.Enabled == nil || *req.Enabled,
+	}
+	if err := s.repos.Announces.Create(c.Context(), a); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusCreated, a)
+}
+
+// handleToggleAnnouncement enables or disables one announcement; the
+// route decides the direction.
+func (s *Server) handleToggleAnnouncement(enabled bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		sess := currentSession(c)
+		err := s.repos.Announces.SetEnabled(c.Context(), sess.ID, c.Params("announcementId"), enabled)
+		if errors.Is(err, sql.ErrNoRows) {
+			return respondError(c, fiber.StatusNotFound, "ANNOUNCEMENT_NOT_FOUND", "announcement not found")
+		}
+		if err != nil {
+			return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+		}
+		return respond(c, fiber.StatusOK, fiber.Map{"enabled": enabled})
+	}
+}
+
+// handleDeleteAnnouncement removes one announcement.
+func (s *Server) handleDeleteAnnouncement(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	err := s.repos.Announces.Delete(c.Context(), sess.ID, c.Params("announcementId"))
+	if errors.Is(err, sql.ErrNoRows) {
+		return respondError(c, fiber.StatusNotFound, "ANNOUNCEMENT_NOT_FOUND", "announcement not found")
+	}
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"deleted": c.Params("announcementId")})
+}
+
+// handleListAnnouncementOptOuts lists groups excluded from all of the
+// session's announcements.
+func (s *Server) handleListAnnouncementOptOuts(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	jids, err := s.repos.Announces.ListOptOuts(c.Context(), sess.ID)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if jids == nil {
+		jids = []string{}
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"group_jids": jids})
+}
+
+type announcementOptOutRequest struct {
+	GroupJID string `json:"group_jid"`
+}
+
+// handleAddAnnouncementOptOut excludes a group from every announcement
+// of the session; adding the same group twice is a no-op.
+func (s *Server) handleAddAnnouncementOptOut(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	var req announcementOptOutRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	jid, err := types.ParseJID(req.GroupJID)
+	if err != nil || jid.Server != types.GroupServer {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", "group_jid must be a group jid")
+	}
+	if err := s.repos.Announces.OptOutGroup(c.Context(), sess.ID, jid.String()); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"opted_out": jid.String()})
+}
+
+// handleRemoveAnnouncementOptOut lifts a group's opt-out.
+func (s *Server) handleRemoveAnnouncementOptOut(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	if err := s.repos.Announces.OptInGroup(c.Context(), sess.ID, c.Params("jid")); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"opted_in": c.Params("jid")})
+}
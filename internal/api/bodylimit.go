@@ -0,0 +1,43 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// bodyLimits rejects oversized request bodies before they are read into
+// memory, using the declared Content-Length. Ordinary JSON endpoints get
+// Server.BodyLimit; the inline-media send endpoints get the larger
+// Server.MediaBodyLimit (base64 bodies routinely run tens of megabytes).
+func (s *Server) bodyLimits(c *fiber.Ctx) error {
+	limit := s.cfg.Server.BodyLimit
+	if isMediaBodyRoute(c.Path()) {
+		limit = s.cfg.Server.MediaBodyLimit
+	}
+	if limit > 0 && c.Request().Header.ContentLength() > limit {
+		return respondError(c, fiber.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE",
+			fmt.Sprintf("request body exceeds the %d byte limit for this endpoint", limit))
+	}
+	return c.Next()
+}
+
+// isMediaBodyRoute reports whether the path accepts inline base64 media.
+func isMediaBodyRoute(path string) bool {
+	return strings.HasSuffix(path, "/send/image") ||
+		strings.HasSuffix(path, "/send/canned")
+}
+
+// decodeStreamingJSON parses the request body incrementally instead of
+// through Fiber's buffered BodyParser. Bulk endpoints with large arrays
+// use this so the decoder works straight off the request stream.
+func decodeStreamingJSON(c *fiber.Ctx, v any) error {
+	body := c.Context().RequestBodyStream()
+	if body == nil {
+		return json.Unmarshal(c.Body(), v)
+	}
+	dec := json.NewDecoder(body)
+	return dec.Decode(v)
+}
@@ -0,0 +1,281 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/media"
+	"github.com/fgsgalvaosz/zemeow/internal/session"
+)
+
+// Bulk job bounds: payload size and parallelism caps.
+const (
+	maxBulkMessages    = 1000
+	maxBulkConcurrency = 5
+	defaultBulkDelay   = time.Second
+)
+
+// bulkMessage is one entry of a bulk send job. Type selects which of the
+// remaining fields apply: "text" (body), "image" (url/base64, mime_type,
+// caption) or "location" (latitude, longitude, name).
+type bulkMessage struct {
+	To        string   `json:"to"`
+	Type      string   `json:"type"`
+	Body      string   `json:"body"`
+	Caption   string   `json:"caption"`
+	URL       string   `json:"url"`
+	Base64    string   `json:"base64"`
+	MimeType  string   `json:"mime_type"`
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+	Name      string   `json:"name"`
+}
+
+type bulkSendRequest struct {
+	Messages []bulkMessage `json:"messages"`
+	// DelayMs is the pause each worker takes between its sends; defaults
+	// to 1000ms so fan-outs stay under rate-limit radar.
+	DelayMs int `json:"delay_ms"`
+	// Concurrency is how many sends run in parallel (1..5, default 1).
+	Concurrency int `json:"concurrency"`
+}
+
+// bulkItem is a validated, media-resolved message ready to send.
+type bulkItem struct {
+	to   types.JID
+	kind string
+	body string // text body or media caption
+	data []byte
+	mime string
+	lat  float64
+	lng  float64
+	name string
+}
+
+// handleSendBulk sends a heterogeneous batch of messages with throttling
+// and bounded concurrency. Validation and media handling happen up front
+// so a malformed entry fails the whole request before anything is sent.
+// The default mode runs inline and returns per-recipient results; async
+// mode (Accept-Async or ?async=true) responds 202 and reports through
+// the operations endpoint instead.
+func (s *Server) handleSendBulk(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	var req bulkSendRequest
+	if err := decodeStreamingJSON(c, &req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if len(req.Messages) == 0 {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "messages is required")
+	}
+	if len(req.Messages) > maxBulkMessages {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY",
+			fmt.Sprintf("at most %d messages per job", maxBulkMessages))
+	}
+	delay := time.Duration(req.DelayMs) * time.Millisecond
+	if req.DelayMs <= 0 {
+		delay = defaultBulkDelay
+	}
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	if concurrency > maxBulkConcurrency {
+		concurrency = maxBulkConcurrency
+	}
+
+	items := make([]bulkItem, len(req.Messages))
+	for i, msg := range req.Messages {
+		item, err := s.prepareBulkItem(c, client, i, msg)
+		if err != nil {
+			return err
+		}
+		items[i] = item
+	}
+
+	if wantAsync(c) {
+		op := s.ops.create(client.Session.ID, "send_bulk", len(items))
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), operationTimeout)
+			defer cancel()
+			summary, results := s.runBulk(ctx, client, items, delay, concurrency, func(done int) {
+				s.ops.progress(op.ID, done)
+			})
+			summary["results"] = results
+			s.ops.finish(op.ID, summary, nil)
+		}()
+		return respond(c, fiber.StatusAccepted, fiber.Map{
+			"recipients":   len(items),
+			"status":       "queued",
+			"operation_id": op.ID,
+		})
+	}
+
+	summary, results := s.runBulk(c.Context(), client, items, delay, concurrency, nil)
+	summary["results"] = results
+	return respond(c, fiber.StatusOK, summary)
+}
+
+// prepareBulkItem validates one bulk entry and resolves its media, running
+// the same outbound media pipeline as the single-send endpoints. The index
+// is quoted in validation errors so callers can locate the bad entry.
+func (s *Server) prepareBulkItem(c *fiber.Ctx, client *session.Client, i int, msg bulkMessage) (bulkItem, error) {
+	fail := func(format string, args ...any) (bulkItem, error) {
+		return bulkItem{}, respondError(c, fiber.StatusBadRequest, "INVALID_BODY",
+			fmt.Sprintf("messages[%d]: ", i)+fmt.Sprintf(format, args...))
+	}
+	to, err := types.ParseJID(msg.To)
+	if err != nil {
+		return fail("invalid to: %v", err)
+	}
+	kind := msg.Type
+	if kind == "" {
+		kind = "text"
+	}
+	item := bulkItem{to: to, kind: kind}
+	switch kind {
+	case "text":
+		if msg.Body == "" {
+			return fail("body is required")
+		}
+		item.body = msg.Body
+	case "image":
+		data, mimeType, err := s.resolveMediaInput(c, client.Session, msg.URL, msg.Base64, msg.MimeType)
+		if err != nil {
+			return fail("invalid media: %v", err)
+		}
+		if mimeType == "" {
+			mimeType = "image/jpeg"
+		}
+		if s.optimizer.Enabled() {
+			data, mimeType, err = s.optimizer.Optimize(data, mimeType)
+			if err != nil {
+				return bulkItem{}, respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+			}
+		}
+		if s.cfg.Media.StripMetadata {
+			data = media.StripMetadata(data, mimeType)
+		}
+		if err := checkMediaPolicy(c, client.Session, mimeType, len(data)); err != nil {
+			return bulkItem{}, err
+		}
+		if err := s.scanOutboundMedia(c, data); err != nil {
+			return bulkItem{}, err
+		}
+		if err := s.checkMediaQuota(c, client.Session, len(data)); err != nil {
+			return bulkItem{}, err
+		}
+		item.data, item.mime, item.body = data, mimeType, msg.Caption
+	case "location":
+		if msg.Latitude == nil || msg.Longitude == nil {
+			return fail("latitude and longitude are required")
+		}
+		item.lat, item.lng, item.name = *msg.Latitude, *msg.Longitude, msg.Name
+	default:
+		return fail("type must be text, image or location")
+	}
+	return item, nil
+}
+
+// runBulk delivers the prepared items with a bounded worker pool, each
+// worker pausing delay between its own sends. Results come back in input
+// order; failures are recorded per recipient and never abort the job.
+func (s *Server) runBulk(ctx context.Context, client *session.Client, items []bulkItem, delay time.Duration, concurrency int, progress func(done int)) (fiber.Map, []fiber.Map) {
+	// Bulk jobs yield to interactive sends on the same session.
+	ctx = session.WithPriority(ctx, session.PriorityBulk)
+	results := make([]fiber.Map, len(items))
+	indexes := make(chan int)
+	var mu sync.Mutex
+	var done, sent, failed int
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			first := true
+			for i := range indexes {
+				if !first {
+					time.Sleep(delay)
+				}
+				first = false
+				result := s.sendBulkItem(ctx, client, items[i])
+				mu.Lock()
+				results[i] = result
+				done++
+				if result["status"] == "sent" {
+					sent++
+				} else {
+					failed++
+				}
+				if progress != nil {
+					progress(done)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range items {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	// Entries skipped by context cancellation stay nil; mark them so the
+	// caller can tell "not attempted" from "failed".
+	for i, r := range results {
+		if r == nil {
+			results[i] = fiber.Map{"to": items[i].to.String(), "status": "skipped"}
+			failed++
+		}
+	}
+	return fiber.Map{"total": len(items), "sent": sent, "failed": failed}, results
+}
+
+// sendBulkItem dispatches one prepared item and reports its outcome.
+func (s *Server) sendBulkItem(ctx context.Context, client *session.Client, item bulkItem) fiber.Map {
+	result := fiber.Map{"to": item.to.String(), "type": item.kind}
+	var err error
+	switch item.kind {
+	case "text":
+		r, e := s.manager.SendText(ctx, client, item.to, item.body)
+		if e == nil {
+			result["message_id"] = r.ID
+		}
+		err = e
+	case "image":
+		r, e := s.manager.SendImage(ctx, client, item.to, item.data, item.mime, item.body)
+		if e == nil {
+			result["message_id"] = r.ID
+			if mErr := s.repos.Usage.AddMediaBytes(ctx, client.Session.ID, int64(len(item.data))); mErr != nil {
+				s.log.Error().Err(mErr).Str("session_id", client.Session.ID).Msg("meter media bytes")
+			}
+		}
+		err = e
+	case "location":
+		r, e := s.manager.SendLocation(ctx, client, item.to, item.lat, item.lng, item.name)
+		if e == nil {
+			result["message_id"] = r.ID
+		}
+		err = e
+	}
+	if err != nil {
+		s.log.Warn().Err(err).Str("session_id", client.Session.ID).
+			Str("to", item.to.String()).Msg("bulk send")
+		result["status"] = "failed"
+		result["error"] = err.Error()
+		return result
+	}
+	result["status"] = "sent"
+	return result
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/media"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// handleListCanned returns the session's canned responses; ?q= searches
+// shortcut and body.
+func (s *Server) handleListCanned(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit <= 0 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+	list, err := s.repos.Canned.Search(c.Context(), sess.ID, c.Query("q"), limit)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if list == nil {
+		list = []*models.CannedResponse{}
+	}
+	return respond(c, fiber.StatusOK, list)
+}
+
+type putCannedRequest struct {
+	Body      string `json:"body"`
+	MediaURL  string `json:"media_url"`
+	MediaMime string `json:"media_mime"`
+}
+
+// handlePutCanned creates or replaces the canned response behind a
+// shortcut.
+func (s *Server) handlePutCanned(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	var req putCannedRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if req.Body == "" && req.MediaURL == "" {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "body or media_url is required")
+	}
+	shortcut := strings.TrimSpace(c.Params("shortcut"))
+	if shortcut == "" {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "shortcut is required")
+	}
+	cr := &models.CannedResponse{
+		SessionID: sess.ID,
+		Shortcut:  shortcut,
+		Body:      req.Body,
+		MediaURL:  req.MediaURL,
+		MediaMime: req.MediaMime,
+	}
+	if err := s.repos.Canned.Upsert(c.Context(), cr); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, cr)
+}
+
+// handleDeleteCanned removes a canned response.
+func (s *Server) handleDeleteCanned(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	if err := s.repos.Canned.Delete(c.Context(), sess.ID, c.Params("shortcut")); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"deleted": c.Params("shortcut")})
+}
+
+type sendCannedRequest struct {
+	To       string `json:"to"`
+	Shortcut string `json:"shortcut"`
+}
+
+// handleSendCanned sends the canned response behind a shortcut: media
+// entries go out as an image with the body as caption, the rest as
+// plain text.
+func (s *Server) handleSendCanned(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	var req sendCannedRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if req.Shortcut == "" {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "shortcut is required")
+	}
+	to, err := types.ParseJID(req.To)
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+
+	cr, err := s.repos.Canned.GetByShortcut(c.Context(), client.Session.ID, req.Shortcut)
+	if errors.Is(err, sql.ErrNoRows) {
+		return respondError(c, fiber.StatusNotFound, "CANNED_NOT_FOUND", "canned response not found")
+	}
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+
+	if cr.MediaURL != "" {
+		data, fetchedType, err := media.FetchFromURL(c.Context(), s.egress, client.Session, cr.MediaURL)
+		if err != nil {
+			return respondError(c, fiber.StatusBadGateway, "MEDIA_FETCH_FAILED", err.Error())
+		}
+		mimeType := cr.MediaMime
+		if mimeType == "" {
+			mimeType = fetchedType
+		}
+		if mimeType == "" {
+			mimeType = "image/jpeg"
+		}
+		if s.cfg.Media.StripMetadata {
+			data = media.StripMetadata(data, mimeType)
+		}
+		if err := checkMediaPolicy(c, client.Session, mimeType, len(data)); err != nil {
+			return err
+		}
+		if err := s.scanOutboundMedia(c, data); err != nil {
+			return err
+		}
+		resp, err := s.manager.SendImage(c.Context(), client, to, data, mimeType, cr.Body)
+		if err != nil {
+			return respondSendError(c, err)
+		}
+		return respond(c, fiber.StatusOK, fiber.Map{
+			"message_id": resp.ID,
+			"timestamp":  resp.Timestamp,
+		})
+	}
+
+	resp, err := s.manager.SendText(c.Context(), client, to, cr.Body)
+	if err != nil {
+		return respondSendError(c, err)
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"message_id": resp.ID,
+		"timestamp":  resp.Timestamp,
+	})
+}
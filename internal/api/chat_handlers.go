@@ -0,0 +1,135 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/session"
+)
+
+type backfillRequest struct {
+	// Count is how many older messages to request; defaults to 50,
+	// capped at 500.
+	Count int `json:"count"`
+}
+
+// handleBackfillChat asks the paired phone for messages older than the
+// ones stored for this chat. The phone answers asynchronously: results
+// are ingested as they arrive and a history_sync event reports the
+// counts, so this returns 202 rather than the messages themselves.
+func (s *Server) handleBackfillChat(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	jid, err := types.ParseJID(c.Params("jid"))
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+	var req backfillRequest
+	if err := c.BodyParser(&req); err != nil && err != fiber.ErrUnprocessableEntity {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if req.Count <= 0 {
+		req.Count = 50
+	}
+	if req.Count > 500 {
+		req.Count = 500
+	}
+
+	switch err := s.manager.RequestHistorySync(c.Context(), client, jid, req.Count); err {
+	case nil:
+	case session.ErrSessionNotConnected:
+		return respondError(c, fiber.StatusConflict, "NOT_CONNECTED", "session is not connected")
+	case session.ErrNoAnchorMessage:
+		return respondError(c, fiber.StatusConflict, "NO_ANCHOR",
+			"no stored message for this chat to anchor the backfill on")
+	default:
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusAccepted, fiber.Map{
+		"chat_jid":  jid.String(),
+		"requested": req.Count,
+	})
+}
+
+// handleChatSummary reports aggregate statistics for one chat: message
+// and media counts, history bounds, the most active senders (relevant
+// for groups) and the average inbound-to-reply response time. Everything
+// is computed by the database, so it stays cheap on long histories.
+func (s *Server) handleChatSummary(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	jid, err := types.ParseJID(c.Params("jid"))
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+	sum, err := s.repos.Messages.ChatSummary(c.Context(), sess.ID, jid.String())
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if sum.TotalMessages == 0 {
+		return respondError(c, fiber.StatusNotFound, "NOT_FOUND", "no stored messages for this chat")
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"chat_jid": jid.String(),
+		"summary":  sum,
+	})
+}
+
+// handleGetChatSettings returns the locally synced settings of one chat:
+// mute expiry, pinned and archived state. The data comes from app state
+// sync, so it reflects what the paired phone last reported.
+func (s *Server) handleGetChatSettings(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	jid, err := types.ParseJID(c.Params("jid"))
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+	settings, err := s.manager.ChatSettings(c.Context(), client, jid)
+	if err == session.ErrNoStore {
+		return respondError(c, fiber.StatusConflict, "NOT_PAIRED", "session has no device store yet")
+	}
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if !settings.Found {
+		return respondError(c, fiber.StatusNotFound, "NOT_FOUND", "no settings synced for this chat")
+	}
+	resp := fiber.Map{
+		"chat_jid": jid.String(),
+		"pinned":   settings.Pinned,
+		"archived": settings.Archived,
+		"muted":    settings.MutedUntil.After(time.Now()),
+	}
+	if !settings.MutedUntil.IsZero() {
+		resp["muted_until"] = settings.MutedUntil.UTC()
+	}
+	return respond(c, fiber.StatusOK, resp)
+}
+
+// handleMarkChatRead marks every unread inbound message in a chat as
+// read and sends the matching read receipts, returning how many messages
+// were processed.
+func (s *Server) handleMarkChatRead(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	jid, err := types.ParseJID(c.Params("jid"))
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+	marked, err := s.manager.MarkChatRead(c.Context(), client, jid)
+	if err != nil {
+		return respondSendError(c, err)
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"chat_jid": jid.String(),
+		"marked":   marked,
+	})
+}
@@ -0,0 +1,247 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/media"
+	"github.com/fgsgalvaosz/zemeow/internal/session"
+)
+
+type setContactLanguageRequest struct {
+	Language string `json:"language"`
+}
+
+// handleSetContactLanguage stores the contact's preferred language (BCP
+// 47 tag), consumed by the translation stage on outgoing template sends.
+// An empty language clears the preference.
+func (s *Server) handleSetContactLanguage(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	jid, err := types.ParseJID(c.Params("jid"))
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+	var req setContactLanguageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if err := s.repos.Contacts.SetLanguage(c.Context(), sess.ID, jid.ToNonAD().String(), req.Language); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"jid":      jid.ToNonAD().String(),
+		"language": req.Language,
+	})
+}
+
+// handleGetAvatar serves a contact's or group's profile picture through
+// the service, caching blobs in object storage keyed by the picture id so
+// repeated requests never refetch from the WhatsApp CDN. ?preview=true
+// returns the low-resolution thumbnail variant.
+func (s *Server) handleGetAvatar(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	jid, err := types.ParseJID(c.Params("jid"))
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+	preview := c.QueryBool("preview", false)
+
+	info, err := s.manager.ProfilePictureInfo(c.Context(), client, jid, preview)
+	if err != nil {
+		return respondSendError(c, err)
+	}
+	if info == nil || info.URL == "" {
+		return respondError(c, fiber.StatusNotFound, "NO_AVATAR", "contact has no profile picture")
+	}
+
+	variant := "full"
+	if preview {
+		variant = "preview"
+	}
+	key := fmt.Sprintf("avatars/%s/%s/%s-%s", client.Session.ID, jid.User, info.ID, variant)
+
+	data, contentType, err := s.storage.Get(c.Context(), key)
+	if err == media.ErrObjectNotFound {
+		data, contentType, err = media.FetchFromURL(c.Context(), s.egress, client.Session, info.URL)
+		if err != nil {
+			return respondError(c, fiber.StatusBadGateway, "AVATAR_FETCH_FAILED", err.Error())
+		}
+		if contentType == "" {
+			contentType = "image/jpeg"
+		}
+		if qerr := s.checkStorageQuota(c, client.Session, len(data)); qerr != nil {
+			return qerr
+		}
+		if perr := s.storage.Put(c.Context(), key, data, contentType); perr != nil {
+			s.log.Warn().Err(perr).Str("key", key).Msg("cache avatar")
+		} else {
+			s.addStorageBytes(client.Session.ID, int64(len(data)))
+		}
+	} else if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+
+	c.Set("Cache-Control", "public, max-age=86400")
+	c.Set(fiber.HeaderContentType, contentType)
+	return c.Send(data)
+}
+
+// batchContactInfoLimit caps how many JIDs one batch request may carry.
+const batchContactInfoLimit = 50
+
+type batchContactInfoRequest struct {
+	JIDs []string `json:"jids"`
+	// IncludeBusiness additionally fetches business profiles for verified
+	// accounts; this costs one extra round trip per business contact.
+	IncludeBusiness bool `json:"include_business"`
+}
+
+// handleBatchContactInfo resolves user info for up to
+// batchContactInfoLimit contacts in one GetUserInfo round trip, instead
+// of callers looping over single-contact endpoints.
+func (s *Server) handleBatchContactInfo(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	var req batchContactInfoRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if len(req.JIDs) == 0 {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "jids is required")
+	}
+	if len(req.JIDs) > batchContactInfoLimit {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY",
+			fmt.Sprintf("at most %d jids per batch", batchContactInfoLimit))
+	}
+	jids := make([]types.JID, 0, len(req.JIDs))
+	for _, raw := range req.JIDs {
+		jid, err := types.ParseJID(raw)
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_JID", "invalid jid "+raw)
+		}
+		jids = append(jids, jid)
+	}
+
+	info, err := s.manager.UserInfoBatch(c.Context(), client, jids)
+	if err != nil {
+		return respondSendError(c, err)
+	}
+
+	// Annotate LID contacts with their phone identity; lookup failures
+	// leave the annotation off.
+	normalized := make([]string, len(jids))
+	for i, jid := range jids {
+		normalized[i] = jid.String()
+	}
+	canonical, err := s.repos.IDs.CanonicalMap(c.Context(), client.Session.ID, normalized)
+	if err != nil {
+		s.log.Warn().Err(err).Str("session_id", client.Session.ID).Msg("resolve canonical jids")
+		canonical = nil
+	}
+
+	contacts := make([]fiber.Map, 0, len(info))
+	for jid, ui := range info {
+		entry := fiber.Map{
+			"jid":        jid.String(),
+			"status":     ui.Status,
+			"picture_id": ui.PictureID,
+			"devices":    len(ui.Devices),
+		}
+		if phone, ok := canonical[jid.String()]; ok && phone != jid.String() {
+			entry["canonical_jid"] = phone
+		}
+		if ui.VerifiedName != nil {
+			entry["verified_name"] = ui.VerifiedName.Details.GetVerifiedName()
+			if req.IncludeBusiness {
+				if profile, err := s.manager.BusinessProfile(c.Context(), client, jid); err == nil && profile != nil {
+					entry["business_profile"] = profile
+				}
+			}
+		}
+		contacts = append(contacts, entry)
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"contacts": contacts})
+}
+
+// handleGetBusinessProfile returns the business profile (category,
+// description, website, address, hours) of one business JID.
+func (s *Server) handleGetBusinessProfile(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	jid, err := types.ParseJID(c.Params("jid"))
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+	profile, err := s.manager.BusinessProfile(c.Context(), client, jid)
+	if err != nil {
+		return respondSendError(c, err)
+	}
+	if profile == nil {
+		return respondError(c, fiber.StatusNotFound, "NOT_FOUND", "contact has no business profile")
+	}
+	return respond(c, fiber.StatusOK, profile)
+}
+
+// contactImportLimit caps how many contacts one import request carries;
+// each becomes a mutation in a single app state patch.
+const contactImportLimit = 100
+
+type contactImportRequest struct {
+	Contacts []struct {
+		JID       string `json:"jid"`
+		FullName  string `json:"full_name"`
+		FirstName string `json:"first_name"`
+	} `json:"contacts"`
+}
+
+// handleImportContacts pushes contact names into the session's address
+// book via app state sync, so they appear saved on the phone.
+func (s *Server) handleImportContacts(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	var req contactImportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if len(req.Contacts) == 0 {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "contacts is required")
+	}
+	if len(req.Contacts) > contactImportLimit {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY",
+			fmt.Sprintf("at most %d contacts per import", contactImportLimit))
+	}
+	entries := make([]session.ContactImport, 0, len(req.Contacts))
+	for _, entry := range req.Contacts {
+		jid, err := types.ParseJID(entry.JID)
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_JID", "invalid jid "+entry.JID)
+		}
+		if entry.FullName == "" {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "full_name is required for "+entry.JID)
+		}
+		first := entry.FirstName
+		if first == "" {
+			first = entry.FullName
+		}
+		entries = append(entries, session.ContactImport{
+			JID:       jid,
+			FullName:  entry.FullName,
+			FirstName: first,
+		})
+	}
+	if err := s.manager.ImportContacts(c.Context(), client, entries); err != nil {
+		return respondSendError(c, err)
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"imported": len(entries)})
+}
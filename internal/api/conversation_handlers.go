@@ -0,0 +1,235 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/metrics"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/repository"
+)
+
+// handleListConversations lists the session's conversation entries,
+// filterable by ?state= and ?agent=.
+func (s *Server) handleListConversations(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	state := c.Query("state")
+	if state != "" && !models.ValidConversationState(state) {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_STATE", "state must be open, pending or resolved")
+	}
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit <= 0 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+	list, err := s.repos.Convos.List(c.Context(), sess.ID, state, c.Query("agent"), limit)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if list == nil {
+		list = []*models.Conversation{}
+	}
+	s.annotateConversationJIDs(c, sess.ID, list)
+	return respond(c, fiber.StatusOK, list)
+}
+
+// annotateConversationJIDs resolves chat JIDs through the identity store
+// so conversations on LID chats carry their phone identity alongside.
+// Lookup failures leave the annotation off.
+func (s *Server) annotateConversationJIDs(c *fiber.Ctx, sessionID string, list []*models.Conversation) {
+	jids := make([]string, 0, len(list))
+	for _, conv := range list {
+		jids = append(jids, conv.ChatJID)
+	}
+	canonical, err := s.repos.IDs.CanonicalMap(c.Context(), sessionID, jids)
+	if err != nil {
+		s.log.Warn().Err(err).Str("session_id", sessionID).Msg("resolve canonical jids")
+		return
+	}
+	for _, conv := range list {
+		if jid, ok := canonical[conv.ChatJID]; ok && jid != conv.ChatJID {
+			conv.CanonicalJID = jid
+		}
+	}
+}
+
+// handleGetConversation returns the workflow entry for one chat.
+func (s *Server) handleGetConversation(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	conv, err := s.repos.Convos.Get(c.Context(), sess.ID, c.Params("jid"))
+	if errors.Is(err, sql.ErrNoRows) {
+		return respondError(c, fiber.StatusNotFound, "NOT_FOUND", "no conversation entry for chat")
+	}
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, conv)
+}
+
+type putConversationRequest struct {
+	State string  `json:"state"`
+	Agent *string `json:"agent"`
+}
+
+// handlePutConversation sets state and/or assignment for a chat and
+// emits a conversation_update event so integrations can react.
+func (s *Server) handlePutConversation(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	var req putConversationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+
+	conv, err := s.repos.Convos.Get(c.Context(), sess.ID, c.Params("jid"))
+	if errors.Is(err, sql.ErrNoRows) {
+		conv = &models.Conversation{
+			SessionID: sess.ID,
+			ChatJID:   c.Params("jid"),
+			State:     models.ConversationStateOpen,
+		}
+	} else if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+
+	prevState := conv.State
+	prevResolvedAt := conv.ResolvedAt
+	if req.State != "" {
+		if !models.ValidConversationState(req.State) {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_STATE", "state must be open, pending or resolved")
+		}
+		conv.State = req.State
+	}
+	if req.Agent != nil {
+		conv.Agent = *req.Agent
+	}
+	resolving := conv.State == models.ConversationStateResolved && prevState != models.ConversationStateResolved
+	if resolving {
+		now := time.Now().UTC()
+		conv.ResolvedAt = &now
+	}
+	if err := s.repos.Convos.Upsert(c.Context(), conv); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+
+	s.manager.Dispatch(c.Context(), sess, models.EventConversation, map[string]any{
+		"chat_jid": conv.ChatJID,
+		"state":    conv.State,
+		"agent":    conv.Agent,
+		"reason":   "api_update",
+	})
+	if resolving {
+		s.recordSLA(c, sess, conv, prevResolvedAt)
+		s.dispatchTranscript(c, sess, conv, prevResolvedAt)
+	}
+	return respond(c, fiber.StatusOK, conv)
+}
+
+// recordSLA measures a freshly resolved conversation: the clock starts
+// at the first inbound message since the previous resolution, and stops
+// at the first reply (time to first response) and at the resolution
+// itself. Conversations without inbound traffic produce no measurement.
+// Failures are logged but never fail the resolution.
+func (s *Server) recordSLA(c *fiber.Ctx, sess *models.Session, conv *models.Conversation, since *time.Time) {
+	var from time.Time
+	if since != nil {
+		from = *since
+	}
+	firstInbound, firstReply, err := s.repos.Messages.FirstExchange(c.Context(), sess.ID, conv.ChatJID, from)
+	if err != nil {
+		s.log.Warn().Err(err).Str("session_id", sess.ID).
+			Str("chat_jid", conv.ChatJID).Msg("sla first exchange")
+		return
+	}
+	if firstInbound == nil {
+		return
+	}
+	sla := &models.ConversationSLA{
+		SessionID:         sess.ID,
+		ChatJID:           conv.ChatJID,
+		Agent:             conv.Agent,
+		ResolutionSeconds: conv.ResolvedAt.Sub(*firstInbound).Seconds(),
+		ResolvedAt:        *conv.ResolvedAt,
+	}
+	if firstReply != nil {
+		gap := firstReply.Sub(*firstInbound).Seconds()
+		sla.FirstResponseSeconds = &gap
+		metrics.FirstResponseSeconds.WithLabelValues(sess.ID).Observe(gap)
+	}
+	metrics.ResolutionSeconds.WithLabelValues(sess.ID).Observe(sla.ResolutionSeconds)
+	if err := s.repos.SLA.Record(c.Context(), sla); err != nil {
+		s.log.Warn().Err(err).Str("session_id", sess.ID).
+			Str("chat_jid", conv.ChatJID).Msg("record sla")
+	}
+}
+
+// handleGetSLA reports per-agent response-time aggregates from recorded
+// resolutions, narrowed by ?agent= and ?since= (RFC 3339).
+func (s *Server) handleGetSLA(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	var since time.Time
+	if raw := c.Query("since"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "since must be RFC 3339")
+		}
+		since = parsed
+	}
+	aggs, err := s.repos.SLA.Aggregates(c.Context(), sess.ID, c.Query("agent"), since)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if aggs == nil {
+		aggs = []*repository.SLAAggregate{}
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"agents": aggs})
+}
+
+// transcriptLimit caps how many messages one conversation_transcript
+// event carries; longer conversations are truncated at the old end.
+const transcriptLimit = 500
+
+// dispatchTranscript emits the conversation_transcript event for a
+// freshly resolved conversation: every stored message of the chat since
+// the previous resolution, in chronological order. Sessions opt in by
+// listing the event type in their events config. Transcript failures are
+// logged but never fail the resolution itself.
+func (s *Server) dispatchTranscript(c *fiber.Ctx, sess *models.Session, conv *models.Conversation, since *time.Time) {
+	var from time.Time
+	if since != nil {
+		from = *since
+	}
+	msgs, err := s.repos.Messages.ListChatSince(c.Context(), sess.ID, conv.ChatJID, from, transcriptLimit)
+	if err != nil {
+		s.log.Warn().Err(err).Str("session_id", sess.ID).
+			Str("chat_jid", conv.ChatJID).Msg("conversation transcript")
+		return
+	}
+	entries := make([]fiber.Map, 0, len(msgs))
+	for _, m := range msgs {
+		entries = append(entries, fiber.Map{
+			"message_id": m.MessageID,
+			"sender_jid": m.SenderJID,
+			"from_me":    m.FromMe,
+			"type":       m.Type,
+			"body":       m.Body,
+			"timestamp":  m.Timestamp,
+			// Rendered in the session's timezone and locale for export
+			// consumers that show transcripts to humans.
+			"timestamp_local": sess.FormatTimestamp(m.Timestamp),
+		})
+	}
+	payload := map[string]any{
+		"chat_jid":    conv.ChatJID,
+		"agent":       conv.Agent,
+		"resolved_at": conv.ResolvedAt,
+		"messages":    entries,
+		"count":       len(entries),
+		"truncated":   len(entries) == transcriptLimit,
+	}
+	if since != nil {
+		payload["since"] = *since
+	}
+	s.manager.Dispatch(c.Context(), sess, models.EventTranscript, payload)
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// handleGetDefaults returns the stored session defaults; an empty object
+// when none are configured.
+func (s *Server) handleGetDefaults(c *fiber.Ctx) error {
+	d, err := s.repos.Defaults.Get(c.Context())
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if d == nil {
+		d = &models.SessionDefaults{}
+	}
+	return respond(c, fiber.StatusOK, d)
+}
+
+// handleSetDefaults replaces the session defaults document. Fields left
+// out of the body carry no default and fall back to the hardcoded
+// server values on session creation.
+func (s *Server) handleSetDefaults(c *fiber.Ctx) error {
+	var d models.SessionDefaults
+	if err := c.BodyParser(&d); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if d.ReceiptSampleRate != nil && (*d.ReceiptSampleRate < 0 || *d.ReceiptSampleRate > 1) {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "receipt_sample_rate must be within [0, 1]")
+	}
+	for name, v := range map[string]*int{
+		"presence_min_interval":      d.PresenceMinInterval,
+		"presence_heartbeat_seconds": d.PresenceHeartbeatSeconds,
+		"inline_media_max_bytes":     d.InlineMediaMaxBytes,
+		"retention_days":             d.RetentionDays,
+		"dedupe_window_seconds":      d.DedupeWindowSeconds,
+	} {
+		if v != nil && *v < 0 {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", name+" must be >= 0")
+		}
+	}
+	if err := s.repos.Defaults.Set(c.Context(), &d); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, &d)
+}
+
+// handleClearDefaults removes the stored defaults.
+func (s *Server) handleClearDefaults(c *fiber.Ctx) error {
+	if err := s.repos.Defaults.Clear(c.Context()); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"cleared": true})
+}
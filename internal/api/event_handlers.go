@@ -0,0 +1,49 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// replayMaxEvents caps a single replay run so a wide time range cannot
+// flood the consumer or pin the database.
+const replayMaxEvents = 10000
+
+type replayEventsRequest struct {
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	Events []string  `json:"events"`
+}
+
+// handleReplayEvents re-emits stored events in a time range to the
+// session's webhook and queue. Replayed envelopes carry a "replayed"
+// marker so consumers can distinguish them from live traffic.
+func (s *Server) handleReplayEvents(c *fiber.Ctx) error {
+	sess := currentSession(c)
+
+	var req replayEventsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if req.From.IsZero() || req.To.IsZero() {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_RANGE", "from and to are required")
+	}
+	if !req.To.After(req.From) {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_RANGE", "to must be after from")
+	}
+
+	stored, err := s.repos.Events.ListByRange(c.Context(), sess.ID, req.From, req.To, req.Events, replayMaxEvents)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+
+	// The replay itself runs in the background; the response reports how
+	// many events were scheduled and whether the cap truncated the range.
+	go s.manager.Replay(sess, stored)
+
+	return respond(c, fiber.StatusAccepted, fiber.Map{
+		"scheduled": len(stored),
+		"truncated": len(stored) == replayMaxEvents,
+	})
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/features"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// handleListFeatures returns the resolved global flag view plus every
+// per-session override, along with the names the server consults.
+func (s *Server) handleListFeatures(c *fiber.Ctx) error {
+	global, overrides := s.features.Snapshot()
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"known":     features.KnownFlags(),
+		"global":    global,
+		"overrides": overrides,
+	})
+}
+
+type setFeatureRequest struct {
+	Enabled   *bool  `json:"enabled"`
+	SessionID string `json:"session_id"`
+}
+
+// handleSetFeature sets a flag globally or, when session_id is given, for
+// a single session.
+func (s *Server) handleSetFeature(c *fiber.Ctx) error {
+	var req setFeatureRequest
+	if err := c.BodyParser(&req); err != nil || req.Enabled == nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "enabled is required")
+	}
+	if req.SessionID != "" {
+		if _, ok := s.manager.Get(req.SessionID); !ok {
+			return respondError(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "session not found")
+		}
+	}
+	flag := &models.FeatureFlag{
+		Name:      c.Params("name"),
+		SessionID: req.SessionID,
+		Enabled:   *req.Enabled,
+	}
+	if err := s.features.Set(c.Context(), flag); err != nil {
+		s.log.Error().Err(err).Str("flag", flag.Name).Msg("set feature flag")
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", "failed to store flag")
+	}
+	return respond(c, fiber.StatusOK, flag)
+}
+
+// handleClearFeature deletes a stored flag so resolution falls back to
+// the global value (for session overrides) or the config default.
+func (s *Server) handleClearFeature(c *fiber.Ctx) error {
+	name := c.Params("name")
+	sessionID := c.Query("session_id")
+	if err := s.features.Clear(c.Context(), name, sessionID); err != nil {
+		s.log.Error().Err(err).Str("flag", name).Msg("clear feature flag")
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", "failed to clear flag")
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"cleared": name})
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/policy"
+	"github.com/fgsgalvaosz/zemeow/internal/session"
+)
+
+// sessionClient resolves the live client for the authenticated session.
+func (s *Server) sessionClient(c *fiber.Ctx) (*session.Client, error) {
+	client, ok := s.manager.Get(currentSession(c).ID)
+	if !ok {
+		return nil, respondError(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "session not found")
+	}
+	return client, nil
+}
+
+// respondSendError maps manager send-path errors onto API error codes.
+func respondSendError(c *fiber.Ctx, err error) error {
+	var violation *policy.Violation
+	if errors.As(err, &violation) {
+		return respondError(c, fiber.StatusUnprocessableEntity, "POLICY_VIOLATION", err.Error())
+	}
+	switch err {
+	case session.ErrSessionPaused:
+		return respondError(c, fiber.StatusConflict, "PAUSED", "session is paused")
+	case session.ErrSessionNotConnected:
+		return respondError(c, fiber.StatusConflict, "NOT_CONNECTED", "session is not connected")
+	case session.ErrCircuitOpen:
+		return respondError(c, fiber.StatusServiceUnavailable, "CIRCUIT_OPEN", "send circuit is open, retry later")
+	case session.ErrDuplicateSend:
+		return respondError(c, fiber.StatusConflict, "DUPLICATE_SEND", "identical text was sent to this recipient within the dedupe window")
+	case session.ErrQuotaExceeded:
+		return respondError(c, fiber.StatusTooManyRequests, "QUOTA_EXCEEDED", "daily message quota exceeded")
+	default:
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+}
+
+func parseGroupJID(c *fiber.Ctx) (types.JID, error) {
+	jid, err := types.ParseJID(c.Params("jid"))
+	if err != nil {
+		return types.EmptyJID, err
+	}
+	if jid.Server != types.GroupServer {
+		return types.EmptyJID, errNotAGroup
+	}
+	return jid, nil
+}
+
+var errNotAGroup = fiber.NewError(fiber.StatusBadRequest, "not a group jid")
+
+// handleListGroups lists the session's groups as slim summaries served
+// from the metadata cache. ?q filters by name substring, ?sort orders by
+// name (default), members or created, and ?limit/?offset page through
+// the result. ?refresh=true repopulates the cache from the server first.
+func (s *Server) handleListGroups(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	var groups []*types.GroupInfo
+	if c.QueryBool("refresh") {
+		groups, err = s.manager.JoinedGroups(c.Context(), client)
+	} else {
+		groups, err = s.manager.JoinedGroupsCached(c.Context(), client)
+	}
+	if err != nil {
+		return respondSendError(c, err)
+	}
+
+	if q := strings.ToLower(c.Query("q")); q != "" {
+		filtered := groups[:0]
+		for _, g := range groups {
+			if strings.Contains(strings.ToLower(g.Name), q) {
+				filtered = append(filtered, g)
+			}
+		}
+		groups = filtered
+	}
+
+	switch c.Query("sort", "name") {
+	case "members":
+		sort.Slice(groups, func(i, j int) bool {
+			return len(groups[i].Participants) > len(groups[j].Participants)
+		})
+	case "created":
+		sort.Slice(groups, func(i, j int) bool {
+			return groups[i].GroupCreated.After(groups[j].GroupCreated)
+		})
+	default:
+		sort.Slice(groups, func(i, j int) bool {
+			return strings.ToLower(groups[i].Name) < strings.ToLower(groups[j].Name)
+		})
+	}
+
+	total := len(groups)
+	offset := c.QueryInt("offset", 0)
+	if offset < 0 {
+		offset = 0
+	}
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit <= 0 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	summaries := make([]fiber.Map, 0, end-offset)
+	for _, g := range groups[offset:end] {
+		summaries = append(summaries, fiber.Map{
+			"jid":               g.JID.String(),
+			"name":              g.Name,
+			"topic":             g.Topic,
+			"created":           g.GroupCreated.UTC(),
+			"participant_count": len(g.Participants),
+			"announce_only":     g.IsAnnounce,
+			"locked":            g.IsLocked,
+		})
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"groups": summaries,
+		"total":  total,
+		"offset": offset,
+	})
+}
+
+func (s *Server) handleGetGroup(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	jid, err := parseGroupJID(c)
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+	info, err := s.manager.GroupInfo(c.Context(), client, jid, false)
+	if err != nil {
+		return respondSendError(c, err)
+	}
+	return respond(c, fiber.StatusOK, info)
+}
+
+// handleRefreshGroup bypasses the metadata cache and refetches the group
+// from the server, returning the fresh metadata.
+func (s *Server) handleRefreshGroup(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	jid, err := parseGroupJID(c)
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+	info, err := s.manager.GroupInfo(c.Context(), client, jid, true)
+	if err != nil {
+		return respondSendError(c, err)
+	}
+	return respond(c, fiber.StatusOK, info)
+}
+
+// handleExportParticipants streams the group member list as CSV for
+// community managers: phone number, full JID, role and display name.
+// WhatsApp does not expose join dates in group metadata, so none are
+// included.
+func (s *Server) handleExportParticipants(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	jid, err := parseGroupJID(c)
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+	info, err := s.manager.GroupInfo(c.Context(), client, jid, c.QueryBool("refresh"))
+	if err != nil {
+		return respondSendError(c, err)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"phone", "jid", "role", "display_name"})
+	for _, p := range info.Participants {
+		role := "member"
+		switch {
+		case p.IsSuperAdmin:
+			role = "superadmin"
+		case p.IsAdmin:
+			role = "admin"
+		}
+		w.Write([]string{p.JID.User, p.JID.String(), role, p.DisplayName})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%q", "participants-"+jid.User+".csv"))
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
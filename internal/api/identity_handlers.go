@@ -0,0 +1,88 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// handleListIdentities returns every phone/LID mapping of the session.
+func (s *Server) handleListIdentities(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	list, err := s.repos.IDs.List(c.Context(), sess.ID)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if list == nil {
+		list = []*models.Identity{}
+	}
+	return respond(c, fiber.StatusOK, list)
+}
+
+// handleResolveIdentity resolves ?jid= to its canonical identity with
+// both identifiers listed.
+func (s *Server) handleResolveIdentity(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	jid := c.Query("jid")
+	if jid == "" {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "jid query parameter is required")
+	}
+	id, err := s.repos.IDs.Resolve(c.Context(), sess.ID, jid)
+	if errors.Is(err, sql.ErrNoRows) {
+		return respondError(c, fiber.StatusNotFound, "NOT_FOUND", "no identity mapping for jid")
+	}
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"canonical_jid": id.Canonical(),
+		"phone_jid":     id.PhoneJID,
+		"lid_jid":       id.LIDJID,
+	})
+}
+
+type putIdentityRequest struct {
+	PhoneJID string `json:"phone_jid"`
+	LIDJID   string `json:"lid_jid"`
+}
+
+// handlePutIdentity stores a phone/LID mapping manually, for identities
+// the automatic capture has not seen yet.
+func (s *Server) handlePutIdentity(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	var req putIdentityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	phone, err := types.ParseJID(req.PhoneJID)
+	if err != nil || phone.Server != types.DefaultUserServer {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", "phone_jid must be a phone-number JID")
+	}
+	lid, err := types.ParseJID(req.LIDJID)
+	if err != nil || !strings.EqualFold(lid.Server, types.HiddenUserServer) {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", "lid_jid must be a LID JID")
+	}
+	id := &models.Identity{
+		SessionID: sess.ID,
+		PhoneJID:  phone.ToNonAD().String(),
+		LIDJID:    lid.ToNonAD().String(),
+	}
+	if err := s.repos.IDs.Upsert(c.Context(), id); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, id)
+}
+
+// handleDeleteIdentity removes the mapping containing the given JID.
+func (s *Server) handleDeleteIdentity(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	if err := s.repos.IDs.Delete(c.Context(), sess.ID, c.Params("jid")); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"deleted": c.Params("jid")})
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/repository"
+)
+
+// inboxAuth resolves which sessions the presented key may read: the
+// admin key sees every session, a session API key sees its own. The
+// accessible ids land in locals for the handler.
+func (s *Server) inboxAuth(c *fiber.Ctx) error {
+	key := c.Get("Authorization")
+	if key == s.cfg.Server.AdminKey {
+		sessions, err := s.repos.Sessions.List(c.Context())
+		if err != nil {
+			return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+		}
+		ids := make([]string, len(sessions))
+		for i, sess := range sessions {
+			ids[i] = sess.ID
+		}
+		c.Locals("inbox_sessions", ids)
+		return c.Next()
+	}
+
+	sess, err := s.repos.Sessions.GetByAPIKey(c.Context(), key)
+	if err == repository.ErrNotFound {
+		return respondError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "invalid api key")
+	}
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	c.Locals("inbox_sessions", []string{sess.ID})
+	return c.Next()
+}
+
+// annotateCanonicalJIDs resolves chat JIDs through the identity store so
+// LID chats carry their phone identity alongside. Lookup failures leave
+// the annotation off.
+func (s *Server) annotateCanonicalJIDs(c *fiber.Ctx, chats []*models.InboxChat) {
+	bySession := make(map[string][]string)
+	for _, ch := range chats {
+		bySession[ch.SessionID] = append(bySession[ch.SessionID], ch.ChatJID)
+	}
+	canonical := make(map[string]map[string]string, len(bySession))
+	for sessionID, jids := range bySession {
+		m, err := s.repos.IDs.CanonicalMap(c.Context(), sessionID, jids)
+		if err != nil {
+			s.log.Warn().Err(err).Str("session_id", sessionID).Msg("resolve canonical jids")
+			continue
+		}
+		canonical[sessionID] = m
+	}
+	for _, ch := range chats {
+		if jid, ok := canonical[ch.SessionID][ch.ChatJID]; ok && jid != ch.ChatJID {
+			ch.CanonicalJID = jid
+		}
+	}
+}
+
+// annotateReactions fills in aggregated reaction counts for each chat's
+// last message, grouped per session like the canonical JID lookup.
+// Failures leave the counts off.
+func (s *Server) annotateReactions(c *fiber.Ctx, chats []*models.InboxChat) {
+	bySession := make(map[string][]string)
+	for _, ch := range chats {
+		bySession[ch.SessionID] = append(bySession[ch.SessionID], ch.LastMessageID)
+	}
+	for sessionID, ids := range bySession {
+		summary, err := s.repos.Messages.ReactionSummary(c.Context(), sessionID, ids)
+		if err != nil {
+			s.log.Warn().Err(err).Str("session_id", sessionID).Msg("inbox reaction summary")
+			continue
+		}
+		for _, ch := range chats {
+			if ch.SessionID == sessionID {
+				ch.LastMessageReactions = summary[ch.LastMessageID]
+			}
+		}
+	}
+}
+
+// handleInbox returns recent chats across every accessible session.
+// Filters: ?session_id= narrows to one session, ?unread_only=true drops
+// chats with no unread inbound messages.
+func (s *Server) handleInbox(c *fiber.Ctx) error {
+	ids := c.Locals("inbox_sessions").([]string)
+	if filter := c.Query("session_id"); filter != "" {
+		allowed := false
+		for _, id := range ids {
+			if id == filter {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return respondError(c, fiber.StatusForbidden, "FORBIDDEN", "session not accessible with this key")
+		}
+		ids = []string{filter}
+	}
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit <= 0 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+
+	chats, err := s.repos.Messages.Inbox(c.Context(), ids, c.QueryBool("unread_only", false), limit)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if chats == nil {
+		chats = []*models.InboxChat{}
+	}
+	s.annotateCanonicalJIDs(c, chats)
+	s.annotateReactions(c, chats)
+	return respond(c, fiber.StatusOK, chats)
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// handleListLegalHolds returns every hold on the session.
+func (s *Server) handleListLegalHolds(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	holds, err := s.repos.Holds.ListBySession(c.Context(), sess.ID)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if holds == nil {
+		holds = []*models.LegalHold{}
+	}
+	return respond(c, fiber.StatusOK, holds)
+}
+
+type legalHoldRequest struct {
+	// ChatJID scopes the hold to one chat; empty holds the whole session.
+	ChatJID string `json:"chat_jid"`
+	Reason  string `json:"reason"`
+}
+
+// handleAddLegalHold places a hold that blocks retention purging.
+func (s *Server) handleAddLegalHold(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	var req legalHoldRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	hold := &models.LegalHold{
+		SessionID: sess.ID,
+		ChatJID:   req.ChatJID,
+		Reason:    req.Reason,
+	}
+	if err := s.repos.Holds.Add(c.Context(), hold); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusCreated, hold)
+}
+
+// handleRemoveLegalHold releases a hold. ?chat_jid= selects the chat
+// hold; omitting it releases the session-wide hold.
+func (s *Server) handleRemoveLegalHold(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	chatJID := c.Query("chat_jid")
+	if err := s.repos.Holds.Remove(c.Context(), sess.ID, chatJID); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"released": true})
+}
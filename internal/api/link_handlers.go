@@ -0,0 +1,47 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// handleLinkRedirect serves the public /l/:code redirector: bump the
+// click counter, notify the owning session via a link_click event and
+// send the visitor on their way.
+func (s *Server) handleLinkRedirect(c *fiber.Ctx) error {
+	link, err := s.repos.Links.RecordClick(c.Context(), c.Params("code"))
+	if errors.Is(err, sql.ErrNoRows) {
+		return respondError(c, fiber.StatusNotFound, "NOT_FOUND", "unknown link")
+	}
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+
+	if client, ok := s.manager.Get(link.SessionID); ok {
+		s.manager.Dispatch(c.Context(), client.Session, models.EventLinkClick, map[string]any{
+			"code":       link.Code,
+			"target_url": link.TargetURL,
+			"campaign":   link.Campaign,
+			"message_id": link.MessageID,
+			"clicks":     link.Clicks,
+		})
+	}
+	return c.Redirect(link.TargetURL, fiber.StatusFound)
+}
+
+// handleLinkStats returns per-campaign click aggregates for the session.
+func (s *Server) handleLinkStats(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	stats, err := s.repos.Links.CampaignStats(c.Context(), sess.ID)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if stats == nil {
+		stats = []*models.CampaignStats{}
+	}
+	return respond(c, fiber.StatusOK, stats)
+}
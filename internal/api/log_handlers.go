@@ -0,0 +1,58 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/session"
+)
+
+// handleGetSessionLogs returns the session's buffered whatsmeow client
+// log entries, oldest first, with the effective capture level.
+func (s *Server) handleGetSessionLogs(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	entries := s.manager.SessionLogs(client)
+	if entries == nil {
+		entries = []session.SessionLogEntry{}
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"level":   s.manager.SessionLogLevel(client),
+		"entries": entries,
+	})
+}
+
+type setLogLevelRequest struct {
+	// Level is the capture floor: debug, info, warn, error or off.
+	Level string `json:"level"`
+}
+
+// handleSetSessionLogLevel raises (or lowers) the session's log capture
+// level at runtime; the override lasts until restart.
+func (s *Server) handleSetSessionLogLevel(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	var req setLogLevelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if err := s.manager.SetSessionLogLevel(client, req.Level); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_LEVEL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"level": req.Level})
+}
+
+// handleResetSessionLogLevel restores the default capture level.
+func (s *Server) handleResetSessionLogLevel(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	if err := s.manager.SetSessionLogLevel(client, "warn"); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"level": "warn"})
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// mediaObjectPath is the bucket key for a deduplicated media blob. Blobs
+// live in a shared content-addressed namespace (not under a session
+// prefix), since identical media sent through different sessions is
+// stored only once.
+func mediaObjectPath(sha string) string {
+	return "media/objects/" + sha[:2] + "/" + sha
+}
+
+// archiveMedia stores a media blob deduplicated by content hash: the
+// hash is checked against media_objects first, and an already-archived
+// blob only gains a reference instead of being written again. It returns
+// the bucket path and the hex hash; callers record the hash on the
+// owning message row (media_sha256) so retention releases the reference
+// when the row's content is purged.
+func (s *Server) archiveMedia(ctx context.Context, data []byte, contentType string) (string, string, error) {
+	sum := sha256.Sum256(data)
+	sha := hex.EncodeToString(sum[:])
+
+	path, isNew, err := s.repos.Media.AddRef(ctx, sha, mediaObjectPath(sha), contentType, int64(len(data)))
+	if err != nil {
+		return "", "", err
+	}
+	if !isNew {
+		return path, sha, nil
+	}
+	if err := s.storage.Put(ctx, path, data, contentType); err != nil {
+		// Roll the reference back so a failed write does not leave a
+		// phantom object row pointing at nothing.
+		if _, _, rerr := s.repos.Media.Release(ctx, sha); rerr != nil {
+			s.log.Error().Err(rerr).Str("sha256", sha).Msg("roll back media reference")
+		}
+		return "", "", err
+	}
+	return path, sha, nil
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/scan"
+)
+
+// handleGetMediaPolicy returns the session's media policy.
+func (s *Server) handleGetMediaPolicy(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	if sess.MediaPolicy == nil {
+		return respondError(c, fiber.StatusNotFound, "NOT_CONFIGURED", "media policy not configured")
+	}
+	return respond(c, fiber.StatusOK, sess.MediaPolicy)
+}
+
+// handleSetMediaPolicy replaces the session's media policy.
+func (s *Server) handleSetMediaPolicy(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	var p models.MediaPolicy
+	if err := c.BodyParser(&p); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if err := p.Validate(); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	sess.MediaPolicy = &p
+	if err := s.repos.Sessions.Update(c.Context(), sess); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, sess.MediaPolicy)
+}
+
+// handleDeleteMediaPolicy removes the media policy, lifting all limits.
+func (s *Server) handleDeleteMediaPolicy(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	sess.MediaPolicy = nil
+	if err := s.repos.Sessions.Update(c.Context(), sess); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"deleted": true})
+}
+
+// scanOutboundMedia runs the malware scanner over outbound media and
+// writes the rejection response when it is infected. Scanner failures
+// fail closed: enterprise deployments enabling scanning expect no
+// unscanned media to leave the service.
+func (s *Server) scanOutboundMedia(c *fiber.Ctx, data []byte) error {
+	if !s.scanner.Enabled() {
+		return nil
+	}
+	result, err := s.scanner.Scan(c.Context(), data)
+	if err != nil {
+		return respondError(c, fiber.StatusBadGateway, "SCAN_FAILED", err.Error())
+	}
+	if result.Verdict == scan.VerdictInfected {
+		return respondError(c, fiber.StatusUnprocessableEntity, "MEDIA_INFECTED",
+			"media rejected by malware scan: "+result.Signature)
+	}
+	return nil
+}
+
+// checkMediaPolicy enforces the session media policy on outbound media
+// and writes the rejection response itself. A nil return means the
+// media passed (or no policy is set).
+func checkMediaPolicy(c *fiber.Ctx, sess *models.Session, mimeType string, size int) error {
+	if sess.MediaPolicy == nil {
+		return nil
+	}
+	if err := sess.MediaPolicy.Check(mimeType, int64(size)); err != nil {
+		return respondError(c, fiber.StatusRequestEntityTooLarge, "MEDIA_REJECTED", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/repository"
+	"github.com/fgsgalvaosz/zemeow/internal/session"
+)
+
+type revokeMessageRequest struct {
+	ChatJID string `json:"chat_jid"`
+}
+
+// handleRevokeMessage revokes a message for everyone. Revoking another
+// participant's message works only in groups where the session holds
+// admin rights.
+func (s *Server) handleRevokeMessage(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	var req revokeMessageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	chat, err := types.ParseJID(req.ChatJID)
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+
+	resp, err := s.manager.DeleteMessage(c.Context(), client, chat, c.Params("messageId"))
+	switch err {
+	case nil:
+		return respond(c, fiber.StatusOK, fiber.Map{
+			"message_id": resp.ID,
+			"timestamp":  resp.Timestamp,
+		})
+	case session.ErrRevokeTargetUnknown:
+		return respondError(c, fiber.StatusNotFound, "MESSAGE_NOT_FOUND", "message to revoke was not found in the store")
+	case session.ErrNotGroupAdmin:
+		return respondError(c, fiber.StatusForbidden, "FORBIDDEN", "session is not an admin of this group")
+	case session.ErrRevokeNotOwn:
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "only own messages can be revoked outside groups")
+	default:
+		return respondSendError(c, err)
+	}
+}
+
+// handleDeleteMessage is the undo-send convenience path: the chat is
+// resolved from the store and the revoke window is validated before the
+// revoke goes out. Only the session's own messages qualify; moderator
+// revocation of others' messages stays on the body-based revoke route.
+func (s *Server) handleDeleteMessage(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	resp, err := s.manager.RevokeOwnMessage(c.Context(), client, c.Params("messageId"))
+	switch err {
+	case nil:
+		return respond(c, fiber.StatusOK, fiber.Map{
+			"message_id": resp.ID,
+			"timestamp":  resp.Timestamp,
+			"revoked":    c.Params("messageId"),
+		})
+	case session.ErrRevokeTargetUnknown:
+		return respondError(c, fiber.StatusNotFound, "MESSAGE_NOT_FOUND", "message to revoke was not found in the store")
+	case session.ErrRevokeNotOwn:
+		return respondError(c, fiber.StatusForbidden, "FORBIDDEN", "only own messages can be deleted this way")
+	case session.ErrRevokeWindowExpired:
+		return respondError(c, fiber.StatusConflict, "REVOKE_WINDOW_EXPIRED", "message is older than the revoke window")
+	default:
+		return respondSendError(c, err)
+	}
+}
+
+// handleMessageTimeline returns the delivery timeline of a message: when
+// it was sent and every delivery/read/played receipt recorded since.
+func (s *Server) handleMessageTimeline(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	messageID := c.Params("messageId")
+
+	msg, err := s.repos.Messages.GetByMessageID(c.Context(), sess.ID, messageID)
+	if err == repository.ErrNotFound {
+		return respondError(c, fiber.StatusNotFound, "MESSAGE_NOT_FOUND", "message not found")
+	}
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+
+	receipts, err := s.repos.Receipts.ListByMessageID(c.Context(), sess.ID, messageID)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+
+	timeline := make([]fiber.Map, 0, len(receipts)+1)
+	timeline = append(timeline, fiber.Map{
+		"type":      "sent",
+		"jid":       msg.SenderJID,
+		"timestamp": msg.Timestamp,
+	})
+	for _, rc := range receipts {
+		timeline = append(timeline, fiber.Map{
+			"type":      rc.Type,
+			"jid":       rc.SenderJID,
+			"timestamp": rc.Timestamp,
+		})
+	}
+
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"message_id": messageID,
+		"chat_jid":   msg.ChatJID,
+		"from_me":    msg.FromMe,
+		"timeline":   timeline,
+	})
+}
+
+// handleGetThread returns the ordered reply thread rooted at the given
+// message id, so support tools can render reply chains directly.
+func (s *Server) handleGetThread(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit <= 0 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+	msgs, err := s.repos.Messages.ListThread(c.Context(), sess.ID, c.Params("rootMessageId"), limit)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if len(msgs) == 0 {
+		return respondError(c, fiber.StatusNotFound, "NOT_FOUND", "no messages in thread")
+	}
+	s.attachReactions(c, sess.ID, msgs)
+	s.attachCanonicalJIDs(c, sess.ID, msgs)
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"root_message_id": c.Params("rootMessageId"),
+		"messages":        msgs,
+	})
+}
+
+// handleListMessages returns filtered message history, newest first,
+// with cursor pagination. Filters: ?chat_jid= one chat, ?mention=
+// messages @-mentioning a JID, ?tag= an enrichment tag, ?type= one
+// message type, ?direction=in|out, ?q= a body substring,
+// ?has_link=true bodies containing a URL, ?starred=true starred
+// messages, ?failed=true messages with recorded delivery retries,
+// ?before=/?after= an RFC 3339 timestamp range.
+func (s *Server) handleListMessages(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	p, err := parsePage(c)
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	filter := repository.MessageFilter{
+		ChatJID: c.Query("chat_jid"),
+		Mention: c.Query("mention"),
+		Tag:     c.Query("tag"),
+		Type:    c.Query("type"),
+		Query:   c.Query("q"),
+		// Stemmed search in the session's language when a locale is set.
+		SearchConfig: sess.SearchConfig(),
+		HasLink:      c.QueryBool("has_link", false),
+		Starred:      c.QueryBool("starred", false),
+		Failed:       c.QueryBool("failed", false),
+		Limit:        p.Limit,
+	}
+	switch c.Query("direction") {
+	case "":
+	case "in":
+		fromMe := false
+		filter.FromMe = &fromMe
+	case "out":
+		fromMe := true
+		filter.FromMe = &fromMe
+	default:
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "direction must be in or out")
+	}
+	if raw := c.Query("before"); raw != "" {
+		before, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "before must be RFC 3339")
+		}
+		filter.Before = before
+	}
+	if raw := c.Query("after"); raw != "" {
+		after, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "after must be RFC 3339")
+		}
+		filter.After = after
+	}
+	if len(p.Cursor) == 2 {
+		ts, err1 := time.Parse(time.RFC3339Nano, p.Cursor[0])
+		id, err2 := strconv.ParseInt(p.Cursor[1], 10, 64)
+		if err1 != nil || err2 != nil {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "invalid cursor")
+		}
+		filter.CursorTS = ts
+		filter.CursorID = id
+	}
+
+	msgs, err := s.repos.Messages.ListFiltered(c.Context(), sess.ID, filter)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if msgs == nil {
+		msgs = []*models.Message{}
+	}
+	s.attachReactions(c, sess.ID, msgs)
+	s.attachCanonicalJIDs(c, sess.ID, msgs)
+
+	var nextCursor string
+	if len(msgs) == p.Limit {
+		last := msgs[len(msgs)-1]
+		nextCursor = encodeCursor(last.Timestamp.Format(time.RFC3339Nano),
+			strconv.FormatInt(last.ID, 10))
+	}
+	return respondPage(c, msgs, nextCursor)
+}
+
+// attachReactions populates each message's aggregated reaction counts
+// from stored reaction rows. Lookup failures leave the counts off: a
+// history response without reactions beats a 500.
+func (s *Server) attachReactions(c *fiber.Ctx, sessionID string, msgs []*models.Message) {
+	ids := make([]string, 0, len(msgs))
+	for _, m := range msgs {
+		if m.Type != models.MessageTypeReaction {
+			ids = append(ids, m.MessageID)
+		}
+	}
+	summary, err := s.repos.Messages.ReactionSummary(c.Context(), sessionID, ids)
+	if err != nil {
+		s.log.Warn().Err(err).Str("session_id", sessionID).Msg("reaction summary")
+		return
+	}
+	for _, m := range msgs {
+		m.Reactions = summary[m.MessageID]
+	}
+}
+
+// attachCanonicalJIDs resolves chat and sender JIDs through the identity
+// store so messages in LID chats carry their phone identities alongside.
+// Lookup failures leave the annotations off.
+func (s *Server) attachCanonicalJIDs(c *fiber.Ctx, sessionID string, msgs []*models.Message) {
+	jidSet := make(map[string]bool)
+	for _, m := range msgs {
+		jidSet[m.ChatJID] = true
+		jidSet[m.SenderJID] = true
+	}
+	jids := make([]string, 0, len(jidSet))
+	for jid := range jidSet {
+		jids = append(jids, jid)
+	}
+	canonical, err := s.repos.IDs.CanonicalMap(c.Context(), sessionID, jids)
+	if err != nil {
+		s.log.Warn().Err(err).Str("session_id", sessionID).Msg("resolve canonical jids")
+		return
+	}
+	for _, m := range msgs {
+		if jid, ok := canonical[m.ChatJID]; ok && jid != m.ChatJID {
+			m.CanonicalChatJID = jid
+		}
+		if jid, ok := canonical[m.SenderJID]; ok && jid != m.SenderJID {
+			m.CanonicalSenderJID = jid
+		}
+	}
+}
+
+// handleDownloadMedia streams the decrypted media blob of a stored
+// message. The bytes come straight from WhatsApp's CDN using the media
+// key persisted with the message, so nothing is cached server-side.
+func (s *Server) handleDownloadMedia(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	dl, err := s.manager.DownloadMedia(c.Context(), client, c.Params("messageId"))
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return respondError(c, fiber.StatusNotFound, "MESSAGE_NOT_FOUND", "message not found")
+	case errors.Is(err, session.ErrNotMedia):
+		return respondError(c, fiber.StatusBadRequest, "NOT_MEDIA", "message has no downloadable media")
+	case errors.Is(err, session.ErrNoRawMessage):
+		return respondError(c, fiber.StatusGone, "MEDIA_UNAVAILABLE",
+			"message payload is no longer stored (purged by retention?)")
+	case errors.Is(err, session.ErrSessionNotConnected):
+		return respondError(c, fiber.StatusConflict, "NOT_CONNECTED", "session is not connected")
+	case err != nil:
+		return respondError(c, fiber.StatusBadGateway, "DOWNLOAD_FAILED", err.Error())
+	}
+	if dl.MimeType != "" {
+		c.Set(fiber.HeaderContentType, dl.MimeType)
+	}
+	if dl.FileName != "" {
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", dl.FileName))
+	}
+	return c.Send(dl.Data)
+}
+
+// handleGetByExternalID resolves a caller-supplied external id to the
+// WhatsApp message id it produced, including the stored message row
+// when ingestion kept one.
+func (s *Server) handleGetByExternalID(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	ref, err := s.repos.Messages.GetExternalID(c.Context(), sess.ID, c.Params("externalId"))
+	if errors.Is(err, repository.ErrNotFound) {
+		return respondError(c, fiber.StatusNotFound, "NOT_FOUND", "external id not found")
+	}
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	out := fiber.Map{
+		"external_id": ref.ExternalID,
+		"message_id":  ref.MessageID,
+		"chat_jid":    ref.ChatJID,
+		"created_at":  ref.CreatedAt,
+	}
+	if msg, err := s.repos.Messages.GetByMessageID(c.Context(), sess.ID, ref.MessageID); err == nil {
+		out["message"] = msg
+	}
+	return respond(c, fiber.StatusOK, out)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (s *Server) handleGetMetadata(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	if sess.Metadata == nil {
+		return respond(c, fiber.StatusOK, map[string]any{})
+	}
+	return respond(c, fiber.StatusOK, sess.Metadata)
+}
+
+// handlePatchMetadata applies an RFC 7396 JSON merge patch to the session
+// metadata document: object values merge recursively, nulls delete keys,
+// everything else replaces.
+func (s *Server) handlePatchMetadata(c *fiber.Ctx) error {
+	sess := currentSession(c)
+
+	var patch map[string]any
+	if err := json.Unmarshal(c.Body(), &patch); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "body must be a JSON object")
+	}
+
+	sess.Metadata = mergePatch(sess.Metadata, patch)
+	if err := s.repos.Sessions.Update(c.Context(), sess); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, sess.Metadata)
+}
+
+// mergePatch implements RFC 7396 merge semantics over decoded JSON maps.
+func mergePatch(target, patch map[string]any) map[string]any {
+	if target == nil {
+		target = make(map[string]any)
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		if patchObj, ok := value.(map[string]any); ok {
+			targetObj, _ := target[key].(map[string]any)
+			target[key] = mergePatch(targetObj, patchObj)
+			continue
+		}
+		target[key] = value
+	}
+	return target
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/repository"
+)
+
+const sessionLocalKey = "session"
+
+// adminAuth guards admin-only routes with the global admin key supplied
+// in the Authorization header.
+func (s *Server) adminAuth(c *fiber.Ctx) error {
+	if c.Get("Authorization") != s.cfg.Server.AdminKey {
+		return respondError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "invalid admin key")
+	}
+	return c.Next()
+}
+
+// sessionAuth resolves the {id} path parameter — a session UUID or its
+// unique name, UUID taking precedence — to a session and checks that
+// the caller presents either the session's own API key or the admin
+// key. The live manager copy is preferred over a database read so
+// handlers that mutate session settings act on the object the event
+// pipeline sees.
+func (s *Server) sessionAuth(c *fiber.Ctx) error {
+	var session *models.Session
+	if client, ok := s.manager.Get(c.Params("id")); ok {
+		session = client.Session
+	} else {
+		var err error
+		session, err = s.repos.Sessions.GetByID(c.Context(), c.Params("id"))
+		if err == repository.ErrNotFound {
+			session, err = s.repos.Sessions.GetByName(c.Context(), c.Params("id"))
+		}
+		if err == repository.ErrNotFound {
+			return respondError(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "session not found")
+		}
+		if err != nil {
+			return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+		}
+		// A name lookup may land on a registered session; switch to the
+		// live copy if so.
+		if client, ok := s.manager.Get(session.ID); ok {
+			session = client.Session
+		}
+	}
+
+	key := c.Get("Authorization")
+	if key != session.APIKey && key != s.cfg.Server.AdminKey {
+		return respondError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "invalid api key")
+	}
+	c.Locals(sessionLocalKey, session)
+	return c.Next()
+}
+
+// securityHeaders sets standard security response headers from config.
+// HSTS is only emitted when a max-age is configured, since the service is
+// frequently deployed behind TLS-terminating proxies.
+func (s *Server) securityHeaders(c *fiber.Ctx) error {
+	sec := s.cfg.Server.Security
+	c.Set("X-Content-Type-Options", "nosniff")
+	c.Set("Referrer-Policy", "no-referrer")
+	if sec.FrameOptions != "" {
+		c.Set("X-Frame-Options", sec.FrameOptions)
+	}
+	if sec.ContentSecurityPolicy != "" {
+		c.Set("Content-Security-Policy", sec.ContentSecurityPolicy)
+	}
+	if sec.HSTSMaxAge > 0 {
+		c.Set("Strict-Transport-Security", fmt.Sprintf("max-age=%d", sec.HSTSMaxAge))
+	}
+	return c.Next()
+}
+
+// currentSession returns the session resolved by sessionAuth.
+func currentSession(c *fiber.Ctx) *models.Session {
+	return c.Locals(sessionLocalKey).(*models.Session)
+}
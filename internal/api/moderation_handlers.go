@@ -0,0 +1,61 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// handleGetModeration returns the session's group moderation config.
+func (s *Server) handleGetModeration(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	if sess.GroupModeration == nil {
+		return respondError(c, fiber.StatusNotFound, "NOT_CONFIGURED", "group moderation not configured")
+	}
+	return respond(c, fiber.StatusOK, sess.GroupModeration)
+}
+
+// handleSetModeration replaces the group moderation config.
+func (s *Server) handleSetModeration(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	var mod models.GroupModeration
+	if err := c.BodyParser(&mod); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if err := mod.Validate(); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	sess.GroupModeration = &mod
+	if err := s.repos.Sessions.Update(c.Context(), sess); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, sess.GroupModeration)
+}
+
+// handleDeleteModeration disables group moderation.
+func (s *Server) handleDeleteModeration(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	sess.GroupModeration = nil
+	if err := s.repos.Sessions.Update(c.Context(), sess); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"deleted": true})
+}
+
+// handleModerationLog lists moderation actions, newest first; ?group_jid=
+// narrows to one group.
+func (s *Server) handleModerationLog(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit <= 0 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+	list, err := s.repos.ModLog.List(c.Context(), sess.ID, c.Query("group_jid"), limit)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if list == nil {
+		list = []*models.ModerationAction{}
+	}
+	return respond(c, fiber.StatusOK, list)
+}
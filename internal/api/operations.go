@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Operation statuses as exposed by the operations endpoint.
+const (
+	opStatusRunning   = "running"
+	opStatusSucceeded = "succeeded"
+	opStatusFailed    = "failed"
+)
+
+// How long finished operations stay queryable, and how long a background
+// run may take before its context is cancelled.
+const (
+	operationTTL     = time.Hour
+	operationTimeout = 5 * time.Minute
+)
+
+// Operation is the queryable state of one asynchronous request. Done and
+// Total report progress for multi-step operations (fan-outs); Result and
+// Error are set once the operation finishes.
+type Operation struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Status    string    `json:"status"`
+	Done      int       `json:"done,omitempty"`
+	Total     int       `json:"total,omitempty"`
+	Result    any       `json:"result,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	sessionID string
+}
+
+// opStore holds in-flight and recently finished operations in memory.
+// Operations are best-effort request state, not durable jobs: a restart
+// forgets them, like the other per-process caches.
+type opStore struct {
+	mu  sync.Mutex
+	ops map[string]*Operation
+}
+
+func newOpStore() *opStore {
+	return &opStore{ops: make(map[string]*Operation)}
+}
+
+// create registers a running operation and prunes expired ones.
+func (st *opStore) create(sessionID, kind string, total int) *Operation {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	now := time.Now().UTC()
+	for id, op := range st.ops {
+		if now.Sub(op.UpdatedAt) > operationTTL {
+			delete(st.ops, id)
+		}
+	}
+	op := &Operation{
+		ID:        uuid.NewString(),
+		Kind:      kind,
+		Status:    opStatusRunning,
+		Total:     total,
+		CreatedAt: now,
+		UpdatedAt: now,
+		sessionID: sessionID,
+	}
+	st.ops[op.ID] = op
+	return op
+}
+
+// progress bumps the done counter.
+func (st *opStore) progress(id string, done int) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if op, ok := st.ops[id]; ok {
+		op.Done = done
+		op.UpdatedAt = time.Now().UTC()
+	}
+}
+
+// finish records the terminal state.
+func (st *opStore) finish(id string, result any, err error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	op, ok := st.ops[id]
+	if !ok {
+		return
+	}
+	if err != nil {
+		op.Status = opStatusFailed
+		op.Error = err.Error()
+	} else {
+		op.Status = opStatusSucceeded
+		op.Result = result
+	}
+	op.UpdatedAt = time.Now().UTC()
+}
+
+// snapshot returns a copy of the operation, scoped to its session so one
+// key cannot read another's operations.
+func (st *opStore) snapshot(sessionID, id string) (Operation, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	op, ok := st.ops[id]
+	if !ok || op.sessionID != sessionID {
+		return Operation{}, false
+	}
+	return *op, true
+}
+
+// wantAsync reports whether the request opted into async mode via the
+// Accept-Async header or ?async=true.
+func wantAsync(c *fiber.Ctx) bool {
+	return c.Get("Accept-Async") == "true" || c.QueryBool("async")
+}
+
+// startOperation runs fn in the background under its own timeout and
+// responds 202 with the operation resource. fn must not touch the fiber
+// context: it outlives the request.
+func (s *Server) startOperation(c *fiber.Ctx, kind string, fn func(ctx context.Context) (any, error)) error {
+	op := s.ops.create(currentSession(c).ID, kind, 0)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), operationTimeout)
+		defer cancel()
+		result, err := fn(ctx)
+		s.ops.finish(op.ID, result, err)
+	}()
+	return respond(c, fiber.StatusAccepted, fiber.Map{
+		"operation_id": op.ID,
+		"status":       opStatusRunning,
+	})
+}
+
+// handleGetOperation reports the progress or result of an asynchronous
+// operation started on this session.
+func (s *Server) handleGetOperation(c *fiber.Ctx) error {
+	op, ok := s.ops.snapshot(currentSession(c).ID, c.Params("opId"))
+	if !ok {
+		return respondError(c, fiber.StatusNotFound, "NOT_FOUND", "operation not found")
+	}
+	return respond(c, fiber.StatusOK, op)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Pagination limits shared by all list endpoints.
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
+// page is the parsed pagination input of a list request: ?limit= and an
+// opaque ?cursor= returned by a previous page.
+type page struct {
+	Limit  int
+	Cursor []string
+}
+
+// parsePage reads the standard pagination query parameters. The cursor is
+// decoded into its key parts; its interpretation belongs to the endpoint.
+func parsePage(c *fiber.Ctx) (page, error) {
+	p := page{Limit: c.QueryInt("limit", defaultPageLimit)}
+	if p.Limit < 1 || p.Limit > maxPageLimit {
+		return p, fmt.Errorf("limit must be between 1 and %d", maxPageLimit)
+	}
+	if raw := c.Query("cursor"); raw != "" {
+		parts, err := decodeCursor(raw)
+		if err != nil {
+			return p, fmt.Errorf("invalid cursor")
+		}
+		p.Cursor = parts
+	}
+	return p, nil
+}
+
+// encodeCursor packs key parts into an opaque cursor string.
+func encodeCursor(parts ...string) string {
+	raw, _ := json.Marshal(parts)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) ([]string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var parts []string
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+// respondPage writes the standard list envelope. next_cursor is omitted
+// on the final page.
+func respondPage(c *fiber.Ctx, items any, nextCursor string) error {
+	data := fiber.Map{"items": items}
+	if nextCursor != "" {
+		data["next_cursor"] = nextCursor
+	}
+	return respond(c, fiber.StatusOK, data)
+}
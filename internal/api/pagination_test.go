@@ -0,0 +1,87 @@
+package api
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cases := [][]string{
+		{"2024-06-25T12:00:00Z", "42"},
+		{"single"},
+		{"", "empty-first-part"},
+		{"with spaces", `with "quotes"`, "with,commas"},
+	}
+	for _, parts := range cases {
+		got, err := decodeCursor(encodeCursor(parts...))
+		if err != nil {
+			t.Fatalf("decodeCursor(%v): %v", parts, err)
+		}
+		if !reflect.DeepEqual(got, parts) {
+			t.Errorf("round trip %v = %v", parts, got)
+		}
+	}
+}
+
+func TestDecodeCursorRejectsGarbage(t *testing.T) {
+	for _, raw := range []string{
+		"not base64!",
+		"eyJub3QiOiJhbiBhcnJheSJ9", // decodes to a JSON object, not an array
+	} {
+		if _, err := decodeCursor(raw); err == nil {
+			t.Errorf("decodeCursor(%q): expected error", raw)
+		}
+	}
+}
+
+// runParsePage routes a request through a fiber handler so parsePage
+// sees real query parameters.
+func runParsePage(t *testing.T, target string) (page, error) {
+	t.Helper()
+	var got page
+	var gotErr error
+	app := fiber.New()
+	app.Get("/list", func(c *fiber.Ctx) error {
+		got, gotErr = parsePage(c)
+		return nil
+	})
+	resp, err := app.Test(httptest.NewRequest("GET", target, nil))
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	resp.Body.Close()
+	return got, gotErr
+}
+
+func TestParsePage(t *testing.T) {
+	p, err := runParsePage(t, "/list")
+	if err != nil {
+		t.Fatalf("default page: %v", err)
+	}
+	if p.Limit != defaultPageLimit || p.Cursor != nil {
+		t.Errorf("default page = %+v", p)
+	}
+
+	p, err = runParsePage(t, "/list?limit=200&cursor="+encodeCursor("ts", "7"))
+	if err != nil {
+		t.Fatalf("explicit page: %v", err)
+	}
+	if p.Limit != maxPageLimit || !reflect.DeepEqual(p.Cursor, []string{"ts", "7"}) {
+		t.Errorf("explicit page = %+v", p)
+	}
+
+	for _, target := range []string{
+		"/list?limit=0",
+		"/list?limit=-1",
+		"/list?limit=201",
+		"/list?cursor=%%%",
+		"/list?cursor=garbage",
+	} {
+		if _, err := runParsePage(t, target); err == nil {
+			t.Errorf("%s: expected error", target)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// handleGetFilters returns the session's inbound filter configuration.
+func (s *Server) handleGetFilters(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	if sess.InboundFilters == nil {
+		return respondError(c, fiber.StatusNotFound, "NOT_CONFIGURED", "inbound filters not configured")
+	}
+	return respond(c, fiber.StatusOK, sess.InboundFilters)
+}
+
+// handleSetFilters replaces the session's inbound filter configuration.
+func (s *Server) handleSetFilters(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	var f models.InboundFilters
+	if err := c.BodyParser(&f); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if err := f.Validate(); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	sess.InboundFilters = &f
+	if err := s.repos.Sessions.Update(c.Context(), sess); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, sess.InboundFilters)
+}
+
+// handleDeleteFilters removes the inbound filter configuration.
+func (s *Server) handleDeleteFilters(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	sess.InboundFilters = nil
+	if err := s.repos.Sessions.Update(c.Context(), sess); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"deleted": true})
+}
+
+// handleListQuarantine lists quarantined messages awaiting review.
+func (s *Server) handleListQuarantine(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit <= 0 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+	list, err := s.repos.Messages.ListQuarantined(c.Context(), sess.ID, limit)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if list == nil {
+		list = []*models.Message{}
+	}
+	return respond(c, fiber.StatusOK, list)
+}
+
+// handleReleaseQuarantine clears the quarantine flag on one message and
+// dispatches the message event the quarantine originally suppressed.
+func (s *Server) handleReleaseQuarantine(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	msg, err := s.repos.Messages.ReleaseQuarantine(c.Context(), sess.ID, c.Params("messageId"))
+	if errors.Is(err, sql.ErrNoRows) {
+		return respondError(c, fiber.StatusNotFound, "NOT_FOUND", "no quarantined message with that id")
+	}
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+
+	s.manager.Dispatch(c.Context(), sess, models.EventMessage, map[string]any{
+		"message_id": msg.MessageID,
+		"chat_jid":   msg.ChatJID,
+		"sender_jid": msg.SenderJID,
+		"from_me":    msg.FromMe,
+		"type":       msg.Type,
+		"body":       msg.Body,
+		"quoted_id":  msg.QuotedID,
+		"thread_id":  msg.ThreadID,
+		"tags":       msg.Tags,
+		"timestamp":  msg.Timestamp,
+		"released":   true,
+	})
+	return respond(c, fiber.StatusOK, msg)
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// PanicReporter receives every recovered handler panic. Implementations
+// must not block: they are invoked on a separate goroutine but a slow
+// reporter still leaks them under a panic storm.
+type PanicReporter interface {
+	ReportPanic(correlationID, method, path string, value any, stack []byte)
+}
+
+// SetPanicReporter installs a custom reporter (a Sentry or Bugsnag
+// bridge, for instance), replacing the HTTP reporter built from
+// ZEMEOW_PANIC_REPORT_URL. Call before Listen.
+func (s *Server) SetPanicReporter(r PanicReporter) {
+	s.reporter = r
+}
+
+// recoverPanics converts handler panics into application/problem+json
+// 500 responses. The stack trace never leaves the server; clients get a
+// correlation id that matches the log entry and the report.
+func (s *Server) recoverPanics(c *fiber.Ctx) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		id := uuid.NewString()
+		stack := debug.Stack()
+		s.log.Error().Str("correlation_id", id).
+			Str("method", c.Method()).Str("path", c.Path()).
+			Str("panic", fmt.Sprint(r)).
+			Bytes("stack", stack).
+			Msg("handler panic recovered")
+		if s.reporter != nil {
+			go s.reporter.ReportPanic(id, c.Method(), c.Path(), r, stack)
+		}
+		err = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"type":           "about:blank",
+			"title":          "Internal Server Error",
+			"status":         fiber.StatusInternalServerError,
+			"detail":         "the request handler panicked; quote the correlation id when reporting",
+			"correlation_id": id,
+		})
+		c.Set(fiber.HeaderContentType, "application/problem+json")
+	}()
+	return c.Next()
+}
+
+// httpReporter posts panic reports as JSON to a configured URL, which is
+// the lowest common denominator for error trackers: both Sentry and
+// Bugsnag accept bridged payloads through a relay or a store endpoint.
+type httpReporter struct {
+	url    string
+	client *http.Client
+}
+
+func newHTTPReporter(url string) *httpReporter {
+	return &httpReporter{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (h *httpReporter) ReportPanic(correlationID, method, path string, value any, stack []byte) {
+	body, err := json.Marshal(map[string]any{
+		"correlation_id": correlationID,
+		"method":         method,
+		"path":           path,
+		"error":          fmt.Sprint(value),
+		"stack":          string(stack),
+		"timestamp":      time.Now().UTC(),
+	})
+	if err != nil {
+		return
+	}
+	resp, err := h.client.Post(h.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
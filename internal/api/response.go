@@ -0,0 +1,29 @@
+package api
+
+import "github.com/gofiber/fiber/v2"
+
+// ErrorBody is the error half of the standard response envelope. Code is
+// a stable machine-readable identifier; Message is human-readable.
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// respond writes the standard success envelope, downgraded to the shape
+// the request's API version expects.
+func respond(c *fiber.Ctx, status int, data any) error {
+	version := effectiveAPIVersion(c)
+	c.Set("X-API-Version", version)
+	return c.Status(status).JSON(fiber.Map{
+		"success": true,
+		"data":    applyVersionShims(c, version, data),
+	})
+}
+
+// respondError writes the standard error envelope.
+func respondError(c *fiber.Ctx, status int, code, message string) error {
+	return c.Status(status).JSON(fiber.Map{
+		"success": false,
+		"error":   ErrorBody{Code: code, Message: message},
+	})
+}
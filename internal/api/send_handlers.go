@@ -0,0 +1,294 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/media"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/repository"
+	"github.com/fgsgalvaosz/zemeow/internal/session"
+)
+
+type sendTextRequest struct {
+	To   string `json:"to"`
+	Body string `json:"body"`
+	// TrackLinks rewrites URLs in the body into tracked short links;
+	// Campaign labels them for the click aggregates.
+	TrackLinks bool   `json:"track_links"`
+	Campaign   string `json:"campaign"`
+	// Priority is "high", "normal" (default) or "bulk".
+	Priority string `json:"priority"`
+	// ExternalID is an optional caller-supplied correlation id, unique
+	// per session; resending with a used id is rejected with the message
+	// id of the original send.
+	ExternalID string `json:"external_id"`
+}
+
+func (s *Server) handleSendText(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	var req sendTextRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if req.Body == "" {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "body is required")
+	}
+	to, err := types.ParseJID(req.To)
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+	prio, ok := session.ParsePriority(req.Priority)
+	if !ok {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "priority must be high, normal or bulk")
+	}
+	if err := s.guardExternalID(c, client.Session.ID, req.ExternalID); err != nil {
+		return err
+	}
+
+	body := req.Body
+	var linkCodes []string
+	if req.TrackLinks {
+		if !s.links.Enabled() {
+			return respondError(c, fiber.StatusBadRequest, "LINKS_DISABLED", "link tracking is not configured on this server")
+		}
+		body, linkCodes, err = s.links.WrapText(c.Context(), client.Session.ID, req.Campaign, body)
+		if err != nil {
+			return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+		}
+	}
+
+	resp, err := s.manager.SendText(session.WithPriority(c.Context(), prio), client, to, body)
+	if err != nil {
+		return respondSendError(c, err)
+	}
+	if len(linkCodes) > 0 {
+		if err := s.links.AttachMessage(c.Context(), client.Session.ID, linkCodes, resp.ID); err != nil {
+			s.log.Error().Err(err).Str("session_id", client.Session.ID).Msg("attach message to links")
+		}
+	}
+	s.recordExternalID(c, client.Session.ID, req.ExternalID, resp.ID, to.String())
+	out := fiber.Map{
+		"message_id": resp.ID,
+		"timestamp":  resp.Timestamp,
+	}
+	if req.ExternalID != "" {
+		out["external_id"] = req.ExternalID
+	}
+	return respond(c, fiber.StatusOK, out)
+}
+
+// guardExternalID rejects a send whose external id was already used,
+// quoting the original message id so the caller can correlate anyway.
+func (s *Server) guardExternalID(c *fiber.Ctx, sessionID, externalID string) error {
+	if externalID == "" {
+		return nil
+	}
+	ref, err := s.repos.Messages.GetExternalID(c.Context(), sessionID, externalID)
+	if err != nil {
+		return nil
+	}
+	return respondError(c, fiber.StatusConflict, "EXTERNAL_ID_EXISTS",
+		fmt.Sprintf("external_id already used by message %s", ref.MessageID))
+}
+
+// recordExternalID persists the mapping after a successful send; a
+// failure here loses only the correlation, not the message.
+func (s *Server) recordExternalID(c *fiber.Ctx, sessionID, externalID, messageID, chatJID string) {
+	if externalID == "" {
+		return
+	}
+	if err := s.repos.Messages.SaveExternalID(c.Context(), &repository.ExternalIDRef{
+		SessionID:  sessionID,
+		ExternalID: externalID,
+		MessageID:  messageID,
+		ChatJID:    chatJID,
+	}); err != nil {
+		s.log.Error().Err(err).Str("session_id", sessionID).
+			Str("external_id", externalID).Msg("save external id")
+	}
+}
+
+type sendReactionRequest struct {
+	To        string `json:"to"`
+	MessageID string `json:"message_id"`
+	Emoji     string `json:"emoji"`
+	// Remove withdraws an earlier reaction instead of adding one; Emoji
+	// is ignored when set.
+	Remove bool `json:"remove"`
+}
+
+// handleSendReaction reacts to (or removes a reaction from) a stored
+// message. The original is looked up so the reaction key carries the
+// correct FromMe flag and group participant.
+func (s *Server) handleSendReaction(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	var req sendReactionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if req.MessageID == "" {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "message_id is required")
+	}
+	if req.Emoji == "" && !req.Remove {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "emoji is required unless remove is set")
+	}
+	to, err := types.ParseJID(req.To)
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+
+	resp, err := s.manager.ReactToMessage(c.Context(), client, to, req.MessageID, req.Emoji, req.Remove)
+	if err == session.ErrReactionTargetUnknown {
+		return respondError(c, fiber.StatusNotFound, "NOT_FOUND", "message to react to was not found in the store")
+	}
+	if err != nil {
+		return respondSendError(c, err)
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"message_id": resp.ID,
+		"timestamp":  resp.Timestamp,
+	})
+}
+
+type sendImageRequest struct {
+	To      string `json:"to"`
+	Caption string `json:"caption"`
+	// Exactly one of URL or Base64 must be provided.
+	URL      string `json:"url"`
+	Base64   string `json:"base64"`
+	MimeType string `json:"mime_type"`
+	// ExternalID is an optional caller-supplied correlation id, unique
+	// per session.
+	ExternalID string `json:"external_id"`
+}
+
+func (s *Server) handleSendImage(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	var req sendImageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	to, err := types.ParseJID(req.To)
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+	if err := s.guardExternalID(c, client.Session.ID, req.ExternalID); err != nil {
+		return err
+	}
+
+	data, mimeType, err := s.resolveMediaInput(c, client.Session, req.URL, req.Base64, req.MimeType)
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_MEDIA", err.Error())
+	}
+	if mimeType == "" {
+		mimeType = "image/jpeg"
+	}
+	originalSize := len(data)
+	if s.optimizer.Enabled() {
+		data, mimeType, err = s.optimizer.Optimize(data, mimeType)
+		if err != nil {
+			return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+		}
+	}
+	if s.cfg.Media.StripMetadata {
+		data = media.StripMetadata(data, mimeType)
+	}
+	if err := checkMediaPolicy(c, client.Session, mimeType, len(data)); err != nil {
+		return err
+	}
+	if err := s.scanOutboundMedia(c, data); err != nil {
+		return err
+	}
+	if err := s.checkMediaQuota(c, client.Session, len(data)); err != nil {
+		return err
+	}
+
+	// Async mode: validation, policy and scanning already happened above,
+	// so only the upload and send run in the background.
+	if wantAsync(c) {
+		return s.startOperation(c, "send_image", func(ctx context.Context) (any, error) {
+			resp, err := s.manager.SendImage(ctx, client, to, data, mimeType, req.Caption)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.repos.Usage.AddMediaBytes(ctx, client.Session.ID, int64(len(data))); err != nil {
+				s.log.Error().Err(err).Str("session_id", client.Session.ID).Msg("meter media bytes")
+			}
+			if req.ExternalID != "" {
+				if err := s.repos.Messages.SaveExternalID(ctx, &repository.ExternalIDRef{
+					SessionID:  client.Session.ID,
+					ExternalID: req.ExternalID,
+					MessageID:  resp.ID,
+					ChatJID:    to.String(),
+				}); err != nil {
+					s.log.Error().Err(err).Str("session_id", client.Session.ID).
+						Str("external_id", req.ExternalID).Msg("save external id")
+				}
+			}
+			return fiber.Map{
+				"message_id":    resp.ID,
+				"timestamp":     resp.Timestamp,
+				"original_size": originalSize,
+				"sent_size":     len(data),
+			}, nil
+		})
+	}
+
+	resp, err := s.manager.SendImage(c.Context(), client, to, data, mimeType, req.Caption)
+	if err != nil {
+		return respondSendError(c, err)
+	}
+	s.recordExternalID(c, client.Session.ID, req.ExternalID, resp.ID, to.String())
+	s.meterMediaBytes(c, client.Session, len(data))
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"message_id":    resp.ID,
+		"timestamp":     resp.Timestamp,
+		"original_size": originalSize,
+		"sent_size":     len(data),
+	})
+}
+
+// resolveMediaInput turns the url/base64 pair of a media send request
+// into raw bytes plus the best-known mime type.
+func (s *Server) resolveMediaInput(c *fiber.Ctx, sess *models.Session, url, b64, mimeType string) ([]byte, string, error) {
+	switch {
+	case url != "" && b64 != "":
+		return nil, "", errBothMediaInputs
+	case url != "":
+		data, fetchedType, err := media.FetchFromURL(c.Context(), s.egress, sess, url)
+		if err != nil {
+			return nil, "", err
+		}
+		if mimeType == "" {
+			mimeType = fetchedType
+		}
+		return data, mimeType, nil
+	case b64 != "":
+		data, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, mimeType, nil
+	default:
+		return nil, "", errNoMediaInput
+	}
+}
+
+var (
+	errBothMediaInputs = fiber.NewError(fiber.StatusBadRequest, "provide either url or base64, not both")
+	errNoMediaInput    = fiber.NewError(fiber.StatusBadRequest, "url or base64 is required")
+)
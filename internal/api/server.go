@@ -0,0 +1,274 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/adaptor/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/etag"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+	"github.com/fgsgalvaosz/zemeow/internal/egress"
+	"github.com/fgsgalvaosz/zemeow/internal/features"
+	"github.com/fgsgalvaosz/zemeow/internal/links"
+	"github.com/fgsgalvaosz/zemeow/internal/media"
+	"github.com/fgsgalvaosz/zemeow/internal/repository"
+	"github.com/fgsgalvaosz/zemeow/internal/scan"
+	"github.com/fgsgalvaosz/zemeow/internal/session"
+	"github.com/fgsgalvaosz/zemeow/internal/template"
+	"github.com/fgsgalvaosz/zemeow/internal/translate"
+)
+
+// Server wires the HTTP API to the session manager and repositories.
+type Server struct {
+	app        *fiber.App
+	cfg        *config.Config
+	manager    *session.Manager
+	repos      *repository.Set
+	storage    *media.Storage
+	egress     *egress.Resolver
+	features   *features.Service
+	links      *links.Service
+	templates  *template.Renderer
+	translator *translate.Translator
+	optimizer  *media.Optimizer
+	scanner    *scan.Scanner
+	ops        *opStore
+	reporter   PanicReporter
+	startedAt  time.Time
+	log        zerolog.Logger
+
+	storageUsage storageUsageCache
+}
+
+// NewServer builds the Fiber application and registers all routes.
+func NewServer(cfg *config.Config, manager *session.Manager, repos *repository.Set,
+	storage *media.Storage, egressResolver *egress.Resolver, featureSvc *features.Service,
+	linkSvc *links.Service, translator *translate.Translator, scanner *scan.Scanner,
+	log zerolog.Logger) *Server {
+	s := &Server{
+		app: fiber.New(fiber.Config{
+			AppName:               "zemeow",
+			DisableStartupMessage: true,
+			// The hard ceiling; per-route limits are enforced earlier by
+			// bodyLimits. Streaming lets bulk endpoints decode incrementally.
+			BodyLimit:         cfg.Server.MediaBodyLimit,
+			StreamRequestBody: true,
+		}),
+		cfg:        cfg,
+		manager:    manager,
+		repos:      repos,
+		storage:    storage,
+		egress:     egressResolver,
+		features:   featureSvc,
+		links:      linkSvc,
+		templates:  template.NewRenderer(egressResolver, log),
+		translator: translator,
+		optimizer:  media.NewOptimizer(cfg.Media),
+		scanner:    scanner,
+		ops:        newOpStore(),
+		startedAt:  time.Now(),
+		log:        log.With().Str("component", "api").Logger(),
+	}
+	if cfg.Server.PanicReportURL != "" {
+		s.reporter = newHTTPReporter(cfg.Server.PanicReportURL)
+	}
+	s.registerRoutes()
+	return s
+}
+
+func (s *Server) registerRoutes() {
+	// Recovery runs first so a panic anywhere below still produces a
+	// response instead of a dropped connection.
+	s.app.Use(s.recoverPanics)
+	if s.cfg.Server.CORS.Enabled {
+		cc := s.cfg.Server.CORS
+		s.app.Use(cors.New(cors.Config{
+			AllowOrigins:     cc.AllowOrigins,
+			AllowMethods:     cc.AllowMethods,
+			AllowHeaders:     cc.AllowHeaders,
+			ExposeHeaders:    cc.ExposeHeaders,
+			AllowCredentials: cc.AllowCredentials,
+			MaxAge:           cc.MaxAge,
+		}))
+	}
+	if s.cfg.Server.Security.Enabled {
+		s.app.Use(s.securityHeaders)
+	}
+	if s.cfg.Server.Compression {
+		s.app.Use(compress.New(compress.Config{Level: compress.LevelDefault}))
+	}
+	if s.cfg.Server.ETags {
+		s.app.Use(etag.New(etag.Config{Weak: true}))
+	}
+
+	s.app.Use(s.bodyLimits)
+	s.app.Use(s.apiVersion)
+
+	s.app.Get("/health", s.handleHealth)
+	s.app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+	if s.cfg.Server.StatusPage {
+		s.app.Get("/status", s.handleStatus)
+	}
+
+	// Public redirector for tracked short links; deliberately unauthenticated.
+	s.app.Get("/l/:code", s.handleLinkRedirect)
+
+	s.app.Get("/inbox", s.inboxAuth, s.handleInbox)
+
+	admin := s.app.Group("/sessions", s.adminAuth)
+	admin.Post("/", s.handleCreateSession)
+	admin.Get("/", s.handleListSessions)
+
+	adminAPI := s.app.Group("/admin", s.adminAuth)
+	adminAPI.Get("/features", s.handleListFeatures)
+	adminAPI.Put("/features/:name", s.handleSetFeature)
+	adminAPI.Delete("/features/:name", s.handleClearFeature)
+	adminAPI.Get("/config", s.handleGetConfig)
+	adminAPI.Get("/integrity", s.handleIntegrityCheck)
+	adminAPI.Post("/integrity/repair", s.handleIntegrityRepair)
+	adminAPI.Get("/defaults", s.handleGetDefaults)
+	adminAPI.Put("/defaults", s.handleSetDefaults)
+	adminAPI.Delete("/defaults", s.handleClearDefaults)
+
+	sess := s.app.Group("/sessions/:id", s.sessionAuth)
+	sess.Get("/", s.handleGetSession)
+	sess.Delete("/", s.handleDeleteSession)
+	sess.Put("/name", s.handleRenameSession)
+	sess.Post("/connect", s.handleConnectSession)
+	sess.Post("/disconnect", s.handleDisconnectSession)
+	sess.Post("/logout", s.handleLogoutSession)
+	sess.Post("/repair", s.handleRepairSession)
+	sess.Post("/appstate/resync", s.handleResyncAppState)
+	sess.Post("/pause", s.handlePauseSession)
+	sess.Post("/resume", s.handleResumeSession)
+	sess.Post("/clone", s.handleCloneSession)
+	sess.Post("/webhook/test", s.handleTestWebhook)
+	sess.Get("/logs", s.handleGetSessionLogs)
+	sess.Put("/logs/level", s.handleSetSessionLogLevel)
+	sess.Delete("/logs/level", s.handleResetSessionLogLevel)
+	sess.Get("/events", s.handleSessionEvents)
+	sess.Get("/events/stream", s.handleSessionStream)
+	sess.Post("/events/replay", s.handleReplayEvents)
+	sess.Get("/metadata", s.handleGetMetadata)
+	sess.Patch("/metadata", s.handlePatchMetadata)
+	sess.Get("/business-hours", s.handleGetBusinessHours)
+	sess.Put("/business-hours", s.handleSetBusinessHours)
+	sess.Get("/media-policy", s.handleGetMediaPolicy)
+	sess.Put("/media-policy", s.handleSetMediaPolicy)
+	sess.Delete("/media-policy", s.handleDeleteMediaPolicy)
+	sess.Get("/operations/:opId", s.handleGetOperation)
+	sess.Get("/usage", s.handleGetUsage)
+	sess.Get("/quota", s.handleGetQuota)
+	sess.Put("/quota", s.handleSetQuota)
+	sess.Delete("/quota", s.handleDeleteQuota)
+	sess.Get("/filters", s.handleGetFilters)
+	sess.Put("/filters", s.handleSetFilters)
+	sess.Delete("/filters", s.handleDeleteFilters)
+	sess.Get("/quarantine", s.handleListQuarantine)
+	sess.Post("/quarantine/:messageId/release", s.handleReleaseQuarantine)
+	sess.Get("/legal-holds", s.handleListLegalHolds)
+	sess.Post("/legal-holds", s.handleAddLegalHold)
+	sess.Delete("/legal-holds", s.handleRemoveLegalHold)
+
+	sess.Post("/contacts/import", s.handleImportContacts)
+	sess.Post("/contacts/info/batch", s.handleBatchContactInfo)
+	sess.Get("/contacts/:jid/business-profile", s.handleGetBusinessProfile)
+	sess.Get("/contacts/:jid/avatar", s.handleGetAvatar)
+	sess.Put("/contacts/:jid/language", s.handleSetContactLanguage)
+
+	sess.Get("/identities", s.handleListIdentities)
+	sess.Get("/identities/resolve", s.handleResolveIdentity)
+	sess.Put("/identities", s.handlePutIdentity)
+	sess.Delete("/identities/:jid", s.handleDeleteIdentity)
+
+	sess.Get("/chats/:jid/settings", s.handleGetChatSettings)
+	sess.Post("/chats/:jid/read", s.handleMarkChatRead)
+	sess.Post("/chats/:jid/backfill", s.handleBackfillChat)
+	sess.Get("/chats/:jid/summary", s.handleChatSummary)
+
+	sess.Get("/conversations", s.handleListConversations)
+	sess.Get("/conversations/:jid", s.handleGetConversation)
+	sess.Put("/conversations/:jid", s.handlePutConversation)
+	sess.Get("/analytics/sla", s.handleGetSLA)
+
+	sess.Get("/messages", s.handleListMessages)
+	sess.Get("/messages/:messageId/timeline", s.handleMessageTimeline)
+	sess.Get("/messages/:messageId/media", s.handleDownloadMedia)
+	sess.Get("/messages/external/:externalId", s.handleGetByExternalID)
+	sess.Post("/messages/:messageId/revoke", s.handleRevokeMessage)
+	sess.Delete("/messages/:messageId", s.handleDeleteMessage)
+	sess.Get("/threads/:rootMessageId", s.handleGetThread)
+
+	sess.Post("/send/text", s.handleSendText)
+	sess.Post("/send/image", s.handleSendImage)
+	sess.Post("/send/reaction", s.handleSendReaction)
+	sess.Post("/send/template", s.handleSendTemplate)
+	sess.Post("/send/canned", s.handleSendCanned)
+	sess.Post("/send/topic", s.handleSendToTopic)
+	sess.Post("/send/bulk", s.handleSendBulk)
+
+	sess.Get("/subscriptions/topics", s.handleListTopics)
+	sess.Get("/subscriptions/topics/:topic", s.handleListSubscribers)
+
+	sess.Get("/announcements", s.handleListAnnouncements)
+	sess.Post("/announcements", s.handleCreateAnnouncement)
+	sess.Get("/announcements/optouts", s.handleListAnnouncementOptOuts)
+	sess.Post("/announcements/optouts", s.handleAddAnnouncementOptOut)
+	sess.Delete("/announcements/optouts/:jid", s.handleRemoveAnnouncementOptOut)
+	sess.Post("/announcements/:announcementId/enable", s.handleToggleAnnouncement(true))
+	sess.Post("/announcements/:announcementId/disable", s.handleToggleAnnouncement(false))
+	sess.Delete("/announcements/:announcementId", s.handleDeleteAnnouncement)
+
+	sess.Get("/templates", s.handleListTemplates)
+	sess.Put("/templates/:name", s.handlePutTemplate)
+	sess.Delete("/templates/:name", s.handleDeleteTemplate)
+
+	sess.Get("/canned", s.handleListCanned)
+	sess.Put("/canned/:shortcut", s.handlePutCanned)
+	sess.Delete("/canned/:shortcut", s.handleDeleteCanned)
+
+	sess.Get("/links/stats", s.handleLinkStats)
+
+	sess.Get("/moderation", s.handleGetModeration)
+	sess.Put("/moderation", s.handleSetModeration)
+	sess.Delete("/moderation", s.handleDeleteModeration)
+	sess.Get("/moderation/log", s.handleModerationLog)
+
+	sess.Get("/groups", s.handleListGroups)
+	sess.Get("/groups/:jid", s.handleGetGroup)
+	sess.Get("/groups/:jid/participants/export", s.handleExportParticipants)
+	sess.Post("/groups/:jid/refresh", s.handleRefreshGroup)
+}
+
+// Listen starts serving on the configured address and blocks.
+func (s *Server) Listen() error {
+	addr := fmt.Sprintf("%s:%d", s.cfg.Server.Host, s.cfg.Server.Port)
+	s.log.Info().Str("addr", addr).Msg("listening")
+	return s.app.Listen(addr)
+}
+
+// Shutdown gracefully stops the HTTP server.
+func (s *Server) Shutdown() error {
+	return s.app.Shutdown()
+}
+
+func (s *Server) handleHealth(c *fiber.Ctx) error {
+	return respond(c, fiber.StatusOK, fiber.Map{"status": "ok"})
+}
+
+// handleStatus is the public status page: coarse instance health with no
+// session names, JIDs or other identifying details.
+func (s *Server) handleStatus(c *fiber.Ctx) error {
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"status":                 "ok",
+		"uptime_seconds":         int64(time.Since(s.startedAt).Seconds()),
+		"sessions_connected":     s.manager.ConnectedCount(),
+		"webhook_avg_latency_ms": s.manager.WebhookAvgLatency().Milliseconds(),
+	})
+}
@@ -0,0 +1,513 @@
+package api
+
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/repository"
+	"github.com/fgsgalvaosz/zemeow/internal/session"
+)
+
+// Session names are slugs: lowercase alphanumerics and single hyphens,
+// at most 64 characters. Names are unique and usable as a path
+// identifier interchangeably with the UUID.
+var sessionNameRE = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+const maxSessionNameLen = 64
+
+// validateSessionName rejects a missing or non-slug name with the
+// appropriate API error, or returns nil.
+func validateSessionName(c *fiber.Ctx, name string) error {
+	if name == "" {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "name is required")
+	}
+	if len(name) > maxSessionNameLen || !sessionNameRE.MatchString(name) {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_NAME",
+			"name must be a slug: lowercase letters, digits and hyphens, at most 64 characters")
+	}
+	return nil
+}
+
+type createSessionRequest struct {
+	Name           string   `json:"name"`
+	WebhookURL     string   `json:"webhook_url"`
+	Events         []string `json:"events"`
+	ProxyURL       string   `json:"proxy_url"`
+	EgressProxyURL string   `json:"egress_proxy_url"`
+	// Defaults: sample every receipt, no presence throttle.
+	ReceiptSampleRate        *float64 `json:"receipt_sample_rate"`
+	PresenceMinInterval      int      `json:"presence_min_interval"`
+	PresenceHeartbeatSeconds int      `json:"presence_heartbeat_seconds"`
+	// InlineMediaMaxBytes embeds inbound media up to this size as base64
+	// in message webhooks. 0 (the default) keeps payloads media-free.
+	InlineMediaMaxBytes int    `json:"inline_media_max_bytes"`
+	Locale              string `json:"locale"`
+	Timezone            string `json:"timezone"`
+	// RetentionDays defaults to the server-wide retention default.
+	RetentionDays *int `json:"retention_days"`
+	// DedupeWindowSeconds enables the duplicate text-send guard. 0 (the
+	// default) turns it off.
+	DedupeWindowSeconds int `json:"dedupe_window_seconds"`
+	// Sandbox sessions persist and echo sends as if delivered but never
+	// reach WhatsApp; useful for integration testing without a number.
+	Sandbox bool `json:"sandbox"`
+	// VerifyWebhook runs a challenge handshake against WebhookURL before
+	// the session is created: the receiver must echo the challenge from a
+	// "webhook_verification" envelope, so a typoed URL fails loudly here
+	// instead of silently dropping every event later.
+	VerifyWebhook bool `json:"verify_webhook"`
+}
+
+func (s *Server) handleCreateSession(c *fiber.Ctx) error {
+	var req createSessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if err := validateSessionName(c, req.Name); err != nil {
+		return err
+	}
+	// Operator-configured defaults fill in whatever the request left
+	// unset, before validation so a bad stored default still fails loudly.
+	defaults, err := s.repos.Defaults.Get(c.Context())
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if defaults != nil {
+		if len(req.Events) == 0 {
+			req.Events = defaults.Events
+		}
+		if req.ReceiptSampleRate == nil {
+			req.ReceiptSampleRate = defaults.ReceiptSampleRate
+		}
+		if req.PresenceMinInterval == 0 && defaults.PresenceMinInterval != nil {
+			req.PresenceMinInterval = *defaults.PresenceMinInterval
+		}
+		if req.PresenceHeartbeatSeconds == 0 && defaults.PresenceHeartbeatSeconds != nil {
+			req.PresenceHeartbeatSeconds = *defaults.PresenceHeartbeatSeconds
+		}
+		if req.InlineMediaMaxBytes == 0 && defaults.InlineMediaMaxBytes != nil {
+			req.InlineMediaMaxBytes = *defaults.InlineMediaMaxBytes
+		}
+		if req.RetentionDays == nil {
+			req.RetentionDays = defaults.RetentionDays
+		}
+		if req.DedupeWindowSeconds == 0 && defaults.DedupeWindowSeconds != nil {
+			req.DedupeWindowSeconds = *defaults.DedupeWindowSeconds
+		}
+	}
+	if len(req.Events) == 0 {
+		req.Events = []string{"all"}
+	}
+	sampleRate := 1.0
+	if req.ReceiptSampleRate != nil {
+		sampleRate = *req.ReceiptSampleRate
+		if sampleRate < 0 || sampleRate > 1 {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "receipt_sample_rate must be within [0, 1]")
+		}
+	}
+	if req.PresenceMinInterval < 0 {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "presence_min_interval must be >= 0")
+	}
+	if req.PresenceHeartbeatSeconds < 0 {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "presence_heartbeat_seconds must be >= 0")
+	}
+	if req.InlineMediaMaxBytes < 0 {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "inline_media_max_bytes must be >= 0")
+	}
+	if req.Timezone != "" {
+		if _, err := time.LoadLocation(req.Timezone); err != nil {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "invalid timezone")
+		}
+	}
+	retentionDays := s.cfg.Retention.DefaultDays
+	if req.RetentionDays != nil {
+		retentionDays = *req.RetentionDays
+	}
+	if retentionDays < 0 {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "retention_days must be >= 0")
+	}
+	if req.DedupeWindowSeconds < 0 {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "dedupe_window_seconds must be >= 0")
+	}
+	if req.VerifyWebhook {
+		if req.WebhookURL == "" {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "verify_webhook requires webhook_url")
+		}
+		if err := s.manager.VerifyWebhook(c.Context(), req.WebhookURL, req.EgressProxyURL); err != nil {
+			return respondError(c, fiber.StatusBadGateway, "WEBHOOK_UNVERIFIED", err.Error())
+		}
+	}
+
+	sess := &models.Session{
+		ID:                       uuid.NewString(),
+		Name:                     req.Name,
+		Status:                   models.SessionStatusDisconnected,
+		APIKey:                   uuid.NewString(),
+		WebhookURL:               req.WebhookURL,
+		Events:                   req.Events,
+		ProxyURL:                 req.ProxyURL,
+		EgressProxyURL:           req.EgressProxyURL,
+		ReceiptSampleRate:        sampleRate,
+		PresenceMinInterval:      req.PresenceMinInterval,
+		PresenceHeartbeatSeconds: req.PresenceHeartbeatSeconds,
+		InlineMediaMaxBytes:      req.InlineMediaMaxBytes,
+		Locale:                   req.Locale,
+		Timezone:                 req.Timezone,
+		RetentionDays:            retentionDays,
+		DedupeWindowSeconds:      req.DedupeWindowSeconds,
+		APIVersion:               apiVersionCurrent,
+		Sandbox:                  req.Sandbox,
+	}
+	if err := s.repos.Sessions.Create(c.Context(), sess); errors.Is(err, repository.ErrNameTaken) {
+		return respondError(c, fiber.StatusConflict, "NAME_TAKEN", "a session with this name already exists")
+	} else if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	s.manager.Register(sess)
+	s.manager.NotifyAdmin("session_created", sess.ID, fiber.Map{"name": sess.Name})
+	return respond(c, fiber.StatusCreated, sess)
+}
+
+// cloneCopyLimit bounds how many templates and canned responses are
+// copied to a clone; sessions holding more than this are not realistic
+// provisioning templates.
+const cloneCopyLimit = 1000
+
+type cloneSessionRequest struct {
+	Name string `json:"name"`
+}
+
+// handleCloneSession creates a new session copying the source session's
+// configuration — webhook, proxies, throttles, retention, rules,
+// templates and canned responses — but none of its credentials: the
+// clone gets a fresh API key and starts unpaired. This makes
+// provisioning many similarly-configured numbers a single call each.
+func (s *Server) handleCloneSession(c *fiber.Ctx) error {
+	src := currentSession(c)
+	var req cloneSessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if err := validateSessionName(c, req.Name); err != nil {
+		return err
+	}
+
+	clone := &models.Session{
+		ID:                       uuid.NewString(),
+		Name:                     req.Name,
+		Status:                   models.SessionStatusDisconnected,
+		APIKey:                   uuid.NewString(),
+		WebhookURL:               src.WebhookURL,
+		Events:                   append([]string(nil), src.Events...),
+		ProxyURL:                 src.ProxyURL,
+		EgressProxyURL:           src.EgressProxyURL,
+		ReceiptSampleRate:        src.ReceiptSampleRate,
+		PresenceMinInterval:      src.PresenceMinInterval,
+		PresenceHeartbeatSeconds: src.PresenceHeartbeatSeconds,
+		InlineMediaMaxBytes:      src.InlineMediaMaxBytes,
+		Locale:                   src.Locale,
+		Timezone:                 src.Timezone,
+		RetentionDays:            src.RetentionDays,
+		DedupeWindowSeconds:      src.DedupeWindowSeconds,
+		BusinessHours:            src.BusinessHours,
+		InboundFilters:           src.InboundFilters,
+		GroupModeration:          src.GroupModeration,
+		MediaPolicy:              src.MediaPolicy,
+		Quota:                    src.Quota,
+		Metadata:                 src.Metadata,
+		// The clone's fresh key pins to the current API version; the
+		// source's pin belongs to its key, not its configuration.
+		APIVersion: apiVersionCurrent,
+		Sandbox:    src.Sandbox,
+	}
+	if err := s.repos.Sessions.Create(c.Context(), clone); errors.Is(err, repository.ErrNameTaken) {
+		return respondError(c, fiber.StatusConflict, "NAME_TAKEN", "a session with this name already exists")
+	} else if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+
+	copied := fiber.Map{"templates": 0, "canned": 0}
+	templates, err := s.repos.Templates.List(c.Context(), src.ID)
+	if err != nil {
+		s.log.Error().Err(err).Str("session_id", src.ID).Msg("list templates for clone")
+	}
+	for i, t := range templates {
+		if i >= cloneCopyLimit {
+			break
+		}
+		t.SessionID = clone.ID
+		t.CreatedAt = time.Time{}
+		if err := s.repos.Templates.Upsert(c.Context(), t); err != nil {
+			s.log.Error().Err(err).Str("session_id", clone.ID).
+				Str("template", t.Name).Msg("copy template to clone")
+			continue
+		}
+		copied["templates"] = copied["templates"].(int) + 1
+	}
+	canned, err := s.repos.Canned.Search(c.Context(), src.ID, "", cloneCopyLimit)
+	if err != nil {
+		s.log.Error().Err(err).Str("session_id", src.ID).Msg("list canned responses for clone")
+	}
+	for _, cr := range canned {
+		cr.SessionID = clone.ID
+		cr.CreatedAt = time.Time{}
+		if err := s.repos.Canned.Upsert(c.Context(), cr); err != nil {
+			s.log.Error().Err(err).Str("session_id", clone.ID).
+				Str("shortcut", cr.Shortcut).Msg("copy canned response to clone")
+			continue
+		}
+		copied["canned"] = copied["canned"].(int) + 1
+	}
+
+	s.manager.Register(clone)
+	s.manager.NotifyAdmin("session_cloned", clone.ID, fiber.Map{
+		"name":      clone.Name,
+		"source_id": src.ID,
+	})
+	return respond(c, fiber.StatusCreated, fiber.Map{
+		"session": clone,
+		"copied":  copied,
+	})
+}
+
+type renameSessionRequest struct {
+	Name string `json:"name"`
+}
+
+// handleRenameSession changes the session's unique name. The previous
+// name is echoed back so name-based integrations can migrate; the UUID
+// keeps working throughout, which is the stable identifier renames are
+// expected to break nothing against.
+func (s *Server) handleRenameSession(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	var req renameSessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if err := validateSessionName(c, req.Name); err != nil {
+		return err
+	}
+	previous := sess.Name
+	if req.Name == previous {
+		return respond(c, fiber.StatusOK, fiber.Map{
+			"id":            sess.ID,
+			"name":          sess.Name,
+			"previous_name": previous,
+		})
+	}
+	sess.Name = req.Name
+	if err := s.repos.Sessions.Update(c.Context(), sess); errors.Is(err, repository.ErrNameTaken) {
+		sess.Name = previous
+		return respondError(c, fiber.StatusConflict, "NAME_TAKEN", "a session with this name already exists")
+	} else if err != nil {
+		sess.Name = previous
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	s.manager.NotifyAdmin("session_renamed", sess.ID, fiber.Map{
+		"name":          sess.Name,
+		"previous_name": previous,
+	})
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"id":            sess.ID,
+		"name":          sess.Name,
+		"previous_name": previous,
+	})
+}
+
+func (s *Server) handleListSessions(c *fiber.Ctx) error {
+	p, err := parsePage(c)
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_PAGE", err.Error())
+	}
+	var afterCreatedAt time.Time
+	var afterID string
+	if len(p.Cursor) == 2 {
+		afterCreatedAt, err = time.Parse(time.RFC3339Nano, p.Cursor[0])
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_PAGE", "invalid cursor")
+		}
+		afterID = p.Cursor[1]
+	}
+
+	list, err := s.repos.Sessions.ListPage(c.Context(), afterCreatedAt, afterID, p.Limit)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+
+	var next string
+	if len(list) == p.Limit {
+		last := list[len(list)-1]
+		next = encodeCursor(last.CreatedAt.Format(time.RFC3339Nano), last.ID)
+	}
+	return respondPage(c, list, next)
+}
+
+func (s *Server) handleGetSession(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	if client, ok := s.manager.Get(sess.ID); ok {
+		sess.SendQueue = client.QueueDepths()
+	}
+	return respond(c, fiber.StatusOK, sess)
+}
+
+func (s *Server) handleDeleteSession(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	s.manager.Unregister(sess.ID)
+	if err := s.repos.Sessions.Delete(c.Context(), sess.ID); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	s.manager.NotifyAdmin("session_deleted", sess.ID, fiber.Map{"name": sess.Name})
+	return respond(c, fiber.StatusOK, fiber.Map{"deleted": sess.ID, "name": sess.Name})
+}
+
+func (s *Server) handleConnectSession(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	err := s.manager.Connect(c.Context(), sess.ID)
+	switch err {
+	case nil:
+		return respond(c, fiber.StatusOK, fiber.Map{"status": models.SessionStatusConnecting})
+	case session.ErrAlreadyConnected:
+		return respondError(c, fiber.StatusConflict, "ALREADY_CONNECTED", "session is already connected")
+	case session.ErrSessionNotFound:
+		return respondError(c, fiber.StatusNotFound, "SESSION_NOT_FOUND", "session not found")
+	default:
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+}
+
+// handleLogoutSession unpairs the device and wipes credentials while
+// keeping the session record, distinct from disconnect (socket only) and
+// delete (removes everything).
+func (s *Server) handleLogoutSession(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	if err := s.manager.Logout(c.Context(), sess.ID); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"status": models.SessionStatusLoggedOut})
+}
+
+// handleRepairSession starts a fresh QR pairing on the same session
+// record. ?force=true wipes remaining credentials first.
+func (s *Server) handleRepairSession(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	err := s.manager.Repair(c.Context(), sess.ID, c.QueryBool("force", false))
+	switch err {
+	case nil:
+		return respond(c, fiber.StatusOK, fiber.Map{"status": models.SessionStatusConnecting})
+	case session.ErrSessionStillPaired:
+		return respondError(c, fiber.StatusConflict, "STILL_PAIRED", "session still paired, logout first or pass force=true")
+	default:
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+}
+
+// handleSessionEvents returns the session's connection lifecycle
+// timeline, newest first. ?before_id pages backwards through history.
+func (s *Server) handleSessionEvents(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit <= 0 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+	beforeID := int64(c.QueryInt("before_id", 0))
+	list, err := s.repos.Timeline.List(c.Context(), sess.ID, beforeID, limit)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if list == nil {
+		list = []*models.SessionEvent{}
+	}
+	return respond(c, fiber.StatusOK, list)
+}
+
+type resyncAppStateRequest struct {
+	// Names limits the resync to the listed collections (contacts,
+	// chats, blocklist, labels); empty resyncs everything.
+	Names []string `json:"names"`
+}
+
+// handleResyncAppState forces a full app state fetch from the phone and
+// reports the per-collection outcome.
+func (s *Server) handleResyncAppState(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	var req resyncAppStateRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+		}
+	}
+	results, err := s.manager.ResyncAppState(c.Context(), client, req.Names)
+	if errors.Is(err, session.ErrUnknownAppStateName) {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if err != nil {
+		return respondSendError(c, err)
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"results": results})
+}
+
+func (s *Server) handlePauseSession(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	if err := s.manager.Pause(c.Context(), sess.ID); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"status": models.SessionStatusPaused})
+}
+
+func (s *Server) handleResumeSession(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	err := s.manager.Resume(c.Context(), sess.ID)
+	switch err {
+	case nil:
+		return respond(c, fiber.StatusOK, fiber.Map{"status": models.SessionStatusConnecting})
+	case session.ErrSessionNotPaused:
+		return respondError(c, fiber.StatusConflict, "NOT_PAUSED", "session is not paused")
+	default:
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+}
+
+func (s *Server) handleGetBusinessHours(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	if sess.BusinessHours == nil {
+		return respondError(c, fiber.StatusNotFound, "NOT_CONFIGURED", "business hours not configured")
+	}
+	return respond(c, fiber.StatusOK, sess.BusinessHours)
+}
+
+func (s *Server) handleSetBusinessHours(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	var bh models.BusinessHours
+	if err := c.BodyParser(&bh); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if err := bh.Validate(); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	sess.BusinessHours = &bh
+	if err := s.repos.Sessions.Update(c.Context(), sess); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, sess.BusinessHours)
+}
+
+func (s *Server) handleDisconnectSession(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	err := s.manager.Disconnect(c.Context(), sess.ID)
+	switch err {
+	case nil:
+		return respond(c, fiber.StatusOK, fiber.Map{"status": models.SessionStatusDisconnected})
+	case session.ErrSessionNotConnected:
+		return respondError(c, fiber.StatusConflict, "NOT_CONNECTED", "session is not connected")
+	default:
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/metrics"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// storageUsageTTL is how long a computed bucket footprint is reused
+// before the object listing runs again.
+const storageUsageTTL = 5 * time.Minute
+
+// storageUsageCache memoizes per-session bucket footprints; listing the
+// bucket on every upload would be far too slow.
+type storageUsageCache struct {
+	mu      sync.Mutex
+	entries map[string]storageUsageEntry
+}
+
+type storageUsageEntry struct {
+	bytes   int64
+	at      time.Time
+	alerted bool
+}
+
+// sessionStoragePrefixes are the key namespaces a session's blobs live
+// under.
+func sessionStoragePrefixes(sessionID string) []string {
+	return []string{
+		"avatars/" + sessionID + "/",
+		"media/" + sessionID + "/",
+	}
+}
+
+// sessionStorageBytes returns the session's bucket footprint, served
+// from the cache while fresh, and keeps the storage gauge current.
+func (s *Server) sessionStorageBytes(ctx context.Context, sessionID string) (int64, error) {
+	s.storageUsage.mu.Lock()
+	entry, ok := s.storageUsage.entries[sessionID]
+	s.storageUsage.mu.Unlock()
+	if ok && time.Since(entry.at) < storageUsageTTL {
+		return entry.bytes, nil
+	}
+
+	var total int64
+	for _, prefix := range sessionStoragePrefixes(sessionID) {
+		n, err := s.storage.UsageBytes(ctx, prefix)
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	metrics.StorageBytes.WithLabelValues(sessionID).Set(float64(total))
+
+	s.storageUsage.mu.Lock()
+	if s.storageUsage.entries == nil {
+		s.storageUsage.entries = make(map[string]storageUsageEntry)
+	}
+	s.storageUsage.entries[sessionID] = storageUsageEntry{bytes: total, at: time.Now()}
+	s.storageUsage.mu.Unlock()
+	return total, nil
+}
+
+// addStorageBytes bumps the cached footprint after a successful upload
+// so back-to-back uploads see the growing total without re-listing.
+func (s *Server) addStorageBytes(sessionID string, n int64) {
+	s.storageUsage.mu.Lock()
+	defer s.storageUsage.mu.Unlock()
+	if entry, ok := s.storageUsage.entries[sessionID]; ok {
+		entry.bytes += n
+		s.storageUsage.entries[sessionID] = entry
+		metrics.StorageBytes.WithLabelValues(sessionID).Set(float64(entry.bytes))
+	}
+}
+
+// checkStorageQuota enforces the session's storage quota before a new
+// blob is written and fires the threshold alert when the upload would
+// cross the configured percentage. Listing failures do not block the
+// upload. A nil return means the write may proceed.
+func (s *Server) checkStorageQuota(c *fiber.Ctx, sess *models.Session, addBytes int) error {
+	q := sess.Quota
+	if q == nil || q.MaxStorageBytes <= 0 {
+		return nil
+	}
+	used, err := s.sessionStorageBytes(c.Context(), sess.ID)
+	if err != nil {
+		s.log.Error().Err(err).Str("session_id", sess.ID).Msg("compute storage usage")
+		return nil
+	}
+	projected := used + int64(addBytes)
+	if projected > q.MaxStorageBytes {
+		return respondError(c, fiber.StatusTooManyRequests, "STORAGE_QUOTA_EXCEEDED",
+			fmt.Sprintf("storage quota of %d bytes exhausted", q.MaxStorageBytes))
+	}
+	if q.StorageAlertPercent > 0 &&
+		projected*100 >= q.MaxStorageBytes*int64(q.StorageAlertPercent) {
+		s.alertStorageThreshold(sess, projected, q)
+	}
+	return nil
+}
+
+// alertStorageThreshold notifies the admin webhook once per cache window
+// when a session crosses its storage alert threshold.
+func (s *Server) alertStorageThreshold(sess *models.Session, used int64, q *models.Quota) {
+	s.storageUsage.mu.Lock()
+	entry := s.storageUsage.entries[sess.ID]
+	already := entry.alerted
+	entry.alerted = true
+	s.storageUsage.entries[sess.ID] = entry
+	s.storageUsage.mu.Unlock()
+	if already {
+		return
+	}
+	s.manager.NotifyAdmin("storage_threshold", sess.ID, fiber.Map{
+		"used_bytes":    used,
+		"max_bytes":     q.MaxStorageBytes,
+		"alert_percent": q.StorageAlertPercent,
+	})
+}
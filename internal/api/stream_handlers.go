@@ -0,0 +1,94 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// streamHeartbeatInterval is how often the SSE stream emits a comment
+// line so intermediaries keep the idle connection open.
+const streamHeartbeatInterval = 15 * time.Second
+
+// defaultStreamEvents are the event types streamed when the client does
+// not narrow the stream: the pairing lifecycle, so a frontend can drive
+// a live QR screen without polling.
+var defaultStreamEvents = []string{
+	models.EventQRCode,
+	models.EventConnected,
+	models.EventDisconnected,
+	models.EventLoggedOut,
+}
+
+// handleSessionStream streams the session's events as Server-Sent
+// Events. By default only pairing and connection lifecycle events flow
+// (QR refreshes, pairing success, disconnects); ?events= takes a
+// comma-separated list to widen or narrow the selection. The first
+// frame is always a status snapshot so clients render immediately.
+func (s *Server) handleSessionStream(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	wanted := make(map[string]bool)
+	if raw := c.Query("events"); raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				wanted[t] = true
+			}
+		}
+	} else {
+		for _, t := range defaultStreamEvents {
+			wanted[t] = true
+		}
+	}
+
+	events, cancel := s.manager.Watch(sess.ID)
+	snapshot, _ := json.Marshal(fiber.Map{"status": sess.Status})
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		if err := writeSSE(w, "status", snapshot); err != nil {
+			return
+		}
+		heartbeat := time.NewTicker(streamHeartbeatInterval)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case evt, ok := <-events:
+				if !ok {
+					return
+				}
+				if !wanted[evt.Type] {
+					continue
+				}
+				if err := writeSSE(w, evt.Type, evt.Payload); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				if _, err := w.WriteString(": keep-alive\n\n"); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	})
+	return nil
+}
+
+// writeSSE emits one event frame and flushes it to the client. A flush
+// error means the client went away.
+func writeSSE(w *bufio.Writer, event string, data []byte) error {
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+)
+
+func TestWriteSSE(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	if err := writeSSE(w, "qr_code", []byte(`{"code":"abc"}`)); err != nil {
+		t.Fatalf("writeSSE: %v", err)
+	}
+	want := "event: qr_code\ndata: {\"code\":\"abc\"}\n\n"
+	if buf.String() != want {
+		t.Errorf("frame = %q, want %q", buf.String(), want)
+	}
+
+	// The frame must be flushed immediately; SSE clients wait on the
+	// terminating blank line, not on a full writer buffer.
+	buf.Reset()
+	if err := writeSSE(w, "connected", []byte(`{}`)); err != nil {
+		t.Fatalf("writeSSE: %v", err)
+	}
+	if buf.Len() == 0 {
+		t.Error("writeSSE did not flush the frame")
+	}
+}
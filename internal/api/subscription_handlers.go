@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/session"
+)
+
+// handleListTopics returns the session's topics with subscriber counts.
+func (s *Server) handleListTopics(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	list, err := s.repos.Subs.Topics(c.Context(), sess.ID)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if list == nil {
+		list = []*models.TopicSummary{}
+	}
+	return respond(c, fiber.StatusOK, list)
+}
+
+// handleListSubscribers returns the subscriber JIDs of one topic.
+func (s *Server) handleListSubscribers(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	subscribers, err := s.repos.Subs.Subscribers(c.Context(), sess.ID, c.Params("topic"))
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if subscribers == nil {
+		subscribers = []string{}
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"topic":       c.Params("topic"),
+		"subscribers": subscribers,
+	})
+}
+
+type sendTopicRequest struct {
+	Topic string `json:"topic"`
+	Body  string `json:"body"`
+	// ThrottleMs is the pause between individual sends; defaults to
+	// 1000ms so fan-outs stay under rate-limit radar.
+	ThrottleMs int `json:"throttle_ms"`
+}
+
+// handleSendToTopic fans the body out to every current subscriber of a
+// topic. The fan-out runs in the background with a per-send throttle;
+// the response reports how many recipients were queued.
+func (s *Server) handleSendToTopic(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	var req sendTopicRequest
+	if err := decodeStreamingJSON(c, &req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if req.Topic == "" || req.Body == "" {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "topic and body are required")
+	}
+	throttle := time.Duration(req.ThrottleMs) * time.Millisecond
+	if req.ThrottleMs <= 0 {
+		throttle = time.Second
+	}
+
+	subscribers, err := s.repos.Subs.Subscribers(c.Context(), client.Session.ID, req.Topic)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if len(subscribers) == 0 {
+		return respondError(c, fiber.StatusNotFound, "NOT_FOUND", "topic has no subscribers")
+	}
+
+	op := s.ops.create(client.Session.ID, "send_topic", len(subscribers))
+	go s.fanOutTopic(op.ID, client, req.Topic, req.Body, subscribers, throttle)
+
+	return respond(c, fiber.StatusAccepted, fiber.Map{
+		"topic":        req.Topic,
+		"recipients":   len(subscribers),
+		"status":       "queued",
+		"operation_id": op.ID,
+	})
+}
+
+// fanOutTopic delivers a topic broadcast one recipient at a time,
+// reporting progress through the operation. Send failures are logged and
+// skipped so one bad JID cannot stall the rest.
+func (s *Server) fanOutTopic(opID string, client *session.Client, topic, body string, subscribers []string, throttle time.Duration) {
+	// Campaign traffic runs at bulk priority so it yields to interactive
+	// sends on the same session.
+	ctx := session.WithPriority(context.Background(), session.PriorityBulk)
+	var sent, failed int
+	for i, raw := range subscribers {
+		if i > 0 {
+			time.Sleep(throttle)
+		}
+		to, err := types.ParseJID(raw)
+		if err != nil {
+			s.log.Warn().Err(err).Str("jid", raw).Str("topic", topic).Msg("skip invalid subscriber")
+			failed++
+			s.ops.progress(opID, i+1)
+			continue
+		}
+		if _, err := s.manager.SendText(ctx, client, to, body); err != nil {
+			s.log.Error().Err(err).Str("topic", topic).
+				Str("session_id", client.Session.ID).Msg("topic fan-out send")
+			failed++
+		} else {
+			sent++
+		}
+		s.ops.progress(opID, i+1)
+	}
+	s.ops.finish(opID, fiber.Map{"sent": sent, "failed": failed}, nil)
+}
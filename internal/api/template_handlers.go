@@ -0,0 +1,118 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// handleListTemplates returns every template on the session.
+func (s *Server) handleListTemplates(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	list, err := s.repos.Templates.List(c.Context(), sess.ID)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if list == nil {
+		list = []*models.Template{}
+	}
+	return respond(c, fiber.StatusOK, list)
+}
+
+type putTemplateRequest struct {
+	Body   string                 `json:"body"`
+	Source *models.VariableSource `json:"source"`
+}
+
+// handlePutTemplate creates or replaces the named template.
+func (s *Server) handlePutTemplate(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	var req putTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if req.Body == "" {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "body is required")
+	}
+	if req.Source != nil && req.Source.URL == "" {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "source.url is required when source is set")
+	}
+	tpl := &models.Template{
+		SessionID: sess.ID,
+		Name:      c.Params("name"),
+		Body:      req.Body,
+		Source:    req.Source,
+	}
+	if err := s.repos.Templates.Upsert(c.Context(), tpl); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, tpl)
+}
+
+// handleDeleteTemplate removes the named template.
+func (s *Server) handleDeleteTemplate(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	if err := s.repos.Templates.Delete(c.Context(), sess.ID, c.Params("name")); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"deleted": c.Params("name")})
+}
+
+type sendTemplateRequest struct {
+	To       string `json:"to"`
+	Template string `json:"template"`
+	// Variables override anything the remote source returns.
+	Variables map[string]string `json:"variables"`
+}
+
+// handleSendTemplate renders a template for the recipient (fetching
+// remote variables just-in-time) and sends the result as text.
+func (s *Server) handleSendTemplate(c *fiber.Ctx) error {
+	client, err := s.sessionClient(c)
+	if err != nil {
+		return err
+	}
+	var req sendTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if req.Template == "" {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", "template is required")
+	}
+	to, err := types.ParseJID(req.To)
+	if err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_JID", err.Error())
+	}
+
+	tpl, err := s.repos.Templates.GetByName(c.Context(), client.Session.ID, req.Template)
+	if errors.Is(err, sql.ErrNoRows) {
+		return respondError(c, fiber.StatusNotFound, "TEMPLATE_NOT_FOUND", "template not found")
+	}
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+
+	body := s.templates.Render(c.Context(), client.Session, tpl, to.ToNonAD().String(), req.Variables)
+	// Localize the rendered body into the recipient's stored language
+	// preference when a translation provider is configured.
+	if s.translator.Enabled() {
+		contact, err := s.repos.Contacts.GetByJID(c.Context(), client.Session.ID, to.ToNonAD().String())
+		if err == nil && contact.Language != "" {
+			if translated := s.translator.Translate(c.Context(), body, contact.Language); translated != "" {
+				body = translated
+			}
+		}
+	}
+	resp, err := s.manager.SendText(c.Context(), client, to, body)
+	if err != nil {
+		return respondSendError(c, err)
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{
+		"message_id": resp.ID,
+		"timestamp":  resp.Timestamp,
+	})
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// Bounds for the usage listing window.
+const (
+	defaultUsageDays = 30
+	maxUsageDays     = 365
+)
+
+// handleGetUsage returns the session's daily usage rows for billing
+// integrations, newest first. ?days bounds the window.
+func (s *Server) handleGetUsage(c *fiber.Ctx) error {
+	days := c.QueryInt("days", defaultUsageDays)
+	if days < 1 {
+		days = defaultUsageDays
+	}
+	if days > maxUsageDays {
+		days = maxUsageDays
+	}
+	usage, err := s.repos.Usage.List(c.Context(), currentSession(c).ID, days)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	if usage == nil {
+		usage = []*models.Usage{}
+	}
+	return respond(c, fiber.StatusOK, usage)
+}
+
+// handleGetQuota returns the session's quota config.
+func (s *Server) handleGetQuota(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	if sess.Quota == nil {
+		return respondError(c, fiber.StatusNotFound, "NOT_CONFIGURED", "quota not configured")
+	}
+	return respond(c, fiber.StatusOK, sess.Quota)
+}
+
+// handleSetQuota replaces the session's quota config.
+func (s *Server) handleSetQuota(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	var q models.Quota
+	if err := c.BodyParser(&q); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	if err := q.Validate(); err != nil {
+		return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+	}
+	sess.Quota = &q
+	if err := s.repos.Sessions.Update(c.Context(), sess); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, sess.Quota)
+}
+
+// handleDeleteQuota removes the quota, lifting all limits.
+func (s *Server) handleDeleteQuota(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	sess.Quota = nil
+	if err := s.repos.Sessions.Update(c.Context(), sess); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, "INTERNAL", err.Error())
+	}
+	return respond(c, fiber.StatusOK, fiber.Map{"deleted": true})
+}
+
+// checkMediaQuota enforces the daily media-bytes quota before an upload
+// and writes the rejection response itself. A metering read failure does
+// not block the send, matching the manager's message-quota behaviour.
+func (s *Server) checkMediaQuota(c *fiber.Ctx, sess *models.Session, size int) error {
+	if sess.Quota == nil || sess.Quota.MaxMediaBytesPerDay <= 0 {
+		return nil
+	}
+	usage, err := s.repos.Usage.Today(c.Context(), sess.ID)
+	if err != nil {
+		s.log.Error().Err(err).Str("session_id", sess.ID).Msg("read usage for quota check")
+		return nil
+	}
+	if usage.MediaBytes+int64(size) > sess.Quota.MaxMediaBytesPerDay {
+		return respondError(c, fiber.StatusTooManyRequests, "QUOTA_EXCEEDED",
+			"daily media quota exceeded")
+	}
+	return nil
+}
+
+// meterMediaBytes records sent media bytes against today's usage,
+// best-effort.
+func (s *Server) meterMediaBytes(c *fiber.Ctx, sess *models.Session, size int) {
+	if err := s.repos.Usage.AddMediaBytes(c.Context(), sess.ID, int64(size)); err != nil {
+		s.log.Error().Err(err).Str("session_id", sess.ID).Msg("meter media bytes")
+	}
+}
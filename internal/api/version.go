@@ -0,0 +1,80 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// API versions are dates, Stripe-style. A session is pinned to the
+// current version when it is created, so existing consumers keep the
+// response shapes they integrated against; a request can override its
+// pin with the X-API-Version header.
+const (
+	// apiVersionInitial covers every response shape that shipped before
+	// versioning existed.
+	apiVersionInitial = "2026-08-01"
+
+	// apiVersionCurrent is what new sessions are pinned to and what
+	// requests without a pin or header get.
+	apiVersionCurrent = apiVersionInitial
+)
+
+// knownAPIVersions gates the header so typos fail loudly instead of
+// silently serving the current shapes.
+var knownAPIVersions = map[string]bool{
+	apiVersionInitial: true,
+}
+
+const apiVersionLocalKey = "api_version"
+
+// versionShim rewrites a response payload into the shape served before
+// the named version. Shims are applied newest-first to requests pinned
+// below shim.version, so a consumer three versions back gets each
+// breaking change undone in order. Adding a breaking change means
+// bumping apiVersionCurrent and appending a shim that restores the old
+// shape here.
+type versionShim struct {
+	version string
+	apply   func(c *fiber.Ctx, data any) any
+}
+
+// versionShims is ordered newest version first.
+var versionShims []versionShim
+
+// apiVersion validates the X-API-Version header when present and stashes
+// it for effectiveAPIVersion.
+func (s *Server) apiVersion(c *fiber.Ctx) error {
+	if v := c.Get("X-API-Version"); v != "" {
+		if !knownAPIVersions[v] {
+			return respondError(c, fiber.StatusBadRequest, "UNKNOWN_API_VERSION",
+				"unknown API version "+v)
+		}
+		c.Locals(apiVersionLocalKey, v)
+	}
+	return c.Next()
+}
+
+// effectiveAPIVersion resolves the version for a request: explicit
+// header, then the session's pin, then current. Routes without a
+// session (admin, health) only have the first and last.
+func effectiveAPIVersion(c *fiber.Ctx) string {
+	if v, ok := c.Locals(apiVersionLocalKey).(string); ok && v != "" {
+		return v
+	}
+	if sess, ok := c.Locals(sessionLocalKey).(*models.Session); ok && sess.APIVersion != "" {
+		return sess.APIVersion
+	}
+	return apiVersionCurrent
+}
+
+// applyVersionShims downgrades data to the shape the request's version
+// expects. Date-formatted versions compare correctly as strings.
+func applyVersionShims(c *fiber.Ctx, version string, data any) any {
+	for _, shim := range versionShims {
+		if version < shim.version {
+			data = shim.apply(c, data)
+		}
+	}
+	return data
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+type testWebhookRequest struct {
+	// EventType selects which synthetic event to send; defaults to
+	// "message".
+	EventType string `json:"event_type"`
+}
+
+// handleTestWebhook fires a synthetic event at the configured webhook
+// URL and echoes the receiver's status, latency and body snippet, so
+// integrators can validate their endpoint before going live.
+func (s *Server) handleTestWebhook(c *fiber.Ctx) error {
+	sess := currentSession(c)
+	if sess.WebhookURL == "" {
+		return respondError(c, fiber.StatusBadRequest, "NOT_CONFIGURED", "no webhook url configured")
+	}
+	var req testWebhookRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return respondError(c, fiber.StatusBadRequest, "INVALID_BODY", err.Error())
+		}
+	}
+	eventType := req.EventType
+	if eventType == "" {
+		eventType = models.EventMessage
+	}
+
+	result, err := s.manager.TestWebhook(c.Context(), sess, eventType, syntheticPayload(sess, eventType))
+	if err != nil {
+		return respondError(c, fiber.StatusBadGateway, "WEBHOOK_UNREACHABLE", err.Error())
+	}
+	return respond(c, fiber.StatusOK, result)
+}
+
+// syntheticPayload builds a representative sample payload for a test
+// delivery. Every payload carries "synthetic": true so receivers can
+// tell test traffic apart.
+func syntheticPayload(sess *models.Session, eventType string) map[string]any {
+	payload := map[string]any{
+		"synthetic": true,
+		"timestamp": time.Now().UTC(),
+	}
+	switch eventType {
+	case models.EventMessage:
+		payload["message_id"] = "TEST0000000000000000"
+		payload["chat_jid"] = "0000000000@s.whatsapp.net"
+		payload["sender_jid"] = "0000000000@s.whatsapp.net"
+		payload["from_me"] = false
+		payload["type"] = models.MessageTypeText
+		payload["body"] = "zemeow webhook test"
+	case models.EventReceipt:
+		payload["chat_jid"] = "0000000000@s.whatsapp.net"
+		payload["message_ids"] = []string{"TEST0000000000000000"}
+		payload["receipt"] = "read"
+	case models.EventConnected:
+		payload["device_jid"] = sess.DeviceJID
+	}
+	return payload
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfigFile drops a config file into a temp dir and points Load at
+// it for the duration of the test.
+func writeConfigFile(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "zemeow.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ZEMEOW_CONFIG_FILE", path)
+}
+
+func TestLoadDefaults(t *testing.T) {
+	t.Setenv("ZEMEOW_CONFIG_FILE", "")
+	t.Setenv("ZEMEOW_ADMIN_KEY", "test-admin-key")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Server.Port != 8080 {
+		t.Errorf("default port = %d", cfg.Server.Port)
+	}
+	if cfg.Webhook.Timeout != 15*time.Second || cfg.Webhook.MaxRetries != 3 {
+		t.Errorf("default webhook config = %+v", cfg.Webhook)
+	}
+	if cfg.Log.Level != "info" || cfg.Log.File.MaxBytes != 64<<20 {
+		t.Errorf("default log config = %+v", cfg.Log)
+	}
+}
+
+func TestLoadRequiresAdminKey(t *testing.T) {
+	t.Setenv("ZEMEOW_CONFIG_FILE", "")
+	t.Setenv("ZEMEOW_ADMIN_KEY", "")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "admin key") {
+		t.Errorf("missing admin key: got %v", err)
+	}
+}
+
+func TestLoadLayering(t *testing.T) {
+	writeConfigFile(t, `
+server:
+  port: 9090
+  adminkey: file-admin-key
+log:
+  level: debug
+webhook:
+  maxretries: 7
+`)
+	// Environment overrides the file, which overrides the defaults.
+	t.Setenv("ZEMEOW_PORT", "7070")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Server.Port != 7070 {
+		t.Errorf("env override lost: port = %d", cfg.Server.Port)
+	}
+	if cfg.Server.AdminKey != "file-admin-key" {
+		t.Errorf("file value lost: admin key = %q", cfg.Server.AdminKey)
+	}
+	if cfg.Log.Level != "debug" || cfg.Webhook.MaxRetries != 7 {
+		t.Errorf("file values lost: level=%q retries=%d", cfg.Log.Level, cfg.Webhook.MaxRetries)
+	}
+	// Untouched defaults survive partial files.
+	if cfg.Webhook.Timeout != 15*time.Second {
+		t.Errorf("default lost under file overlay: timeout = %v", cfg.Webhook.Timeout)
+	}
+}
+
+func TestLoadValidation(t *testing.T) {
+	t.Setenv("ZEMEOW_CONFIG_FILE", "")
+	t.Setenv("ZEMEOW_ADMIN_KEY", "test-admin-key")
+
+	t.Setenv("ZEMEOW_CORS_ALLOW_CREDENTIALS", "true")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "wildcard") {
+		t.Errorf("CORS credentials with wildcard origin: got %v", err)
+	}
+	t.Setenv("ZEMEOW_CORS_ALLOW_CREDENTIALS", "")
+
+	t.Setenv("ZEMEOW_PRIVACY_HASH_JIDS", "true")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "HASH_SALT") {
+		t.Errorf("JID hashing without salt: got %v", err)
+	}
+	t.Setenv("ZEMEOW_PRIVACY_HASH_SALT", "pepper")
+	if _, err := Load(); err != nil {
+		t.Errorf("JID hashing with salt: %v", err)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := &Config{}
+	cfg.Server.AdminKey = "super-secret"
+	cfg.Database.DSN = "postgres://zemeow:hunter2@db:5432/zemeow?sslmode=disable"
+	cfg.Storage.AccessKey = "AKIA123"
+	cfg.Storage.SecretKey = "s3cr3t"
+	cfg.Queue.URL = "amqp://guest:guest@mq:5672/"
+	cfg.Webhook.Secret = "hmac-secret"
+	cfg.Privacy.HashSalt = "pepper"
+	cfg.Server.Port = 8080
+
+	red := cfg.Redacted()
+	for name, got := range map[string]string{
+		"admin key":      red.Server.AdminKey,
+		"access key":     red.Storage.AccessKey,
+		"secret key":     red.Storage.SecretKey,
+		"webhook secret": red.Webhook.Secret,
+		"hash salt":      red.Privacy.HashSalt,
+	} {
+		if got != "[redacted]" {
+			t.Errorf("%s = %q, want masked", name, got)
+		}
+	}
+	if strings.Contains(red.Database.DSN, "hunter2") {
+		t.Errorf("DSN password leaked: %q", red.Database.DSN)
+	}
+	if !strings.Contains(red.Database.DSN, "db:5432/zemeow") {
+		t.Errorf("DSN host lost: %q", red.Database.DSN)
+	}
+	if strings.Contains(red.Queue.URL, "guest:guest") {
+		t.Errorf("queue password leaked: %q", red.Queue.URL)
+	}
+	// Non-secrets and the original are untouched.
+	if red.Server.Port != 8080 {
+		t.Errorf("port = %d", red.Server.Port)
+	}
+	if cfg.Server.AdminKey != "super-secret" {
+		t.Error("Redacted mutated the receiver")
+	}
+	// Unset secrets stay empty so the dump shows they are unconfigured.
+	if empty := (&Config{}).Redacted(); empty.Webhook.Secret != "" {
+		t.Errorf("empty secret = %q", empty.Webhook.Secret)
+	}
+}
+
+func TestParseKeyValues(t *testing.T) {
+	got := parseKeyValues("urgent=priority, promo=campaign,broken,=bad,also=")
+	want := map[string]string{"urgent": "priority", "promo": "campaign"}
+	if len(got) != len(want) {
+		t.Fatalf("parseKeyValues = %v", got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("parseKeyValues[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+	if parseKeyValues("") != nil {
+		t.Error("empty input should yield nil")
+	}
+}
@@ -0,0 +1,308 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// Connect opens the Postgres pool and verifies connectivity.
+func Connect(ctx context.Context, cfg config.DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+	return db, nil
+}
+
+// migrations are applied in order at startup. Each statement must be
+// idempotent; there is no down path.
+var migrations = []string{
+	`CREATE TABLE IF NOT EXISTS sessions (
+		id          TEXT PRIMARY KEY,
+		name        TEXT NOT NULL,
+		device_jid  TEXT NOT NULL DEFAULT '',
+		status      TEXT NOT NULL,
+		api_key     TEXT NOT NULL UNIQUE,
+		webhook_url TEXT NOT NULL DEFAULT '',
+		events      TEXT[] NOT NULL DEFAULT '{}',
+		proxy_url   TEXT NOT NULL DEFAULT '',
+		created_at  TIMESTAMPTZ NOT NULL,
+		updated_at  TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS messages (
+		id         BIGSERIAL PRIMARY KEY,
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		message_id TEXT NOT NULL,
+		chat_jid   TEXT NOT NULL,
+		sender_jid TEXT NOT NULL,
+		from_me    BOOLEAN NOT NULL,
+		type       TEXT NOT NULL,
+		body       TEXT NOT NULL DEFAULT '',
+		media_path TEXT NOT NULL DEFAULT '',
+		media_mime TEXT NOT NULL DEFAULT '',
+		quoted_id  TEXT NOT NULL DEFAULT '',
+		raw        BYTEA,
+		timestamp  TIMESTAMPTZ NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		UNIQUE (session_id, message_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_messages_session_chat
+		ON messages (session_id, chat_jid, timestamp DESC)`,
+	`CREATE TABLE IF NOT EXISTS events (
+		id         BIGSERIAL PRIMARY KEY,
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		type       TEXT NOT NULL,
+		payload    JSONB NOT NULL,
+		timestamp  TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_events_session_time
+		ON events (session_id, timestamp)`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS egress_proxy_url TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS receipt_sample_rate DOUBLE PRECISION NOT NULL DEFAULT 1`,
+	`CREATE TABLE IF NOT EXISTS contacts (
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		jid        TEXT NOT NULL,
+		push_name  TEXT NOT NULL DEFAULT '',
+		full_name  TEXT NOT NULL DEFAULT '',
+		updated_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (session_id, jid)
+	)`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS presence_min_interval INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE messages ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}'`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS business_hours JSONB`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}'`,
+	`CREATE TABLE IF NOT EXISTS receipts (
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		message_id TEXT NOT NULL,
+		chat_jid   TEXT NOT NULL,
+		sender_jid TEXT NOT NULL,
+		type       TEXT NOT NULL,
+		timestamp  TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (session_id, message_id, sender_jid, type)
+	)`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS locale TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT ''`,
+	`CREATE TABLE IF NOT EXISTS feature_flags (
+		name       TEXT NOT NULL,
+		session_id TEXT NOT NULL DEFAULT '',
+		enabled    BOOLEAN NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (name, session_id)
+	)`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS retention_days INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE messages ADD COLUMN IF NOT EXISTS thread_id TEXT NOT NULL DEFAULT ''`,
+	`CREATE INDEX IF NOT EXISTS idx_messages_session_thread
+		ON messages (session_id, thread_id) WHERE thread_id <> ''`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS dedupe_window_seconds INTEGER NOT NULL DEFAULT 0`,
+	`CREATE TABLE IF NOT EXISTS links (
+		code        TEXT PRIMARY KEY,
+		session_id  TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		campaign    TEXT NOT NULL DEFAULT '',
+		target_url  TEXT NOT NULL,
+		message_id  TEXT NOT NULL DEFAULT '',
+		clicks      BIGINT NOT NULL DEFAULT 0,
+		created_at  TIMESTAMPTZ NOT NULL,
+		last_click  TIMESTAMPTZ
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_links_session_campaign
+		ON links (session_id, campaign)`,
+	`CREATE TABLE IF NOT EXISTS templates (
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		name       TEXT NOT NULL,
+		body       TEXT NOT NULL,
+		source     JSONB,
+		created_at TIMESTAMPTZ NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (session_id, name)
+	)`,
+	`CREATE TABLE IF NOT EXISTS conversations (
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		chat_jid   TEXT NOT NULL,
+		state      TEXT NOT NULL,
+		agent      TEXT NOT NULL DEFAULT '',
+		updated_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (session_id, chat_jid)
+	)`,
+	`CREATE TABLE IF NOT EXISTS legal_holds (
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		chat_jid   TEXT NOT NULL DEFAULT '',
+		reason     TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (session_id, chat_jid)
+	)`,
+	`CREATE TABLE IF NOT EXISTS canned_responses (
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		shortcut   TEXT NOT NULL,
+		body       TEXT NOT NULL,
+		media_url  TEXT NOT NULL DEFAULT '',
+		media_mime TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (session_id, shortcut)
+	)`,
+	`ALTER TABLE contacts ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS inbound_filters JSONB`,
+	`ALTER TABLE messages ADD COLUMN IF NOT EXISTS quarantined BOOLEAN NOT NULL DEFAULT FALSE`,
+	`ALTER TABLE messages ADD COLUMN IF NOT EXISTS quarantine_reason TEXT NOT NULL DEFAULT ''`,
+	`CREATE INDEX IF NOT EXISTS idx_messages_session_quarantined
+		ON messages (session_id, timestamp) WHERE quarantined`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS group_moderation JSONB`,
+	`CREATE TABLE IF NOT EXISTS moderation_actions (
+		id         BIGSERIAL PRIMARY KEY,
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		group_jid  TEXT NOT NULL,
+		target_jid TEXT NOT NULL,
+		message_id TEXT NOT NULL DEFAULT '',
+		action     TEXT NOT NULL,
+		reason     TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_moderation_actions_offender
+		ON moderation_actions (session_id, group_jid, target_jid)`,
+	`CREATE TABLE IF NOT EXISTS subscriptions (
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		topic      TEXT NOT NULL,
+		jid        TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (session_id, topic, jid)
+	)`,
+	`CREATE TABLE IF NOT EXISTS identities (
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		phone_jid  TEXT NOT NULL,
+		lid_jid    TEXT NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (session_id, lid_jid)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_identities_phone
+		ON identities (session_id, phone_jid)`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS media_policy JSONB`,
+	`ALTER TABLE messages ADD COLUMN IF NOT EXISTS scan_verdict TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE messages ADD COLUMN IF NOT EXISTS retries JSONB NOT NULL DEFAULT '{}'`,
+	`CREATE TABLE IF NOT EXISTS session_events (
+		id         BIGSERIAL PRIMARY KEY,
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		kind       TEXT NOT NULL,
+		detail     TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_session_events_session
+		ON session_events (session_id, id DESC)`,
+	`CREATE TABLE IF NOT EXISTS usage_daily (
+		session_id         TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		day                DATE NOT NULL,
+		messages_sent      BIGINT NOT NULL DEFAULT 0,
+		media_bytes        BIGINT NOT NULL DEFAULT 0,
+		webhook_deliveries BIGINT NOT NULL DEFAULT 0,
+		PRIMARY KEY (session_id, day)
+	)`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS quota JSONB`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS api_version TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS presence_heartbeat_seconds INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS inline_media_max_bytes INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE messages ADD COLUMN IF NOT EXISTS read_at TIMESTAMPTZ`,
+	`CREATE TABLE IF NOT EXISTS media_objects (
+		sha256       TEXT PRIMARY KEY,
+		path         TEXT NOT NULL,
+		content_type TEXT NOT NULL DEFAULT '',
+		size_bytes   BIGINT NOT NULL DEFAULT 0,
+		refs         INTEGER NOT NULL DEFAULT 1,
+		created_at   TIMESTAMPTZ NOT NULL
+	)`,
+	`ALTER TABLE messages ADD COLUMN IF NOT EXISTS media_sha256 TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE messages ADD COLUMN IF NOT EXISTS extracted_text TEXT NOT NULL DEFAULT ''`,
+	`CREATE INDEX IF NOT EXISTS idx_messages_extracted_text
+		ON messages USING GIN (to_tsvector('simple', extracted_text))`,
+	`ALTER TABLE messages ADD COLUMN IF NOT EXISTS transcript TEXT NOT NULL DEFAULT ''`,
+	`CREATE INDEX IF NOT EXISTS idx_messages_transcript
+		ON messages USING GIN (to_tsvector('simple', transcript))`,
+	`ALTER TABLE messages ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS sandbox BOOLEAN NOT NULL DEFAULT FALSE`,
+	`ALTER TABLE messages ADD COLUMN IF NOT EXISTS mentions TEXT[] NOT NULL DEFAULT '{}'`,
+	`ALTER TABLE messages ADD COLUMN IF NOT EXISTS starred BOOLEAN NOT NULL DEFAULT FALSE`,
+	`CREATE INDEX IF NOT EXISTS idx_messages_mentions
+		ON messages USING GIN (mentions)`,
+	`CREATE INDEX IF NOT EXISTS idx_messages_starred
+		ON messages (session_id, timestamp) WHERE starred`,
+	`CREATE INDEX IF NOT EXISTS idx_messages_links
+		ON messages (session_id, timestamp) WHERE body ~* 'https?://'`,
+	`CREATE INDEX IF NOT EXISTS idx_messages_tags
+		ON messages USING GIN (tags)`,
+	`CREATE TABLE IF NOT EXISTS session_defaults (
+		id         BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+		doc        JSONB NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL
+	)`,
+	`ALTER TABLE sessions ADD COLUMN IF NOT EXISTS paired_at TIMESTAMPTZ`,
+	`CREATE TABLE IF NOT EXISTS group_announcements (
+		id          TEXT PRIMARY KEY,
+		session_id  TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		group_jid   TEXT NOT NULL,
+		cron        TEXT NOT NULL,
+		timezone    TEXT NOT NULL,
+		template    TEXT NOT NULL,
+		enabled     BOOLEAN NOT NULL DEFAULT TRUE,
+		last_run_at TIMESTAMPTZ,
+		created_at  TIMESTAMPTZ NOT NULL,
+		updated_at  TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_group_announcements_session
+		ON group_announcements (session_id)`,
+	`CREATE TABLE IF NOT EXISTS group_announcement_optouts (
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		group_jid  TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (session_id, group_jid)
+	)`,
+	`ALTER TABLE conversations ADD COLUMN IF NOT EXISTS resolved_at TIMESTAMPTZ`,
+	`CREATE TABLE IF NOT EXISTS message_external_ids (
+		session_id  TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		external_id TEXT NOT NULL,
+		message_id  TEXT NOT NULL,
+		chat_jid    TEXT NOT NULL,
+		created_at  TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (session_id, external_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS conversation_sla (
+		id BIGSERIAL PRIMARY KEY,
+		session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+		chat_jid TEXT NOT NULL,
+		agent TEXT NOT NULL DEFAULT '',
+		first_response_seconds DOUBLE PRECISION,
+		resolution_seconds DOUBLE PRECISION NOT NULL,
+		resolved_at TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_conversation_sla_session ON conversation_sla (session_id, resolved_at)`,
+	// Suffix pre-existing duplicate names with their id prefix (oldest
+	// session keeps the plain name) so the unique index can be built.
+	`UPDATE sessions SET name = name || '-' || substr(id, 1, 8)
+	WHERE id IN (
+		SELECT id FROM (
+			SELECT id, row_number() OVER (PARTITION BY name ORDER BY created_at, id) AS rn
+			FROM sessions
+		) dupes WHERE rn > 1
+	)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_sessions_name ON sessions (name)`,
+}
+
+// Migrate applies the schema migrations.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	for i, stmt := range migrations {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("migration %d: %w", i, err)
+		}
+	}
+	return nil
+}
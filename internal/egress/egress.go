@@ -0,0 +1,62 @@
+// Package egress builds HTTP clients for outbound application traffic —
+// webhook deliveries and media fetches from URLs. The proxy configured
+// here is deliberately separate from the WhatsApp socket proxy: locked
+// down networks often route web egress and the WhatsApp TLS socket
+// through different gateways.
+package egress
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Resolver hands out HTTP clients for outbound traffic. A session-level
+// proxy overrides the global one; an empty string on both means direct.
+// Clients are cached per proxy URL so connection pools are reused.
+type Resolver struct {
+	globalProxy string
+	timeout     time.Duration
+
+	mu      sync.Mutex
+	clients map[string]*http.Client
+}
+
+// NewResolver creates a Resolver with the global egress proxy (may be
+// empty) and the timeout applied to all built clients.
+func NewResolver(globalProxy string, timeout time.Duration) *Resolver {
+	return &Resolver{
+		globalProxy: globalProxy,
+		timeout:     timeout,
+		clients:     make(map[string]*http.Client),
+	}
+}
+
+// ClientFor returns the HTTP client to use for a session with the given
+// per-session egress proxy override.
+func (r *Resolver) ClientFor(sessionProxy string) (*http.Client, error) {
+	proxy := r.globalProxy
+	if sessionProxy != "" {
+		proxy = sessionProxy
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.clients[proxy]; ok {
+		return c, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy != "" {
+		u, err := url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("egress: parse proxy url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(u)
+	}
+	c := &http.Client{Timeout: r.timeout, Transport: transport}
+	r.clients[proxy] = c
+	return c, nil
+}
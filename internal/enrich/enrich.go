@@ -0,0 +1,94 @@
+// Package enrich provides the tagging hook point of the message ingestion
+// pipeline. Enrichers run synchronously before a message is stored and
+// dispatched, so the tags they produce appear both in the database and in
+// webhook payloads.
+package enrich
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// Enricher annotates an inbound or outbound message with tags. Enrichers
+// must be fast and must not block on network calls; slow enrichment
+// belongs in a webhook consumer instead.
+type Enricher interface {
+	Name() string
+	Tags(msg *models.Message) []string
+}
+
+// Registry holds the ordered set of enrichers applied to every message.
+type Registry struct {
+	mu        sync.RWMutex
+	enrichers []Enricher
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register appends an enricher to the pipeline.
+func (r *Registry) Register(e Enricher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.enrichers = append(r.enrichers, e)
+}
+
+// Run applies every enricher and returns the deduplicated tag set.
+func (r *Registry) Run(msg *models.Message) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.enrichers) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{})
+	var tags []string
+	for _, e := range r.enrichers {
+		for _, t := range e.Tags(msg) {
+			if _, ok := seen[t]; ok {
+				continue
+			}
+			seen[t] = struct{}{}
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// KeywordEnricher tags messages whose body contains configured keywords.
+// Matching is case-insensitive substring matching on the text body and,
+// when present, on text extracted from media, so keyword routing also
+// covers scanned images and PDFs.
+type KeywordEnricher struct {
+	rules map[string]string // lowercased keyword -> tag
+}
+
+// NewKeywordEnricher builds a KeywordEnricher from keyword->tag rules.
+func NewKeywordEnricher(rules map[string]string) *KeywordEnricher {
+	lowered := make(map[string]string, len(rules))
+	for kw, tag := range rules {
+		lowered[strings.ToLower(kw)] = tag
+	}
+	return &KeywordEnricher{rules: lowered}
+}
+
+// Name implements Enricher.
+func (k *KeywordEnricher) Name() string { return "keyword" }
+
+// Tags implements Enricher.
+func (k *KeywordEnricher) Tags(msg *models.Message) []string {
+	if msg.Body == "" && msg.ExtractedText == "" && msg.Transcript == "" {
+		return nil
+	}
+	text := strings.ToLower(msg.Body + "\n" + msg.ExtractedText + "\n" + msg.Transcript)
+	var tags []string
+	for kw, tag := range k.rules {
+		if strings.Contains(text, kw) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,120 @@
+// Package extract integrates an external text-extraction provider that
+// runs OCR on images and text extraction on PDF documents. Like
+// translation, the provider is optional: a nil *Extractor is valid and
+// leaves media unextracted.
+package extract
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/rs/zerolog"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// Kinds of extraction the provider is asked to perform.
+const (
+	KindImage = "image"
+	KindPDF   = "pdf"
+)
+
+// maxResponseBytes caps provider responses; extracted text from a chat
+// attachment should never be anywhere near this.
+const maxResponseBytes = 4 << 20
+
+// Extractor calls a configurable provider endpoint to pull text out of
+// media blobs. The provider contract is a POST with {"kind": ...,
+// "mime": ..., "data": <base64>} answering {"text": ...}.
+type Extractor struct {
+	endpoint string
+	maxBytes int
+	client   *http.Client
+	log      zerolog.Logger
+}
+
+// NewExtractor builds an Extractor from configuration; returns nil when
+// no provider endpoint is configured.
+func NewExtractor(cfg config.ExtractConfig, log zerolog.Logger) *Extractor {
+	if cfg.Endpoint == "" {
+		return nil
+	}
+	return &Extractor{
+		endpoint: cfg.Endpoint,
+		maxBytes: cfg.MaxBytes,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		log:      log.With().Str("component", "extract").Logger(),
+	}
+}
+
+// Enabled reports whether a provider is configured, nil-safe.
+func (e *Extractor) Enabled() bool {
+	return e != nil && e.endpoint != ""
+}
+
+// MaxBytes is the largest blob the extractor will accept; larger media
+// is skipped rather than truncated.
+func (e *Extractor) MaxBytes() int {
+	if e == nil {
+		return 0
+	}
+	return e.maxBytes
+}
+
+// Text returns the text the provider extracted from data, or "" when the
+// provider is unavailable or found nothing — callers treat "" as "no
+// extractable text".
+func (e *Extractor) Text(ctx context.Context, kind, mimeType string, data []byte) string {
+	if !e.Enabled() || len(data) == 0 {
+		return ""
+	}
+	if e.maxBytes > 0 && len(data) > e.maxBytes {
+		return ""
+	}
+	text, err := e.extract(ctx, kind, mimeType, data)
+	if err != nil {
+		e.log.Warn().Err(err).Str("kind", kind).Msg("text extraction failed")
+		return ""
+	}
+	return text
+}
+
+func (e *Extractor) extract(ctx context.Context, kind, mimeType string, data []byte) (string, error) {
+	payload, _ := json.Marshal(map[string]string{
+		"kind": kind,
+		"mime": mimeType,
+		"data": base64.StdEncoding.EncodeToString(data),
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("provider returned %s", resp.Status)
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return "", err
+	}
+	var out struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return "", fmt.Errorf("decode provider response: %w", err)
+	}
+	return out.Text, nil
+}
@@ -0,0 +1,153 @@
+// Package features provides a small feature-flag service so experimental
+// capabilities can be rolled out per session or globally without a
+// deploy. Flags live in the feature_flags table; config supplies the
+// defaults used when no row exists.
+package features
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/repository"
+)
+
+// Known flag names. Unknown names are accepted (so flags can ship ahead
+// of the code that reads them) but these are the ones the server
+// currently consults.
+const (
+	FlagInteractiveMessages = "interactive_messages"
+	FlagAIResponder         = "ai_responder"
+	FlagClustering          = "clustering"
+)
+
+// KnownFlags lists the flag names the server currently consults.
+func KnownFlags() []string {
+	return []string{FlagInteractiveMessages, FlagAIResponder, FlagClustering}
+}
+
+// Service resolves flag values with session overrides beating global rows
+// beating config defaults. All rows are cached in memory and kept current
+// by the mutation methods, which is sound because mutations only flow
+// through this process.
+type Service struct {
+	repo     *repository.FeatureRepository
+	defaults map[string]bool
+
+	mu       sync.RWMutex
+	global   map[string]bool
+	sessions map[string]map[string]bool
+}
+
+// NewService builds a Service over repo. defaults (usually from config)
+// apply when neither a global nor a session row exists; nil is fine.
+func NewService(repo *repository.FeatureRepository, defaults map[string]bool) *Service {
+	if defaults == nil {
+		defaults = map[string]bool{}
+	}
+	return &Service{
+		repo:     repo,
+		defaults: defaults,
+		global:   map[string]bool{},
+		sessions: map[string]map[string]bool{},
+	}
+}
+
+// Load populates the cache from the database. Call once at startup.
+func (s *Service) Load(ctx context.Context) error {
+	flags, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.global = map[string]bool{}
+	s.sessions = map[string]map[string]bool{}
+	for _, f := range flags {
+		s.cacheLocked(f)
+	}
+	return nil
+}
+
+// Enabled reports whether a flag is on for a session. Pass an empty
+// sessionID to resolve the global value only.
+func (s *Service) Enabled(sessionID, name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if sessionID != "" {
+		if overrides, ok := s.sessions[sessionID]; ok {
+			if v, ok := overrides[name]; ok {
+				return v
+			}
+		}
+	}
+	if v, ok := s.global[name]; ok {
+		return v
+	}
+	return s.defaults[name]
+}
+
+// Set persists a flag value and updates the cache. An empty SessionID
+// sets the global value.
+func (s *Service) Set(ctx context.Context, f *models.FeatureFlag) error {
+	if err := s.repo.Upsert(ctx, f); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.cacheLocked(f)
+	s.mu.Unlock()
+	return nil
+}
+
+// Clear removes a stored flag so resolution falls back to the next layer.
+func (s *Service) Clear(ctx context.Context, name, sessionID string) error {
+	if err := s.repo.Delete(ctx, name, sessionID); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	if sessionID == "" {
+		delete(s.global, name)
+	} else if overrides, ok := s.sessions[sessionID]; ok {
+		delete(overrides, name)
+		if len(overrides) == 0 {
+			delete(s.sessions, sessionID)
+		}
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// Snapshot returns the stored rows for the admin listing: the resolved
+// global view (defaults merged with global rows) plus every session
+// override.
+func (s *Service) Snapshot() (global map[string]bool, overrides map[string]map[string]bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	global = make(map[string]bool, len(s.defaults)+len(s.global))
+	for name, v := range s.defaults {
+		global[name] = v
+	}
+	for name, v := range s.global {
+		global[name] = v
+	}
+	overrides = make(map[string]map[string]bool, len(s.sessions))
+	for id, flags := range s.sessions {
+		copied := make(map[string]bool, len(flags))
+		for name, v := range flags {
+			copied[name] = v
+		}
+		overrides[id] = copied
+	}
+	return global, overrides
+}
+
+func (s *Service) cacheLocked(f *models.FeatureFlag) {
+	if f.SessionID == "" {
+		s.global[f.Name] = f.Enabled
+		return
+	}
+	if s.sessions[f.SessionID] == nil {
+		s.sessions[f.SessionID] = map[string]bool{}
+	}
+	s.sessions[f.SessionID][f.Name] = f.Enabled
+}
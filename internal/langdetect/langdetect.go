@@ -0,0 +1,78 @@
+// Package langdetect implements a lightweight language detector for
+// short chat messages. It scores high-frequency function words per
+// language, which is cheap enough to run inline during ingestion and
+// accurate enough for routing decisions; it makes no network calls and
+// needs no models on disk.
+package langdetect
+
+import "strings"
+
+// minTokens is the minimum number of words a message needs before a
+// guess is attempted; one-word messages are not worth routing on.
+const minTokens = 2
+
+// stopwords maps ISO 639-1 codes to the highest-frequency function
+// words of each supported language. The sets are intentionally small:
+// function words dominate chat text, so a handful per language is
+// enough to separate them.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "you", "that", "for", "with", "this", "have", "are", "not", "was", "but", "what", "your", "can"},
+	"es": {"que", "los", "las", "una", "por", "con", "para", "está", "pero", "como", "más", "este", "gracias", "hola", "usted"},
+	"pt": {"que", "não", "uma", "com", "por", "para", "mais", "você", "está", "isso", "como", "mas", "obrigado", "olá", "bom"},
+	"fr": {"les", "des", "est", "pas", "que", "pour", "vous", "avec", "une", "dans", "sur", "mais", "bonjour", "merci", "je"},
+	"de": {"der", "die", "und", "ist", "nicht", "das", "ich", "sie", "mit", "für", "ein", "auf", "danke", "hallo", "aber"},
+	"it": {"che", "non", "per", "una", "con", "sono", "come", "del", "questo", "della", "grazie", "ciao", "anche", "più", "hai"},
+	"nl": {"het", "een", "van", "dat", "niet", "voor", "met", "aan", "maar", "zijn", "dit", "ook", "bedankt", "hallo", "je"},
+	"id": {"yang", "dan", "tidak", "untuk", "dengan", "ini", "itu", "ada", "saya", "anda", "bisa", "akan", "terima", "kasih", "apa"},
+}
+
+// ambiguous words shared between languages (notably es/pt "que", "com"
+// vs "con") are resolved by total score; ties return no guess rather
+// than a coin flip.
+
+// Detect returns the ISO 639-1 code of the most likely language of
+// text, or "" when the text is too short or no language stands out.
+func Detect(text string) string {
+	if text == "" {
+		return ""
+	}
+	tokens := tokenize(text)
+	if len(tokens) < minTokens {
+		return ""
+	}
+
+	var best, runnerUp int
+	var bestLang string
+	for lang, words := range stopwords {
+		score := 0
+		for _, w := range words {
+			score += tokens[w]
+		}
+		switch {
+		case score > best:
+			runnerUp, best, bestLang = best, score, lang
+		case score > runnerUp:
+			runnerUp = score
+		}
+	}
+	// Require a clear winner: at least one hit and strictly ahead of the
+	// second-best language.
+	if best == 0 || best == runnerUp {
+		return ""
+	}
+	return bestLang
+}
+
+// tokenize lowercases text and counts its words, stripping common
+// punctuation so "que," and "que" count together.
+func tokenize(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, field := range strings.Fields(strings.ToLower(text)) {
+		word := strings.Trim(field, ".,!?;:\"'()[]¿¡*")
+		if word == "" {
+			continue
+		}
+		counts[word]++
+	}
+	return counts
+}
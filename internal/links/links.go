@@ -0,0 +1,88 @@
+// Package links implements the self-hosted link-wrapping service: URLs
+// in outbound texts are swapped for short tracked links served by the
+// /l/:code redirector, and clicks surface as webhook events.
+package links
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/repository"
+)
+
+// urlPattern matches http(s) URLs in message text. Trailing punctuation
+// that commonly ends a sentence is left out of the match.
+var urlPattern = regexp.MustCompile(`https?://[^\s<>"]+[^\s<>".,!?)]`)
+
+// Service mints short links and rewrites outbound text.
+type Service struct {
+	repo    *repository.LinkRepository
+	baseURL string
+}
+
+// NewService builds the link service; it returns nil when no base URL is
+// configured, which disables wrapping entirely.
+func NewService(cfg config.LinksConfig, repo *repository.LinkRepository) *Service {
+	if cfg.BaseURL == "" {
+		return nil
+	}
+	return &Service{repo: repo, baseURL: strings.TrimRight(cfg.BaseURL, "/")}
+}
+
+// Enabled reports whether wrapping is available.
+func (s *Service) Enabled() bool {
+	return s != nil
+}
+
+// WrapText replaces every URL in text with a tracked short link and
+// returns the rewritten text plus the minted codes (for attaching the
+// message id after the send).
+func (s *Service) WrapText(ctx context.Context, sessionID, campaign, text string) (string, []string, error) {
+	var codes []string
+	var wrapErr error
+	out := urlPattern.ReplaceAllStringFunc(text, func(target string) string {
+		if wrapErr != nil {
+			return target
+		}
+		code, err := newCode()
+		if err != nil {
+			wrapErr = err
+			return target
+		}
+		if err := s.repo.Create(ctx, &models.Link{
+			Code:      code,
+			SessionID: sessionID,
+			Campaign:  campaign,
+			TargetURL: target,
+		}); err != nil {
+			wrapErr = err
+			return target
+		}
+		codes = append(codes, code)
+		return s.baseURL + "/l/" + code
+	})
+	if wrapErr != nil {
+		return "", nil, fmt.Errorf("wrap links: %w", wrapErr)
+	}
+	return out, codes, nil
+}
+
+// AttachMessage records which message the minted links went out in.
+func (s *Service) AttachMessage(ctx context.Context, sessionID string, codes []string, messageID string) error {
+	return s.repo.AttachMessage(ctx, sessionID, codes, messageID)
+}
+
+// newCode returns a 12-character URL-safe link code.
+func newCode() (string, error) {
+	var buf [6]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf[:]), nil
+}
@@ -0,0 +1,106 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// rotatingFile appends JSON log lines to one file, rotating it aside
+// with a timestamp suffix once it exceeds the size limit. Rotated files
+// older than the age limit are pruned on the next rotation, so the sink
+// never needs a separate cleanup job.
+type rotatingFile struct {
+	path     string
+	maxBytes int64
+	maxAge   time.Duration
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+func newRotatingFile(cfg config.LogFileConfig) *rotatingFile {
+	return &rotatingFile{
+		path:     cfg.Path,
+		maxBytes: cfg.MaxBytes,
+		maxAge:   time.Duration(cfg.MaxAgeDays) * 24 * time.Hour,
+	}
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	if r.maxBytes > 0 && r.size+int64(len(p)) > r.maxBytes {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.f = f
+	r.size = info.Size()
+	return nil
+}
+
+func (r *rotatingFile) rotate() error {
+	r.f.Close()
+	r.f = nil
+	rotated := fmt.Sprintf("%s.%s", r.path, time.Now().UTC().Format("20060102T150405"))
+	if err := os.Rename(r.path, rotated); err != nil {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	r.prune()
+	return r.open()
+}
+
+// prune removes rotated files older than the age limit. Errors are
+// ignored: a failed prune just leaves a file for the next rotation.
+func (r *rotatingFile) prune() {
+	if r.maxAge <= 0 {
+		return
+	}
+	matches, err := filepath.Glob(r.path + ".*")
+	if err != nil {
+		return
+	}
+	cutoff := time.Now().Add(-r.maxAge)
+	for _, m := range matches {
+		if info, err := os.Stat(m); err == nil && info.ModTime().Before(cutoff) {
+			os.Remove(m)
+		}
+	}
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		return nil
+	}
+	err := r.f.Close()
+	r.f = nil
+	return err
+}
@@ -0,0 +1,104 @@
+// Package logging assembles the zerolog output writer from the
+// configured sinks: stderr (console or JSON), a rotating file, syslog
+// and Grafana Loki. Every sink applies its own level floor, so a noisy
+// debug console can coexist with a warn-only syslog feed.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rs/zerolog"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// NewWriter builds the combined log writer. The returned close function
+// flushes and closes the sinks; call it on shutdown so buffered Loki
+// entries are not lost. Sink construction failures are fatal: silently
+// dropping a configured audit sink is worse than refusing to start.
+func NewWriter(cfg config.LogConfig) (io.Writer, func(), error) {
+	var base io.Writer = os.Stderr
+	if cfg.Format == "console" {
+		base = zerolog.ConsoleWriter{Out: os.Stderr}
+	}
+	writers := []io.Writer{base}
+	var closers []func()
+
+	if cfg.File.Path != "" {
+		f := newRotatingFile(cfg.File)
+		writers = append(writers, filtered(f, sinkLevel(cfg.File.Level, cfg.Level)))
+		closers = append(closers, func() { f.Close() })
+	}
+	if cfg.Syslog.Enabled {
+		w, err := newSyslogWriter(cfg.Syslog)
+		if err != nil {
+			return nil, nil, fmt.Errorf("logging: syslog: %w", err)
+		}
+		writers = append(writers, filtered(w, sinkLevel(cfg.Syslog.Level, cfg.Level)))
+	}
+	if cfg.Loki.URL != "" {
+		l := newLokiWriter(cfg.Loki)
+		writers = append(writers, filtered(l, sinkLevel(cfg.Loki.Level, cfg.Level)))
+		closers = append(closers, l.Close)
+	}
+
+	closeAll := func() {
+		for _, c := range closers {
+			c()
+		}
+	}
+	if len(writers) == 1 {
+		return base, closeAll, nil
+	}
+	return zerolog.MultiLevelWriter(writers...), closeAll, nil
+}
+
+// sinkLevel resolves a sink's level floor, inheriting the global level
+// when unset and falling back to info on unparseable values.
+func sinkLevel(sink, global string) zerolog.Level {
+	raw := sink
+	if raw == "" {
+		raw = global
+	}
+	level, err := zerolog.ParseLevel(raw)
+	if err != nil {
+		return zerolog.InfoLevel
+	}
+	return level
+}
+
+// filtered wraps a writer so only entries at or above min reach it.
+// Level-less entries always pass.
+func filtered(w io.Writer, min zerolog.Level) zerolog.LevelWriter {
+	lw, ok := w.(zerolog.LevelWriter)
+	if !ok {
+		lw = levelWriterAdapter{w}
+	}
+	return &filterWriter{lw: lw, min: min}
+}
+
+type filterWriter struct {
+	lw  zerolog.LevelWriter
+	min zerolog.Level
+}
+
+func (f *filterWriter) Write(p []byte) (int, error) {
+	return f.lw.Write(p)
+}
+
+func (f *filterWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if level < f.min && level != zerolog.NoLevel {
+		return len(p), nil
+	}
+	return f.lw.WriteLevel(level, p)
+}
+
+type levelWriterAdapter struct {
+	io.Writer
+}
+
+func (a levelWriterAdapter) WriteLevel(_ zerolog.Level, p []byte) (int, error) {
+	return a.Write(p)
+}
@@ -0,0 +1,128 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// Loki batching bounds: entries are buffered and pushed either when the
+// buffer fills or on the ticker, whichever comes first.
+const (
+	lokiBatchSize     = 200
+	lokiFlushInterval = 2 * time.Second
+	lokiPushTimeout   = 5 * time.Second
+)
+
+type lokiEntry struct {
+	ts   time.Time
+	line string
+}
+
+// lokiWriter pushes log lines to a Grafana Loki instance via the HTTP
+// push API, batched in the background. Push failures drop the batch and
+// note it on stderr — the logging pipeline must never block or recurse
+// into itself.
+type lokiWriter struct {
+	url    string
+	labels map[string]string
+	client *http.Client
+
+	mu   sync.Mutex
+	buf  []lokiEntry
+	kick chan struct{}
+	done chan struct{}
+}
+
+func newLokiWriter(cfg config.LogLokiConfig) *lokiWriter {
+	labels := cfg.Labels
+	if len(labels) == 0 {
+		labels = map[string]string{"app": "zemeow"}
+	}
+	l := &lokiWriter{
+		url:    cfg.URL,
+		labels: labels,
+		client: &http.Client{Timeout: lokiPushTimeout},
+		kick:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+func (l *lokiWriter) Write(p []byte) (int, error) {
+	entry := lokiEntry{ts: time.Now(), line: strings.TrimRight(string(p), "\n")}
+	l.mu.Lock()
+	l.buf = append(l.buf, entry)
+	full := len(l.buf) >= lokiBatchSize
+	l.mu.Unlock()
+	if full {
+		select {
+		case l.kick <- struct{}{}:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+func (l *lokiWriter) run() {
+	ticker := time.NewTicker(lokiFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.done:
+			l.flush()
+			return
+		case <-l.kick:
+			l.flush()
+		case <-ticker.C:
+			l.flush()
+		}
+	}
+}
+
+func (l *lokiWriter) flush() {
+	l.mu.Lock()
+	batch := l.buf
+	l.buf = nil
+	l.mu.Unlock()
+	if len(batch) == 0 {
+		return
+	}
+
+	values := make([][2]string, len(batch))
+	for i, e := range batch {
+		values[i] = [2]string{strconv.FormatInt(e.ts.UnixNano(), 10), e.line}
+	}
+	body, err := json.Marshal(map[string]any{
+		"streams": []map[string]any{{
+			"stream": l.labels,
+			"values": values,
+		}},
+	})
+	if err != nil {
+		return
+	}
+	resp, err := l.client.Post(l.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "loki push failed, dropped %d entries: %v\n", len(batch), err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		fmt.Fprintf(os.Stderr, "loki push returned %d, dropped %d entries\n", resp.StatusCode, len(batch))
+	}
+}
+
+// Close flushes the remaining buffer and stops the background pusher.
+func (l *lokiWriter) Close() {
+	close(l.done)
+}
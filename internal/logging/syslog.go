@@ -0,0 +1,25 @@
+package logging
+
+import (
+	"io"
+	"log/syslog"
+
+	"github.com/rs/zerolog"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// newSyslogWriter dials syslog and adapts it so zerolog levels map onto
+// syslog severities. Empty network and address connect to the local
+// daemon, matching log/syslog.Dial.
+func newSyslogWriter(cfg config.LogSyslogConfig) (io.Writer, error) {
+	tag := cfg.Tag
+	if tag == "" {
+		tag = "zemeow"
+	}
+	w, err := syslog.Dial(cfg.Network, cfg.Address, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, err
+	}
+	return zerolog.SyslogLevelWriter(w), nil
+}
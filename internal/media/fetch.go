@@ -0,0 +1,45 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fgsgalvaosz/zemeow/internal/egress"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// fetchMaxBytes bounds how much a URL fetch will read.
+const fetchMaxBytes = 100 << 20 // 100 MiB
+
+// FetchFromURL downloads media referenced by URL for a send request,
+// using the session's egress client so corporate proxy setups work. It
+// returns the bytes and the Content-Type reported by the server.
+func FetchFromURL(ctx context.Context, resolver *egress.Resolver, session *models.Session, url string) ([]byte, string, error) {
+	client, err := resolver.ClientFor(session.EgressProxyURL)
+	if err != nil {
+		return nil, "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("media: build request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("media: fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("media: fetch %s: status %d", url, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, fetchMaxBytes+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("media: read body: %w", err)
+	}
+	if len(data) > fetchMaxBytes {
+		return nil, "", fmt.Errorf("media: %s exceeds %d byte fetch limit", url, fetchMaxBytes)
+	}
+	return data, resp.Header.Get("Content-Type"), nil
+}
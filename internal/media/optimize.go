@@ -0,0 +1,108 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png" // register PNG decoding for image.Decode
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// Optimizer recompresses outbound images before upload: large images are
+// scaled down to fit MaxDimension and re-encoded as JPEG at the
+// configured quality. Formats the optimizer cannot decode pass through
+// untouched.
+type Optimizer struct {
+	maxDimension int
+	quality      int
+}
+
+// NewOptimizer builds an Optimizer from config, or returns nil when
+// optimization is disabled.
+func NewOptimizer(cfg config.MediaConfig) *Optimizer {
+	if !cfg.OptimizeImages {
+		return nil
+	}
+	return &Optimizer{maxDimension: cfg.MaxDimension, quality: cfg.JPEGQuality}
+}
+
+// Enabled reports whether the optimizer is configured, nil-safe.
+func (o *Optimizer) Enabled() bool { return o != nil }
+
+// Optimize returns the optimized image bytes and their MIME type. The
+// original bytes come back unchanged when the input is not a supported
+// image, or when optimization would not shrink it.
+func (o *Optimizer) Optimize(data []byte, mimeType string) ([]byte, string, error) {
+	if o == nil {
+		return data, mimeType, nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		// Unsupported format (webp, animated content, ...): send as-is.
+		return data, mimeType, nil
+	}
+	if w, h := img.Bounds().Dx(), img.Bounds().Dy(); w > o.maxDimension || h > o.maxDimension {
+		img = scaleDown(img, o.maxDimension)
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: o.quality}); err != nil {
+		return nil, "", fmt.Errorf("encode optimized image: %w", err)
+	}
+	if buf.Len() >= len(data) {
+		return data, mimeType, nil
+	}
+	return buf.Bytes(), "image/jpeg", nil
+}
+
+// scaleDown resizes img so its longer edge equals maxDim, sampling each
+// destination pixel from the box of source pixels it covers.
+func scaleDown(img image.Image, maxDim int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	scale := float64(maxDim) / float64(w)
+	if h > w {
+		scale = float64(maxDim) / float64(h)
+	}
+	dw, dh := int(float64(w)*scale), int(float64(h)*scale)
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for dy := 0; dy < dh; dy++ {
+		sy0 := bounds.Min.Y + dy*h/dh
+		sy1 := bounds.Min.Y + (dy+1)*h/dh
+		if sy1 <= sy0 {
+			sy1 = sy0 + 1
+		}
+		for dx := 0; dx < dw; dx++ {
+			sx0 := bounds.Min.X + dx*w/dw
+			sx1 := bounds.Min.X + (dx+1)*w/dw
+			if sx1 <= sx0 {
+				sx1 = sx0 + 1
+			}
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					b += uint64(pb)
+					a += uint64(pa)
+					n++
+				}
+			}
+			i := dst.PixOffset(dx, dy)
+			dst.Pix[i+0] = uint8(r / n >> 8)
+			dst.Pix[i+1] = uint8(g / n >> 8)
+			dst.Pix[i+2] = uint8(b / n >> 8)
+			dst.Pix[i+3] = uint8(a / n >> 8)
+		}
+	}
+	return dst
+}
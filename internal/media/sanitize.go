@@ -0,0 +1,93 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+)
+
+// StripMetadata removes embedded metadata (EXIF, GPS, XMP, IPTC,
+// textual chunks) from an image without re-encoding it, so senders do
+// not leak location data baked into their files. Unsupported formats
+// come back unchanged — pixel data is never touched.
+func StripMetadata(data []byte, mimeType string) []byte {
+	switch {
+	case strings.EqualFold(mimeType, "image/jpeg"):
+		return stripJPEGMetadata(data)
+	case strings.EqualFold(mimeType, "image/png"):
+		return stripPNGMetadata(data)
+	default:
+		return data
+	}
+}
+
+// stripJPEGMetadata drops APP1 (EXIF/XMP) and APP13 (IPTC/Photoshop)
+// segments from a JPEG stream. APP0 (JFIF) and APP14 (Adobe) stay, as
+// decoders rely on them for colour interpretation. Malformed input is
+// returned as-is.
+func stripJPEGMetadata(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return data
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:2]...)
+	i := 2
+	for i+4 <= len(data) {
+		if data[i] != 0xFF {
+			return data
+		}
+		marker := data[i+1]
+		// SOS: entropy-coded data follows, copy the remainder verbatim.
+		if marker == 0xDA {
+			return append(out, data[i:]...)
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return data
+		}
+		if marker != 0xE1 && marker != 0xED {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	return data
+}
+
+// pngMagic is the fixed eight-byte PNG signature.
+var pngMagic = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}
+
+// droppedPNGChunks are the ancillary chunk types carrying metadata.
+var droppedPNGChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+// stripPNGMetadata drops metadata chunks from a PNG stream. Malformed
+// input is returned as-is.
+func stripPNGMetadata(data []byte) []byte {
+	if len(data) < 8 || !bytes.Equal(data[:8], pngMagic) {
+		return data
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:8]...)
+	i := 8
+	for i+12 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[i : i+4]))
+		end := i + 12 + length
+		if end > len(data) || end < i {
+			return data
+		}
+		if !droppedPNGChunks[string(data[i+4:i+8])] {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	if i != len(data) {
+		return data
+	}
+	return out
+}
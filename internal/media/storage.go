@@ -0,0 +1,101 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// Storage is the MinIO-backed object store for media blobs: downloaded
+// message media, cached avatars and generated thumbnails.
+type Storage struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewStorage connects to MinIO and ensures the bucket exists.
+func NewStorage(ctx context.Context, cfg config.StorageConfig) (*Storage, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storage: connect: %w", err)
+	}
+	exists, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("storage: check bucket: %w", err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{}); err != nil {
+			return nil, fmt.Errorf("storage: create bucket: %w", err)
+		}
+	}
+	return &Storage{client: client, bucket: cfg.Bucket}, nil
+}
+
+// Put stores a blob under key.
+func (s *Storage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(data),
+		int64(len(data)), minio.PutObjectOptions{ContentType: contentType})
+	if err != nil {
+		return fmt.Errorf("storage: put %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get returns the blob stored under key and its content type. Missing
+// keys return ErrObjectNotFound.
+func (s *Storage) Get(ctx context.Context, key string) ([]byte, string, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, "", fmt.Errorf("storage: get %s: %w", key, err)
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		if resp := minio.ToErrorResponse(err); resp.Code == "NoSuchKey" {
+			return nil, "", ErrObjectNotFound
+		}
+		return nil, "", fmt.Errorf("storage: read %s: %w", key, err)
+	}
+	stat, err := obj.Stat()
+	if err != nil {
+		return nil, "", fmt.Errorf("storage: stat %s: %w", key, err)
+	}
+	return data, stat.ContentType, nil
+}
+
+// Remove deletes the blob stored under key. Removing a missing key is
+// not an error.
+func (s *Storage) Remove(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("storage: remove %s: %w", key, err)
+	}
+	return nil
+}
+
+// ErrObjectNotFound is returned by Get for keys that do not exist.
+var ErrObjectNotFound = fmt.Errorf("storage: object not found")
+
+// UsageBytes sums the stored size of every object under prefix.
+func (s *Storage) UsageBytes(ctx context.Context, prefix string) (int64, error) {
+	var total int64
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	}) {
+		if obj.Err != nil {
+			return 0, fmt.Errorf("storage: list %s: %w", prefix, obj.Err)
+		}
+		total += obj.Size
+	}
+	return total, nil
+}
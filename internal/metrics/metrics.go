@@ -0,0 +1,68 @@
+// Package metrics holds the service's Prometheus collectors. Collectors
+// are package-level and registered on the default registry so any
+// component can instrument without plumbing.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ProtocolErrors counts WhatsApp protocol failures by error type, both on
+// the send path and from stream-level events.
+var ProtocolErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "zemeow",
+	Name:      "whatsapp_protocol_errors_total",
+	Help:      "WhatsApp protocol errors by session and error type.",
+}, []string{"session_id", "type"})
+
+// SendQueueDepth tracks how many sends are waiting for a session's send
+// slot, per priority level.
+var SendQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "zemeow",
+	Name:      "send_queue_depth",
+	Help:      "Outbound sends waiting per session and priority.",
+}, []string{"session_id", "priority"})
+
+// IngestQueueDepth tracks how many stored messages are waiting in the
+// ingestion batcher's queue. A depth near the queue capacity means the
+// flusher cannot keep up with inbound traffic.
+var IngestQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: "zemeow",
+	Name:      "ingest_queue_depth",
+	Help:      "Messages waiting in the ingestion batching queue.",
+})
+
+// IngestOverflow counts messages that found the ingestion queue full and
+// fell back to a synchronous single-row insert.
+var IngestOverflow = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "zemeow",
+	Name:      "ingest_overflow_total",
+	Help:      "Messages written synchronously because the ingestion queue was full.",
+})
+
+// StorageBytes tracks each session's media storage footprint in the
+// bucket, refreshed whenever the quota check recomputes it.
+var StorageBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "zemeow",
+	Name:      "storage_bytes",
+	Help:      "Bytes of media stored in the bucket per session.",
+}, []string{"session_id"})
+
+// FirstResponseSeconds observes, per resolved conversation, the gap
+// between the first inbound message and the session's first reply.
+var FirstResponseSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "zemeow",
+	Name:      "conversation_first_response_seconds",
+	Help:      "Time to first reply per resolved conversation.",
+	Buckets:   prometheus.ExponentialBuckets(30, 2, 12),
+}, []string{"session_id"})
+
+// ResolutionSeconds observes, per resolved conversation, the gap between
+// the first inbound message and the resolution.
+var ResolutionSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "zemeow",
+	Name:      "conversation_resolution_seconds",
+	Help:      "Time to resolution per resolved conversation.",
+	Buckets:   prometheus.ExponentialBuckets(60, 2, 14),
+}, []string{"session_id"})
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// GroupAnnouncement is a recurring message posted to one group on a cron
+// schedule, rendered from a session template in the group's time zone.
+type GroupAnnouncement struct {
+	ID        string `json:"id" db:"id"`
+	SessionID string `json:"session_id" db:"session_id"`
+	GroupJID  string `json:"group_jid" db:"group_jid"`
+	// Cron is a standard five-field spec (minute hour day-of-month month
+	// day-of-week) evaluated in Timezone.
+	Cron     string `json:"cron" db:"cron"`
+	Timezone string `json:"timezone" db:"timezone"`
+	// Template names a template of the owning session; it is rendered
+	// with the group JID as the recipient.
+	Template  string     `json:"template" db:"template"`
+	Enabled   bool       `json:"enabled" db:"enabled"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty" db:"last_run_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// BusinessHours configures the per-session auto-away responder. Outside
+// the scheduled windows, inbound direct messages get the away message as
+// an automatic reply (throttled per chat).
+type BusinessHours struct {
+	Enabled     bool   `json:"enabled"`
+	Timezone    string `json:"timezone"`
+	AwayMessage string `json:"away_message"`
+	// Schedule maps lowercase English weekday names ("monday") to open
+	// windows. Days without an entry are fully closed.
+	Schedule map[string][]HoursWindow `json:"schedule"`
+	// ReplyIntervalMinutes throttles the away reply per chat. Defaults to
+	// 360 (six hours) when zero.
+	ReplyIntervalMinutes int `json:"reply_interval_minutes"`
+}
+
+// HoursWindow is one open interval within a day, in "15:04" local time.
+type HoursWindow struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Validate checks the timezone and every window's time format. An empty
+// timezone is allowed and falls back to the session timezone.
+func (b *BusinessHours) Validate() error {
+	if b.Timezone != "" {
+		if _, err := time.LoadLocation(b.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q", b.Timezone)
+		}
+	}
+	for day, windows := range b.Schedule {
+		for _, w := range windows {
+			if _, err := time.Parse("15:04", w.Start); err != nil {
+				return fmt.Errorf("%s: invalid start %q", day, w.Start)
+			}
+			if _, err := time.Parse("15:04", w.End); err != nil {
+				return fmt.Errorf("%s: invalid end %q", day, w.End)
+			}
+		}
+	}
+	if b.Enabled && b.AwayMessage == "" {
+		return fmt.Errorf("away_message is required when enabled")
+	}
+	return nil
+}
+
+// IsOpen reports whether t falls inside an open window of the schedule.
+// fallback is the location used when the schedule has no timezone of its
+// own (usually the session timezone).
+func (b *BusinessHours) IsOpen(t time.Time, fallback *time.Location) bool {
+	loc := fallback
+	if b.Timezone != "" {
+		if l, err := time.LoadLocation(b.Timezone); err == nil {
+			loc = l
+		}
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := t.In(loc)
+	day := []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}[local.Weekday()]
+	clock := local.Format("15:04")
+	for _, w := range b.Schedule[day] {
+		if clock >= w.Start && clock < w.End {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplyInterval returns the per-chat away reply throttle.
+func (b *BusinessHours) ReplyInterval() time.Duration {
+	if b.ReplyIntervalMinutes <= 0 {
+		return 6 * time.Hour
+	}
+	return time.Duration(b.ReplyIntervalMinutes) * time.Minute
+}
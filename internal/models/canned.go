@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// CannedResponse is a reusable quick reply addressed by a short
+// shortcut (e.g. "/greet"). The optional media URL is fetched at send
+// time so the library stays a thin pointer store.
+type CannedResponse struct {
+	SessionID string    `json:"session_id" db:"session_id"`
+	Shortcut  string    `json:"shortcut" db:"shortcut"`
+	Body      string    `json:"body" db:"body"`
+	MediaURL  string    `json:"media_url,omitempty" db:"media_url"`
+	MediaMime string    `json:"media_mime,omitempty" db:"media_mime"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Contact is the service's own view of a contact, accumulated from
+// message traffic and app state rather than fetched on demand.
+type Contact struct {
+	SessionID string `json:"session_id" db:"session_id"`
+	JID       string `json:"jid" db:"jid"`
+	PushName  string `json:"push_name,omitempty" db:"push_name"`
+	FullName  string `json:"full_name,omitempty" db:"full_name"`
+	// Language is the contact's preferred language (BCP 47 tag), used to
+	// localize outgoing template sends when translation is configured.
+	Language  string    `json:"language,omitempty" db:"language"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Conversation workflow states.
+const (
+	ConversationStateOpen     = "open"
+	ConversationStatePending  = "pending"
+	ConversationStateResolved = "resolved"
+)
+
+// ValidConversationState reports whether s is a known workflow state.
+func ValidConversationState(s string) bool {
+	switch s {
+	case ConversationStateOpen, ConversationStatePending, ConversationStateResolved:
+		return true
+	}
+	return false
+}
+
+// Conversation is the minimal ticketing layer over a chat: a workflow
+// state plus an assigned agent identifier. Assignment semantics (round
+// robin, skills) live in the caller; the server only stores and reports.
+type Conversation struct {
+	SessionID string `json:"session_id" db:"session_id"`
+	ChatJID   string `json:"chat_jid" db:"chat_jid"`
+	// CanonicalJID is runtime-only: the phone form of the chat JID when
+	// an identity mapping links a LID chat to a phone identity.
+	CanonicalJID string `json:"canonical_jid,omitempty" db:"-"`
+	State        string `json:"state" db:"state"`
+	Agent        string `json:"agent,omitempty" db:"agent"`
+	// ResolvedAt records the last transition into resolved; the next
+	// resolution's transcript covers messages after this point.
+	ResolvedAt *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+}
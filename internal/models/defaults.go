@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// SessionDefaults are operator-editable defaults applied to newly
+// created sessions wherever the create request leaves a field unset.
+// Stored as a single document so the whole set is edited atomically;
+// nil pointers mean "no default configured" and the server-wide
+// hardcoded fallbacks apply.
+type SessionDefaults struct {
+	// Events is the default webhook subscription for new sessions.
+	Events                   []string  `json:"events,omitempty"`
+	ReceiptSampleRate        *float64  `json:"receipt_sample_rate,omitempty"`
+	PresenceMinInterval      *int      `json:"presence_min_interval,omitempty"`
+	PresenceHeartbeatSeconds *int      `json:"presence_heartbeat_seconds,omitempty"`
+	InlineMediaMaxBytes      *int      `json:"inline_media_max_bytes,omitempty"`
+	RetentionDays            *int      `json:"retention_days,omitempty"`
+	DedupeWindowSeconds      *int      `json:"dedupe_window_seconds,omitempty"`
+	UpdatedAt                time.Time `json:"updated_at"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event types emitted to webhooks and the queue.
+const (
+	EventMessage      = "message"
+	EventReceipt      = "receipt"
+	EventPresence     = "presence"
+	EventConnected    = "connected"
+	EventDisconnected = "disconnected"
+	EventQRCode       = "qrcode"
+	EventLoggedOut    = "logged_out"
+	EventPicture      = "picture"
+	EventContact      = "contact_update"
+	// EventRetentionPurge is the audit record written whenever the
+	// retention runner purges message content for a session.
+	EventRetentionPurge = "retention_purge"
+	// EventLinkClick fires when a tracked short link is followed.
+	EventLinkClick = "link_click"
+	// EventConversation fires on conversation workflow changes (state or
+	// assignment).
+	EventConversation = "conversation_update"
+	// EventModeration fires for every automatic group moderation action.
+	EventModeration = "moderation_action"
+	// EventSubscription fires when a contact joins or leaves a topic.
+	EventSubscription = "subscription_update"
+	// EventNewsletterMessage fires for messages in followed newsletter
+	// channels, with the channel metadata attached.
+	EventNewsletterMessage = "newsletter_message"
+	// EventMessageRetry fires when a recipient keeps failing to decrypt
+	// one of our messages after the automatic resend.
+	EventMessageRetry = "message_retry"
+	// EventChatPresence fires when a peer starts or stops typing or
+	// recording in a chat, debounced per sender.
+	EventChatPresence = "chat_presence"
+	// EventMaintenance reminds operators to schedule maintenance: the
+	// session's pairing is older than the configured maximum or its
+	// credentials need re-pairing.
+	EventMaintenance = "maintenance_reminder"
+	// EventHistorySync fires after a history sync payload (including
+	// on-demand backfill) has been ingested, with per-chat counts.
+	EventHistorySync = "history_sync"
+	// EventTranscript fires when a conversation is resolved, carrying
+	// the full message transcript since the previous resolution.
+	EventTranscript = "conversation_transcript"
+)
+
+// StoredEvent is one event persisted to the events table. Payload is the
+// exact JSON document that was (or would have been) delivered to the
+// session webhook, so replays are byte-identical to the original delivery.
+type StoredEvent struct {
+	ID        int64           `json:"id" db:"id"`
+	SessionID string          `json:"session_id" db:"session_id"`
+	Type      string          `json:"type" db:"type"`
+	Payload   json.RawMessage `json:"payload" db:"payload"`
+	Timestamp time.Time       `json:"timestamp" db:"timestamp"`
+}
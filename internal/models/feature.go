@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// FeatureFlag is a persisted feature toggle. An empty SessionID makes the
+// flag global; a session-scoped row overrides the global value for that
+// session only.
+type FeatureFlag struct {
+	Name      string    `json:"name"`
+	SessionID string    `json:"session_id,omitempty"`
+	Enabled   bool      `json:"enabled"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// InboundFilters configures spam/abuse filtering for inbound messages.
+// Matches are quarantined: stored with a reason but excluded from
+// webhooks, conversation tracking and auto-replies until released. A nil
+// value disables filtering.
+type InboundFilters struct {
+	// BlockPatterns are case-insensitive regular expressions matched
+	// against the message body.
+	BlockPatterns []string `json:"block_patterns,omitempty"`
+	// BlockLinks quarantines inbound messages containing a URL.
+	BlockLinks bool `json:"block_links,omitempty"`
+	// UnknownSenderMaxPerMinute caps messages per minute from senders not
+	// in the address book (no synced full name). 0 disables the throttle.
+	UnknownSenderMaxPerMinute int `json:"unknown_sender_max_per_minute,omitempty"`
+}
+
+// Validate checks the filter configuration, compiling every pattern so
+// bad regexps are rejected at set time rather than silently skipped in
+// the hot path.
+func (f *InboundFilters) Validate() error {
+	for _, p := range f.BlockPatterns {
+		if p == "" {
+			return fmt.Errorf("block pattern must not be empty")
+		}
+		if _, err := regexp.Compile("(?i)" + p); err != nil {
+			return fmt.Errorf("block pattern %q: %w", p, err)
+		}
+	}
+	if f.UnknownSenderMaxPerMinute < 0 {
+		return fmt.Errorf("unknown_sender_max_per_minute must not be negative")
+	}
+	return nil
+}
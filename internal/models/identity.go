@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Identity links the two JIDs WhatsApp may use for the same person: the
+// phone-number JID and the LID (hidden-user) JID. The phone JID is the
+// canonical form wherever both are known.
+type Identity struct {
+	SessionID string    `json:"session_id" db:"session_id"`
+	PhoneJID  string    `json:"phone_jid" db:"phone_jid"`
+	LIDJID    string    `json:"lid_jid" db:"lid_jid"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Canonical returns the identity's canonical JID.
+func (i *Identity) Canonical() string {
+	if i.PhoneJID != "" {
+		return i.PhoneJID
+	}
+	return i.LIDJID
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// InboxChat is one chat in the unified inbox: the latest activity of a
+// chat with session attribution, so multi-number deployments get one
+// working list across accounts. Unread approximates "inbound messages
+// since our last reply".
+type InboxChat struct {
+	SessionID string `json:"session_id"`
+	ChatJID   string `json:"chat_jid"`
+	// CanonicalJID is the phone-number form of the chat JID when an
+	// identity mapping links a LID chat to a phone identity.
+	CanonicalJID    string    `json:"canonical_jid,omitempty"`
+	LastActivity    time.Time `json:"last_activity"`
+	LastMessageID   string    `json:"last_message_id"`
+	LastMessageType string    `json:"last_message_type"`
+	LastMessageBody string    `json:"last_message_body,omitempty"`
+	LastFromMe      bool      `json:"last_from_me"`
+	// LastMessageReactions aggregates emoji counts on the last message,
+	// latest reaction per sender winning.
+	LastMessageReactions map[string]int `json:"last_message_reactions,omitempty"`
+	Unread               int64          `json:"unread"`
+}
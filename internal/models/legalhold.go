@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// LegalHold blocks retention purging for a chat. An empty ChatJID holds
+// the entire session. Holds are created and released by the operator;
+// the retention runner never touches held rows.
+type LegalHold struct {
+	SessionID string    `json:"session_id" db:"session_id"`
+	ChatJID   string    `json:"chat_jid,omitempty" db:"chat_jid"`
+	Reason    string    `json:"reason,omitempty" db:"reason"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
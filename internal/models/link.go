@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Link is one tracked short link minted by the link-wrapping service.
+// Clicks is bumped by the redirector on every hit.
+type Link struct {
+	Code      string     `json:"code" db:"code"`
+	SessionID string     `json:"session_id" db:"session_id"`
+	Campaign  string     `json:"campaign,omitempty" db:"campaign"`
+	TargetURL string     `json:"target_url" db:"target_url"`
+	MessageID string     `json:"message_id,omitempty" db:"message_id"`
+	Clicks    int64      `json:"clicks" db:"clicks"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	LastClick *time.Time `json:"last_click,omitempty" db:"last_click"`
+}
+
+// CampaignStats aggregates link performance for one campaign.
+type CampaignStats struct {
+	Campaign string `json:"campaign"`
+	Links    int64  `json:"links"`
+	Clicks   int64  `json:"clicks"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MediaPolicy caps what media a session may send. Limits are enforced
+// before the upload so oversized or disallowed files are rejected with a
+// descriptive error instead of failing mid-transfer. A nil policy means
+// no per-session limits.
+type MediaPolicy struct {
+	// Per-kind size caps in bytes; 0 means no cap for that kind.
+	MaxImageBytes    int64 `json:"max_image_bytes"`
+	MaxVideoBytes    int64 `json:"max_video_bytes"`
+	MaxDocumentBytes int64 `json:"max_document_bytes"`
+	// AllowedMimeTypes whitelists outbound media types. Entries are exact
+	// types ("image/png") or prefixes ending in "/" ("image/"). Empty
+	// allows everything.
+	AllowedMimeTypes []string `json:"allowed_mime_types,omitempty"`
+}
+
+// Validate rejects negative size caps and malformed MIME entries.
+func (p *MediaPolicy) Validate() error {
+	if p.MaxImageBytes < 0 || p.MaxVideoBytes < 0 || p.MaxDocumentBytes < 0 {
+		return fmt.Errorf("size limits must be >= 0")
+	}
+	for _, m := range p.AllowedMimeTypes {
+		if m == "" || !strings.Contains(m, "/") {
+			return fmt.Errorf("invalid mime type %q", m)
+		}
+	}
+	return nil
+}
+
+// maxFor returns the size cap applying to the given MIME type. Anything
+// that is not an image or video counts as a document.
+func (p *MediaPolicy) maxFor(mimeType string) int64 {
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		return p.MaxImageBytes
+	case strings.HasPrefix(mimeType, "video/"):
+		return p.MaxVideoBytes
+	default:
+		return p.MaxDocumentBytes
+	}
+}
+
+// mimeAllowed reports whether the MIME type passes the whitelist.
+func (p *MediaPolicy) mimeAllowed(mimeType string) bool {
+	if len(p.AllowedMimeTypes) == 0 {
+		return true
+	}
+	for _, allowed := range p.AllowedMimeTypes {
+		if strings.HasSuffix(allowed, "/") {
+			if strings.HasPrefix(mimeType, allowed) {
+				return true
+			}
+		} else if strings.EqualFold(mimeType, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check verifies a piece of outbound media against the policy and
+// returns a descriptive error when it is rejected.
+func (p *MediaPolicy) Check(mimeType string, size int64) error {
+	if !p.mimeAllowed(mimeType) {
+		return fmt.Errorf("media type %s is not allowed by the session media policy", mimeType)
+	}
+	if max := p.maxFor(mimeType); max > 0 && size > max {
+		return fmt.Errorf("media of %d bytes exceeds the session limit of %d bytes for %s", size, max, mimeType)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Message types stored in the messages table.
+const (
+	MessageTypeText     = "text"
+	MessageTypeImage    = "image"
+	MessageTypeVideo    = "video"
+	MessageTypeAudio    = "audio"
+	MessageTypeDocument = "document"
+	MessageTypeSticker  = "sticker"
+	MessageTypeLocation = "location"
+	MessageTypeContact  = "contact"
+	MessageTypeReaction = "reaction"
+	MessageTypeRevoke   = "revoke"
+	MessageTypeUnknown  = "unknown"
+)
+
+// Message is a stored WhatsApp message, inbound or outbound. Raw keeps the
+// serialized protocol payload so events can be replayed without loss.
+type Message struct {
+	ID        int64  `json:"-" db:"id"`
+	SessionID string `json:"session_id" db:"session_id"`
+	MessageID string `json:"message_id" db:"message_id"`
+	ChatJID   string `json:"chat_jid" db:"chat_jid"`
+	SenderJID string `json:"sender_jid" db:"sender_jid"`
+	FromMe    bool   `json:"from_me" db:"from_me"`
+	Type      string `json:"type" db:"type"`
+	Body      string `json:"body,omitempty" db:"body"`
+	MediaPath string `json:"media_path,omitempty" db:"media_path"`
+	MediaMime string `json:"media_mime,omitempty" db:"media_mime"`
+	// MediaSHA256 is the hex content hash of the archived media blob; it
+	// keys the shared media_objects reference counts.
+	MediaSHA256 string `json:"media_sha256,omitempty" db:"media_sha256"`
+	QuotedID    string `json:"quoted_id,omitempty" db:"quoted_id"`
+	// ThreadID is the message id of the reply chain's root. Messages that
+	// start a conversation (no quote) root their own thread.
+	ThreadID string `json:"thread_id,omitempty" db:"thread_id"`
+	// Tags are produced by the enrichment pipeline at ingestion time.
+	Tags []string        `json:"tags,omitempty" db:"tags"`
+	Raw  json.RawMessage `json:"-" db:"raw"`
+	// Quarantined messages matched an inbound filter: stored, but held
+	// back from webhooks and auto-replies until released via the API.
+	Quarantined      bool   `json:"quarantined,omitempty" db:"quarantined"`
+	QuarantineReason string `json:"quarantine_reason,omitempty" db:"quarantine_reason"`
+	// ScanVerdict records the malware scan outcome for document media
+	// ("clean", "infected", or "infected: <signature>"); empty when the
+	// message was not scanned.
+	ScanVerdict string `json:"scan_verdict,omitempty" db:"scan_verdict"`
+	// ExtractedText holds text pulled out of media by the OCR/extraction
+	// provider (image OCR, PDF text), making media content searchable and
+	// routable by keyword.
+	ExtractedText string `json:"extracted_text,omitempty" db:"extracted_text"`
+	// Transcript holds the speech-to-text transcription of an inbound
+	// voice note, produced by the transcription provider.
+	Transcript string `json:"transcript,omitempty" db:"transcript"`
+	// Language is the detected ISO 639-1 language of the text body,
+	// empty when detection was inconclusive. Used for routing
+	// conversations to the right support team.
+	Language string `json:"language,omitempty" db:"language"`
+	// Mentions lists the JIDs @-mentioned in the message, extracted from
+	// the protocol context at ingestion.
+	Mentions []string `json:"mentions,omitempty" db:"mentions"`
+	// Starred mirrors the WhatsApp star state, kept in sync from app
+	// state updates.
+	Starred bool `json:"starred,omitempty" db:"starred"`
+	// Retries counts retry receipts per recipient JID, tracking how often
+	// each device asked for this message to be re-sent.
+	Retries map[string]int `json:"retries,omitempty" db:"retries"`
+	// ReadAt is when we marked this inbound message read via the API; nil
+	// while unread (and always nil for our own messages).
+	ReadAt *time.Time `json:"read_at,omitempty" db:"read_at"`
+	// Reactions is runtime-only: the aggregated emoji counts on this
+	// message (latest reaction per sender wins), populated by the history
+	// endpoints from stored reaction rows.
+	Reactions map[string]int `json:"reactions,omitempty" db:"-"`
+	// CanonicalChatJID/CanonicalSenderJID are runtime-only: the phone
+	// forms of the chat and sender JIDs when an identity mapping links
+	// their LIDs to phone identities, populated by the history endpoints.
+	CanonicalChatJID   string    `json:"canonical_chat_jid,omitempty" db:"-"`
+	CanonicalSenderJID string    `json:"canonical_sender_jid,omitempty" db:"-"`
+	Timestamp          time.Time `json:"timestamp" db:"timestamp"`
+	CreatedAt          time.Time `json:"-" db:"created_at"`
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Moderation actions recorded in the moderation log.
+const (
+	ModerationActionDelete = "delete"
+	ModerationActionWarn   = "warn"
+	ModerationActionKick   = "kick"
+)
+
+// GroupModeration configures automatic moderation for groups the session
+// participates in. Messages matching a pattern are revoked; repeat
+// offenders are warned and eventually removed. A nil value disables
+// moderation.
+type GroupModeration struct {
+	// Patterns are case-insensitive regular expressions matched against
+	// message bodies in groups.
+	Patterns []string `json:"patterns"`
+	// Groups limits moderation to the listed group JIDs; empty moderates
+	// every group.
+	Groups []string `json:"groups,omitempty"`
+	// WarnThreshold is the offense count at which the offender is warned
+	// in the group. 0 disables warnings.
+	WarnThreshold int `json:"warn_threshold,omitempty"`
+	// KickThreshold is the offense count at which the offender is removed
+	// from the group. 0 disables kicks.
+	KickThreshold int `json:"kick_threshold,omitempty"`
+}
+
+// Validate checks the moderation configuration, compiling every pattern
+// up front.
+func (g *GroupModeration) Validate() error {
+	if len(g.Patterns) == 0 {
+		return fmt.Errorf("at least one pattern is required")
+	}
+	for _, p := range g.Patterns {
+		if p == "" {
+			return fmt.Errorf("pattern must not be empty")
+		}
+		if _, err := regexp.Compile("(?i)" + p); err != nil {
+			return fmt.Errorf("pattern %q: %w", p, err)
+		}
+	}
+	if g.WarnThreshold < 0 || g.KickThreshold < 0 {
+		return fmt.Errorf("thresholds must not be negative")
+	}
+	if g.WarnThreshold > 0 && g.KickThreshold > 0 && g.WarnThreshold > g.KickThreshold {
+		return fmt.Errorf("warn_threshold must not exceed kick_threshold")
+	}
+	return nil
+}
+
+// Applies reports whether moderation covers the given group.
+func (g *GroupModeration) Applies(groupJID string) bool {
+	if len(g.Groups) == 0 {
+		return true
+	}
+	for _, jid := range g.Groups {
+		if jid == groupJID {
+			return true
+		}
+	}
+	return false
+}
+
+// ModerationAction is one entry in the moderation log.
+type ModerationAction struct {
+	ID        int64     `json:"id" db:"id"`
+	SessionID string    `json:"session_id" db:"session_id"`
+	GroupJID  string    `json:"group_jid" db:"group_jid"`
+	TargetJID string    `json:"target_jid" db:"target_jid"`
+	MessageID string    `json:"message_id,omitempty" db:"message_id"`
+	Action    string    `json:"action" db:"action"`
+	Reason    string    `json:"reason,omitempty" db:"reason"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
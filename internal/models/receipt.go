@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Receipt is one stored delivery/read/played receipt for a message.
+type Receipt struct {
+	SessionID string    `json:"session_id" db:"session_id"`
+	MessageID string    `json:"message_id" db:"message_id"`
+	ChatJID   string    `json:"chat_jid" db:"chat_jid"`
+	SenderJID string    `json:"sender_jid" db:"sender_jid"`
+	Type      string    `json:"type" db:"type"`
+	Timestamp time.Time `json:"timestamp" db:"timestamp"`
+}
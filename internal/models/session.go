@@ -0,0 +1,165 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Session status values as exposed by the API.
+const (
+	SessionStatusDisconnected = "disconnected"
+	SessionStatusConnecting   = "connecting"
+	SessionStatusConnected    = "connected"
+	SessionStatusLoggedOut    = "logged_out"
+	SessionStatusPaused       = "paused"
+)
+
+// Session is one WhatsApp account managed by the service. A session owns
+// its whatsmeow device credentials, its webhook configuration and its own
+// API key.
+type Session struct {
+	ID        string `json:"id" db:"id"`
+	Name      string `json:"name" db:"name"`
+	DeviceJID string `json:"device_jid,omitempty" db:"device_jid"`
+	// PairedAt is when the current device pairing happened; nil while
+	// unpaired. Feeds the maintenance reminder for aged pairings.
+	PairedAt *time.Time `json:"paired_at,omitempty" db:"paired_at"`
+	Status   string     `json:"status" db:"status"`
+	APIKey   string     `json:"api_key,omitempty" db:"api_key"`
+	// Sandbox marks a test session: sends are persisted and echoed back
+	// via webhook as if delivered, but never reach WhatsApp, so
+	// integrations can be exercised without a paired number.
+	Sandbox    bool   `json:"sandbox,omitempty" db:"sandbox"`
+	WebhookURL string `json:"webhook_url,omitempty" db:"webhook_url"`
+	// Events is the list of event types delivered to the webhook. The
+	// special value "all" subscribes to every event.
+	Events   []string `json:"events" db:"events"`
+	ProxyURL string   `json:"proxy_url,omitempty" db:"proxy_url"`
+	// EgressProxyURL overrides the global egress proxy for this session's
+	// webhook deliveries and media fetches. Distinct from ProxyURL, which
+	// proxies the WhatsApp socket itself.
+	EgressProxyURL string `json:"egress_proxy_url,omitempty" db:"egress_proxy_url"`
+	// ReceiptSampleRate is the fraction of receipt events that are
+	// emitted (1 = all, 0 = none). Messages themselves are unaffected.
+	ReceiptSampleRate float64 `json:"receipt_sample_rate" db:"receipt_sample_rate"`
+	// PresenceMinInterval throttles presence events to at most one per
+	// contact per this many seconds. 0 disables throttling.
+	PresenceMinInterval int `json:"presence_min_interval" db:"presence_min_interval"`
+	// PresenceHeartbeatSeconds re-announces "available" presence at this
+	// interval while connected, keeping the number visibly online. 0
+	// disables the heartbeat.
+	PresenceHeartbeatSeconds int `json:"presence_heartbeat_seconds" db:"presence_heartbeat_seconds"`
+	// Locale is a BCP 47 tag ("pt-BR", "en") selecting the full-text
+	// search dictionary and template/date rendering language. Empty means
+	// no language-specific behaviour.
+	Locale string `json:"locale,omitempty" db:"locale"`
+	// Timezone is the IANA zone used for timestamp formatting in exports
+	// and as the fallback for business-hours evaluation. Empty means UTC.
+	Timezone string `json:"timezone,omitempty" db:"timezone"`
+	// RetentionDays is how long message content is kept before the
+	// retention runner blanks it (metadata stays). 0 keeps content
+	// forever. Legal holds always win over retention.
+	RetentionDays int `json:"retention_days" db:"retention_days"`
+	// InlineMediaMaxBytes embeds inbound media up to this size as base64
+	// in message webhook payloads; larger blobs are referenced by URL
+	// only. 0 disables inlining.
+	InlineMediaMaxBytes int `json:"inline_media_max_bytes" db:"inline_media_max_bytes"`
+	// DedupeWindowSeconds rejects an identical text to the same recipient
+	// within this many seconds of the previous send. 0 disables the
+	// guard.
+	DedupeWindowSeconds int `json:"dedupe_window_seconds" db:"dedupe_window_seconds"`
+	// BusinessHours configures the auto-away responder; nil means the
+	// responder is not configured.
+	BusinessHours *BusinessHours `json:"business_hours,omitempty" db:"business_hours"`
+	// InboundFilters configures spam quarantine for inbound messages; nil
+	// disables filtering.
+	InboundFilters *InboundFilters `json:"inbound_filters,omitempty" db:"inbound_filters"`
+	// GroupModeration configures automatic group moderation; nil disables
+	// it.
+	GroupModeration *GroupModeration `json:"group_moderation,omitempty" db:"group_moderation"`
+	// MediaPolicy caps outbound media size and type; nil means no limits.
+	MediaPolicy *MediaPolicy `json:"media_policy,omitempty" db:"media_policy"`
+	// Quota caps daily metered consumption; nil means unlimited.
+	Quota *Quota `json:"quota,omitempty" db:"quota"`
+	// APIVersion is the API version this session's key is pinned to,
+	// set at creation. Empty (pre-versioning sessions) means current.
+	APIVersion string `json:"api_version,omitempty" db:"api_version"`
+	// Metadata holds free-form operator notes and labels, edited via the
+	// JSON merge-patch metadata endpoint.
+	Metadata  map[string]any `json:"metadata,omitempty" db:"metadata"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at" db:"updated_at"`
+	// SendQueue is runtime-only: the per-priority depth of the send
+	// queue, populated on the session status endpoint.
+	SendQueue map[string]int `json:"send_queue,omitempty" db:"-"`
+}
+
+// Location resolves the session timezone, falling back to UTC when unset
+// or invalid.
+func (s *Session) Location() *time.Location {
+	if s.Timezone != "" {
+		if loc, err := time.LoadLocation(s.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// searchConfigs maps locale language prefixes to Postgres text search
+// configurations.
+var searchConfigs = map[string]string{
+	"pt": "portuguese",
+	"en": "english",
+	"es": "spanish",
+	"fr": "french",
+	"de": "german",
+	"it": "italian",
+	"nl": "dutch",
+	"ru": "russian",
+}
+
+// SearchConfig returns the Postgres text search configuration for the
+// session locale. Unknown or empty locales get "simple", which does
+// plain token matching without stemming.
+func (s *Session) SearchConfig() string {
+	lang := strings.ToLower(s.Locale)
+	if len(lang) > 2 {
+		lang = lang[:2]
+	}
+	if cfg, ok := searchConfigs[lang]; ok {
+		return cfg
+	}
+	return "simple"
+}
+
+// dateLayouts maps locale language prefixes to timestamp layouts for
+// exports and template rendering. Locales without an entry use the
+// day-first default.
+var dateLayouts = map[string]string{
+	"en": "01/02/2006 3:04 PM",
+}
+
+// FormatTimestamp renders a timestamp in the session's timezone using
+// the session locale's conventional layout.
+func (s *Session) FormatTimestamp(t time.Time) string {
+	layout := "02/01/2006 15:04"
+	lang := strings.ToLower(s.Locale)
+	if len(lang) > 2 {
+		lang = lang[:2]
+	}
+	if l, ok := dateLayouts[lang]; ok {
+		layout = l
+	}
+	return t.In(s.Location()).Format(layout)
+}
+
+// WantsEvent reports whether the session's webhook subscription includes
+// the given event type.
+func (s *Session) WantsEvent(eventType string) bool {
+	for _, e := range s.Events {
+		if e == "all" || e == eventType {
+			return true
+		}
+	}
+	return false
+}
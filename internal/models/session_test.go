@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSearchConfig(t *testing.T) {
+	cases := []struct {
+		locale string
+		want   string
+	}{
+		{"pt-BR", "portuguese"},
+		{"pt", "portuguese"},
+		{"EN-us", "english"},
+		{"es-AR", "spanish"},
+		{"ja-JP", "simple"},
+		{"", "simple"},
+	}
+	for _, tc := range cases {
+		s := &Session{Locale: tc.locale}
+		if got := s.SearchConfig(); got != tc.want {
+			t.Errorf("SearchConfig(%q) = %q, want %q", tc.locale, got, tc.want)
+		}
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	ts := time.Date(2024, 6, 5, 18, 30, 0, 0, time.UTC)
+
+	// Day-first default rendered in the session timezone.
+	s := &Session{Locale: "pt-BR", Timezone: "America/Sao_Paulo"}
+	if got := s.FormatTimestamp(ts); got != "05/06/2024 15:30" {
+		t.Errorf("pt-BR format = %q", got)
+	}
+
+	// English locales get the month-first 12-hour layout.
+	s = &Session{Locale: "en-US"}
+	if got := s.FormatTimestamp(ts); got != "06/05/2024 6:30 PM" {
+		t.Errorf("en-US format = %q", got)
+	}
+
+	// Unset locale and timezone fall back to the default layout in UTC.
+	s = &Session{}
+	if got := s.FormatTimestamp(ts); got != "05/06/2024 18:30" {
+		t.Errorf("default format = %q", got)
+	}
+}
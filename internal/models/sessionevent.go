@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Session lifecycle event kinds recorded for the debugging timeline.
+const (
+	SessionEventQRGenerated   = "qr_generated"
+	SessionEventPaired        = "paired"
+	SessionEventConnected     = "connected"
+	SessionEventDisconnected  = "disconnected"
+	SessionEventLoggedOut     = "logged_out"
+	SessionEventStreamError   = "stream_error"
+	SessionEventConnectFailed = "connect_failure"
+	SessionEventTemporaryBan  = "temporary_ban"
+)
+
+// SessionEvent is one entry in a session's connection lifecycle
+// timeline, kept separate from webhook events so support can
+// reconstruct what happened to a flaky session.
+type SessionEvent struct {
+	ID        int64  `json:"id" db:"id"`
+	SessionID string `json:"session_id" db:"session_id"`
+	Kind      string `json:"kind" db:"kind"`
+	// Detail carries the kind-specific context, e.g. the disconnect or
+	// logout reason.
+	Detail    string    `json:"detail,omitempty" db:"detail"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// ConversationSLA is one resolved conversation's service-level
+// measurements. The clock starts at the first inbound message after the
+// previous resolution: FirstResponseSeconds is the gap to the session's
+// first reply (nil when the conversation was resolved without one) and
+// ResolutionSeconds the gap to the resolution itself.
+type ConversationSLA struct {
+	ID                   int64     `json:"id" db:"id"`
+	SessionID            string    `json:"session_id" db:"session_id"`
+	ChatJID              string    `json:"chat_jid" db:"chat_jid"`
+	Agent                string    `json:"agent,omitempty" db:"agent"`
+	FirstResponseSeconds *float64  `json:"first_response_seconds,omitempty" db:"first_response_seconds"`
+	ResolutionSeconds    float64   `json:"resolution_seconds" db:"resolution_seconds"`
+	ResolvedAt           time.Time `json:"resolved_at" db:"resolved_at"`
+}
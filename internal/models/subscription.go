@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Subscription is one contact's membership in a keyword topic, managed
+// by the contact itself via join/leave messages or through the API.
+type Subscription struct {
+	SessionID string    `json:"session_id" db:"session_id"`
+	Topic     string    `json:"topic" db:"topic"`
+	JID       string    `json:"jid" db:"jid"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// TopicSummary is a topic with its subscriber count.
+type TopicSummary struct {
+	Topic       string `json:"topic" db:"topic"`
+	Subscribers int    `json:"subscribers" db:"subscribers"`
+}
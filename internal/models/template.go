@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Template is a reusable message body with {{variable}} placeholders,
+// stored per session and addressed by name.
+type Template struct {
+	SessionID string `json:"session_id" db:"session_id"`
+	Name      string `json:"name" db:"name"`
+	Body      string `json:"body" db:"body"`
+	// Source optionally declares a remote variable source queried with
+	// the recipient at render time; nil renders from caller-supplied
+	// variables only.
+	Source    *VariableSource `json:"source,omitempty" db:"source"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// VariableSource is an HTTP endpoint returning a flat JSON object of
+// personalization variables for one recipient. The recipient JID is
+// passed as the "recipient" query parameter.
+type VariableSource struct {
+	URL string `json:"url"`
+	// TimeoutSeconds bounds the fetch; defaults to 5.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+	// CacheTTLSeconds keeps fetched variables per recipient; defaults to
+	// 300. 0 uses the default; use a negative value to disable caching.
+	CacheTTLSeconds int `json:"cache_ttl_seconds,omitempty"`
+	// Fallbacks are used for variables the source did not return, or for
+	// all variables when the fetch fails outright.
+	Fallbacks map[string]string `json:"fallbacks,omitempty"`
+}
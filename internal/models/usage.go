@@ -0,0 +1,39 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Usage is one day's metered consumption for a session, the unit billing
+// integrations work with.
+type Usage struct {
+	SessionID         string    `json:"session_id" db:"session_id"`
+	Day               time.Time `json:"day" db:"day"`
+	MessagesSent      int64     `json:"messages_sent" db:"messages_sent"`
+	MediaBytes        int64     `json:"media_bytes" db:"media_bytes"`
+	WebhookDeliveries int64     `json:"webhook_deliveries" db:"webhook_deliveries"`
+}
+
+// Quota caps a session's daily consumption. Zero values mean unlimited.
+type Quota struct {
+	MaxMessagesPerDay   int64 `json:"max_messages_per_day"`
+	MaxMediaBytesPerDay int64 `json:"max_media_bytes_per_day"`
+	// MaxStorageBytes caps the session's total media footprint in the
+	// bucket; new blobs are refused once it is reached.
+	MaxStorageBytes int64 `json:"max_storage_bytes"`
+	// StorageAlertPercent emits an admin alert once storage passes this
+	// percentage of MaxStorageBytes. 0 disables the alert.
+	StorageAlertPercent int `json:"storage_alert_percent"`
+}
+
+// Validate rejects negative limits.
+func (q *Quota) Validate() error {
+	if q.MaxMessagesPerDay < 0 || q.MaxMediaBytesPerDay < 0 || q.MaxStorageBytes < 0 {
+		return fmt.Errorf("quota limits must be >= 0")
+	}
+	if q.StorageAlertPercent < 0 || q.StorageAlertPercent > 100 {
+		return fmt.Errorf("storage_alert_percent must be within [0, 100]")
+	}
+	return nil
+}
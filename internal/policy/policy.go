@@ -0,0 +1,160 @@
+// Package policy enforces outbound content rules before a message is
+// handed to WhatsApp: a built-in regex denylist and word stripper, plus
+// an optional external HTTP policy service that can allow, block or
+// rewrite the text.
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// maxResponseBytes bounds how much of the policy service response is
+// read.
+const maxResponseBytes = 1 << 20
+
+// Violation is the error returned when a policy blocks an outbound
+// message. Rule identifies which rule fired; Reason is safe to surface
+// to the API caller.
+type Violation struct {
+	Rule   string
+	Reason string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("blocked by policy %s: %s", v.Rule, v.Reason)
+}
+
+// Engine runs the configured policy rules in order: denylist, word
+// stripping, then the external service. A nil Engine allows everything.
+type Engine struct {
+	deny     []*regexp.Regexp
+	strip    []*regexp.Regexp
+	endpoint string
+	client   *http.Client
+	log      zerolog.Logger
+}
+
+// NewEngine builds an Engine from config, or nil when no rules are
+// configured. Invalid deny patterns are logged and skipped rather than
+// silently weakening the remaining rules at send time.
+func NewEngine(cfg config.PolicyConfig, log zerolog.Logger) *Engine {
+	if len(cfg.DenyPatterns) == 0 && len(cfg.StripWords) == 0 && cfg.Endpoint == "" {
+		return nil
+	}
+	e := &Engine{
+		endpoint: cfg.Endpoint,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		log:      log.With().Str("component", "policy").Logger(),
+	}
+	for _, p := range cfg.DenyPatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			e.log.Error().Err(err).Str("pattern", p).Msg("invalid policy deny pattern, skipping")
+			continue
+		}
+		e.deny = append(e.deny, re)
+	}
+	for _, w := range cfg.StripWords {
+		re, err := regexp.Compile(`(?i)\b` + regexp.QuoteMeta(w) + `\b`)
+		if err != nil {
+			e.log.Error().Err(err).Str("word", w).Msg("invalid policy strip word, skipping")
+			continue
+		}
+		e.strip = append(e.strip, re)
+	}
+	return e
+}
+
+// Apply runs the policy stage over outbound text, returning the
+// (possibly rewritten) text or a *Violation when a rule blocks the
+// send. External service failures fail open with a warning: an outage
+// at the policy service should not silence the whole deployment.
+func (e *Engine) Apply(ctx context.Context, sessionID, text string) (string, error) {
+	if e == nil {
+		return text, nil
+	}
+	for _, re := range e.deny {
+		if re.MatchString(text) {
+			return "", &Violation{Rule: "denylist", Reason: "text matches pattern " + re.String()}
+		}
+	}
+	for _, re := range e.strip {
+		text = re.ReplaceAllString(text, "")
+	}
+	if e.endpoint == "" {
+		return text, nil
+	}
+	return e.checkExternal(ctx, sessionID, text)
+}
+
+// externalResponse is the policy service's verdict. Action is "allow",
+// "block" or "modify"; Text replaces the message on "modify".
+type externalResponse struct {
+	Action string `json:"action"`
+	Text   string `json:"text"`
+	Rule   string `json:"rule"`
+	Reason string `json:"reason"`
+}
+
+func (e *Engine) checkExternal(ctx context.Context, sessionID, text string) (string, error) {
+	body, err := json.Marshal(map[string]string{
+		"session_id": sessionID,
+		"text":       text,
+	})
+	if err != nil {
+		return text, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		e.log.Warn().Err(err).Msg("build policy request")
+		return text, nil
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	resp, err := e.client.Do(req)
+	if err != nil {
+		e.log.Warn().Err(err).Msg("policy service unreachable, allowing send")
+		return text, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		e.log.Warn().Int("status", resp.StatusCode).
+			Dur("elapsed", time.Since(start)).
+			Msg("policy service returned non-200, allowing send")
+		return text, nil
+	}
+	var verdict externalResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&verdict); err != nil {
+		e.log.Warn().Err(err).Msg("decode policy response, allowing send")
+		return text, nil
+	}
+
+	switch verdict.Action {
+	case "block":
+		rule := verdict.Rule
+		if rule == "" {
+			rule = "external"
+		}
+		reason := verdict.Reason
+		if reason == "" {
+			reason = "blocked by policy service"
+		}
+		return "", &Violation{Rule: rule, Reason: reason}
+	case "modify":
+		return verdict.Text, nil
+	default:
+		return text, nil
+	}
+}
@@ -0,0 +1,108 @@
+// Package privacy implements PII controls for deployments subject to
+// privacy review: masking phone numbers and message bodies in logs, and
+// salted hashing of JIDs in webhook payloads.
+package privacy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// Redactor applies the configured privacy controls. The zero value (and
+// a Redactor built from an all-off config) passes everything through
+// unchanged, so call sites never need to branch.
+type Redactor struct {
+	redactLogs bool
+	hashJIDs   bool
+	salt       string
+}
+
+// NewRedactor builds a Redactor from the privacy config.
+func NewRedactor(cfg config.PrivacyConfig) *Redactor {
+	return &Redactor{
+		redactLogs: cfg.RedactLogs,
+		hashJIDs:   cfg.HashJIDs,
+		salt:       cfg.HashSalt,
+	}
+}
+
+// JID masks the user part of a JID for logging, keeping the server and
+// the last two digits so operators can still correlate lines.
+func (r *Redactor) JID(jid string) string {
+	if r == nil || !r.redactLogs {
+		return jid
+	}
+	user, server, ok := strings.Cut(jid, "@")
+	if !ok || len(user) <= 2 {
+		return jid
+	}
+	return "***" + user[len(user)-2:] + "@" + server
+}
+
+// Body replaces message text with a length marker for logging.
+func (r *Redactor) Body(body string) string {
+	if r == nil || !r.redactLogs {
+		return body
+	}
+	return fmt.Sprintf("[redacted %d chars]", len(body))
+}
+
+// HashPayloadJIDs rewrites every JID-shaped string value in a webhook
+// payload with its salted hash, recursively. Disabled (or on any decode
+// error) the payload is returned unchanged.
+func (r *Redactor) HashPayloadJIDs(raw json.RawMessage) json.RawMessage {
+	if r == nil || !r.hashJIDs {
+		return raw
+	}
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return raw
+	}
+	out, err := json.Marshal(r.hashValue(doc))
+	if err != nil {
+		return raw
+	}
+	return out
+}
+
+func (r *Redactor) hashValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			val[k] = r.hashValue(item)
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = r.hashValue(item)
+		}
+		return val
+	case string:
+		if isJID(val) {
+			return r.hashJID(val)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// hashJID keeps the server part so consumers can still tell users from
+// groups, but replaces the user part with a salted hash.
+func (r *Redactor) hashJID(jid string) string {
+	user, server, _ := strings.Cut(jid, "@")
+	sum := sha256.Sum256([]byte(r.salt + user))
+	return hex.EncodeToString(sum[:8]) + "@" + server
+}
+
+func isJID(s string) bool {
+	return strings.HasSuffix(s, "@s.whatsapp.net") ||
+		strings.HasSuffix(s, "@g.us") ||
+		strings.HasSuffix(s, "@broadcast") ||
+		strings.HasSuffix(s, "@lid")
+}
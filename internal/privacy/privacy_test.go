@@ -0,0 +1,105 @@
+package privacy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+func TestRedactorDisabledPassesThrough(t *testing.T) {
+	for _, r := range []*Redactor{nil, NewRedactor(config.PrivacyConfig{})} {
+		if got := r.JID("5511999999999@s.whatsapp.net"); got != "5511999999999@s.whatsapp.net" {
+			t.Errorf("JID passthrough = %q", got)
+		}
+		if got := r.Body("hello"); got != "hello" {
+			t.Errorf("Body passthrough = %q", got)
+		}
+		raw := json.RawMessage(`{"chat_jid":"123@g.us"}`)
+		if got := r.HashPayloadJIDs(raw); string(got) != string(raw) {
+			t.Errorf("HashPayloadJIDs passthrough = %s", got)
+		}
+	}
+}
+
+func TestJIDMasking(t *testing.T) {
+	r := NewRedactor(config.PrivacyConfig{RedactLogs: true})
+	cases := map[string]string{
+		"5511999999999@s.whatsapp.net": "***99@s.whatsapp.net",
+		"120363040111222333@g.us":      "***33@g.us",
+		// Too short to mask meaningfully, and non-JIDs, stay unchanged.
+		"ab@g.us": "ab@g.us",
+		"no-at":   "no-at",
+		"":        "",
+	}
+	for in, want := range cases {
+		if got := r.JID(in); got != want {
+			t.Errorf("JID(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := r.Body("secret message"); got != "[redacted 14 chars]" {
+		t.Errorf("Body = %q", got)
+	}
+}
+
+func TestHashPayloadJIDs(t *testing.T) {
+	r := NewRedactor(config.PrivacyConfig{HashJIDs: true, HashSalt: "pepper"})
+	raw := json.RawMessage(`{
+		"chat_jid": "5511999999999@s.whatsapp.net",
+		"body": "call me at 5511999999999",
+		"nested": {"sender": "123@lid"},
+		"participants": ["1@g.us", "2@g.us", 7]
+	}`)
+	var doc map[string]any
+	if err := json.Unmarshal(r.HashPayloadJIDs(raw), &doc); err != nil {
+		t.Fatalf("unmarshal hashed payload: %v", err)
+	}
+
+	chat := doc["chat_jid"].(string)
+	if chat == "5511999999999@s.whatsapp.net" {
+		t.Error("chat_jid was not hashed")
+	}
+	if !strings.HasSuffix(chat, "@s.whatsapp.net") {
+		t.Errorf("hashed JID lost its server part: %q", chat)
+	}
+	if doc["body"] != "call me at 5511999999999" {
+		t.Errorf("non-JID string was rewritten: %q", doc["body"])
+	}
+	if nested := doc["nested"].(map[string]any); nested["sender"] == "123@lid" {
+		t.Error("nested JID was not hashed")
+	}
+	parts := doc["participants"].([]any)
+	if parts[0] == "1@g.us" || parts[0] == parts[1] {
+		t.Errorf("array JIDs not hashed distinctly: %v", parts)
+	}
+	if parts[2].(float64) != 7 {
+		t.Errorf("non-string array element changed: %v", parts[2])
+	}
+
+	// Hashing is deterministic under one salt and diverges across salts.
+	again := r.HashPayloadJIDs(raw)
+	var doc2 map[string]any
+	if err := json.Unmarshal(again, &doc2); err != nil {
+		t.Fatalf("unmarshal second pass: %v", err)
+	}
+	if doc2["chat_jid"] != chat {
+		t.Error("hashing is not deterministic for one salt")
+	}
+	other := NewRedactor(config.PrivacyConfig{HashJIDs: true, HashSalt: "different"})
+	var doc3 map[string]any
+	if err := json.Unmarshal(other.HashPayloadJIDs(raw), &doc3); err != nil {
+		t.Fatalf("unmarshal other salt: %v", err)
+	}
+	if doc3["chat_jid"] == chat {
+		t.Error("different salts produced the same hash")
+	}
+}
+
+func TestHashPayloadJIDsInvalidJSON(t *testing.T) {
+	r := NewRedactor(config.PrivacyConfig{HashJIDs: true, HashSalt: "pepper"})
+	raw := json.RawMessage(`{broken`)
+	if got := r.HashPayloadJIDs(raw); string(got) != string(raw) {
+		t.Errorf("invalid JSON was rewritten: %s", got)
+	}
+}
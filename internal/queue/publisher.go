@@ -0,0 +1,89 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/rs/zerolog"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// Publisher publishes session events to a RabbitMQ topic exchange with
+// routing keys of the form "<session_id>.<event>". A nil Publisher is a
+// valid no-op, used when no queue is configured.
+type Publisher struct {
+	cfg  config.QueueConfig
+	log  zerolog.Logger
+	mu   sync.Mutex
+	conn *amqp.Connection
+	ch   *amqp.Channel
+}
+
+// NewPublisher connects to RabbitMQ and declares the exchange. It returns
+// (nil, nil) when cfg.URL is empty.
+func NewPublisher(cfg config.QueueConfig, log zerolog.Logger) (*Publisher, error) {
+	if cfg.URL == "" {
+		return nil, nil
+	}
+	p := &Publisher{cfg: cfg, log: log.With().Str("component", "queue").Logger()}
+	if err := p.connect(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Publisher) connect() error {
+	conn, err := amqp.Dial(p.cfg.URL)
+	if err != nil {
+		return fmt.Errorf("queue: dial: %w", err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("queue: channel: %w", err)
+	}
+	if err := ch.ExchangeDeclare(p.cfg.Exchange, "topic", true, false, false, false, nil); err != nil {
+		conn.Close()
+		return fmt.Errorf("queue: declare exchange: %w", err)
+	}
+	p.conn, p.ch = conn, ch
+	return nil
+}
+
+// Publish sends payload to the exchange. It transparently reconnects once
+// on a closed channel.
+func (p *Publisher) Publish(ctx context.Context, sessionID, event string, payload any) error {
+	if p == nil {
+		return nil
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("queue: marshal: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	msg := amqp.Publishing{ContentType: "application/json", Body: body}
+	key := sessionID + "." + event
+	err = p.ch.PublishWithContext(ctx, p.cfg.Exchange, key, false, false, msg)
+	if err != nil {
+		p.log.Warn().Err(err).Msg("publish failed, reconnecting")
+		if rerr := p.connect(); rerr != nil {
+			return rerr
+		}
+		err = p.ch.PublishWithContext(ctx, p.cfg.Exchange, key, false, false, msg)
+	}
+	return err
+}
+
+// Close tears down the AMQP connection.
+func (p *Publisher) Close() error {
+	if p == nil || p.conn == nil {
+		return nil
+	}
+	return p.conn.Close()
+}
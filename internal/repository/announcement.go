@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+const announcementColumns = `id, session_id, group_jid, cron, timezone, template, enabled, last_run_at, created_at, updated_at`
+
+// AnnouncementRepository persists recurring group announcements and the
+// per-group opt-out list.
+type AnnouncementRepository struct {
+	db *sql.DB
+}
+
+// NewAnnouncementRepository creates an AnnouncementRepository backed by db.
+func NewAnnouncementRepository(db *sql.DB) *AnnouncementRepository {
+	return &AnnouncementRepository{db: db}
+}
+
+func scanAnnouncement(row interface{ Scan(...any) error }) (*models.GroupAnnouncement, error) {
+	var a models.GroupAnnouncement
+	err := row.Scan(&a.ID, &a.SessionID, &a.GroupJID, &a.Cron, &a.Timezone,
+		&a.Template, &a.Enabled, &a.LastRunAt, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// Create inserts a new announcement.
+func (r *AnnouncementRepository) Create(ctx context.Context, a *models.GroupAnnouncement) error {
+	now := time.Now().UTC()
+	a.CreatedAt = now
+	a.UpdatedAt = now
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO group_announcements (`+announcementColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		a.ID, a.SessionID, a.GroupJID, a.Cron, a.Timezone, a.Template,
+		a.Enabled, a.LastRunAt, a.CreatedAt, a.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("create announcement: %w", err)
+	}
+	return nil
+}
+
+// Get returns one announcement of a session.
+func (r *AnnouncementRepository) Get(ctx context.Context, sessionID, id string) (*models.GroupAnnouncement, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+announcementColumns+`
+		FROM group_announcements WHERE session_id = $1 AND id = $2`,
+		sessionID, id)
+	return scanAnnouncement(row)
+}
+
+// List returns a session's announcements, newest first.
+func (r *AnnouncementRepository) List(ctx context.Context, sessionID string) ([]*models.GroupAnnouncement, error) {
+	return r.query(ctx, `
+		SELECT `+announcementColumns+`
+		FROM group_announcements WHERE session_id = $1
+		ORDER BY created_at DESC`, sessionID)
+}
+
+// ListEnabled returns every enabled announcement across all sessions,
+// for the scheduler sweep.
+func (r *AnnouncementRepository) ListEnabled(ctx context.Context) ([]*models.GroupAnnouncement, error) {
+	return r.query(ctx, `
+		SELECT `+announcementColumns+`
+		FROM group_announcements WHERE enabled`)
+}
+
+func (r *AnnouncementRepository) query(ctx context.Context, q string, args ...any) ([]*models.GroupAnnouncement, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list announcements: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.GroupAnnouncement
+	for rows.Next() {
+		a, err := scanAnnouncement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan announcement: %w", err)
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// SetEnabled toggles an announcement without touching its schedule.
+func (r *AnnouncementRepository) SetEnabled(ctx context.Context, sessionID, id string, enabled bool) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE group_announcements SET enabled = $3, updated_at = NOW()
+		WHERE session_id = $1 AND id = $2`,
+		sessionID, id, enabled)
+	if err != nil {
+		return fmt.Errorf("toggle announcement: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// MarkRan records the last successful fire time.
+func (r *AnnouncementRepository) MarkRan(ctx context.Context, id string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE group_announcements SET last_run_at = $2, updated_at = NOW()
+		WHERE id = $1`, id, at)
+	if err != nil {
+		return fmt.Errorf("mark announcement ran: %w", err)
+	}
+	return nil
+}
+
+// Delete removes an announcement.
+func (r *AnnouncementRepository) Delete(ctx context.Context, sessionID, id string) error {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM group_announcements WHERE session_id = $1 AND id = $2`,
+		sessionID, id)
+	if err != nil {
+		return fmt.Errorf("delete announcement: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// OptOutGroup excludes a group from every announcement of the session.
+func (r *AnnouncementRepository) OptOutGroup(ctx context.Context, sessionID, groupJID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO group_announcement_optouts (session_id, group_jid, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (session_id, group_jid) DO NOTHING`,
+		sessionID, groupJID)
+	if err != nil {
+		return fmt.Errorf("opt out group: %w", err)
+	}
+	return nil
+}
+
+// OptInGroup lifts a group's opt-out.
+func (r *AnnouncementRepository) OptInGroup(ctx context.Context, sessionID, groupJID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM group_announcement_optouts WHERE session_id = $1 AND group_jid = $2`,
+		sessionID, groupJID)
+	if err != nil {
+		return fmt.Errorf("opt in group: %w", err)
+	}
+	return nil
+}
+
+// ListOptOuts returns the session's opted-out group JIDs.
+func (r *AnnouncementRepository) ListOptOuts(ctx context.Context, sessionID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT group_jid FROM group_announcement_optouts
+		WHERE session_id = $1 ORDER BY group_jid`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("list announcement opt-outs: %w", err)
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var jid string
+		if err := rows.Scan(&jid); err != nil {
+			return nil, fmt.Errorf("scan opt-out: %w", err)
+		}
+		out = append(out, jid)
+	}
+	return out, rows.Err()
+}
+
+// OptOutSet returns every opt-out as a (session, group) lookup for the
+// scheduler sweep.
+func (r *AnnouncementRepository) OptOutSet(ctx context.Context) (map[string]map[string]bool, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT session_id, group_jid FROM group_announcement_optouts`)
+	if err != nil {
+		return nil, fmt.Errorf("load announcement opt-outs: %w", err)
+	}
+	defer rows.Close()
+
+	out := make(map[string]map[string]bool)
+	for rows.Next() {
+		var sessionID, jid string
+		if err := rows.Scan(&sessionID, &jid); err != nil {
+			return nil, fmt.Errorf("scan opt-out: %w", err)
+		}
+		if out[sessionID] == nil {
+			out[sessionID] = make(map[string]bool)
+		}
+		out[sessionID][jid] = true
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+const cannedColumns = `session_id, shortcut, body, media_url, media_mime, created_at, updated_at`
+
+// CannedRepository persists quick-reply canned responses in the
+// canned_responses table.
+type CannedRepository struct {
+	db *sql.DB
+}
+
+// NewCannedRepository creates a CannedRepository backed by db.
+func NewCannedRepository(db *sql.DB) *CannedRepository {
+	return &CannedRepository{db: db}
+}
+
+func scanCanned(row interface{ Scan(...any) error }) (*models.CannedResponse, error) {
+	var cr models.CannedResponse
+	err := row.Scan(&cr.SessionID, &cr.Shortcut, &cr.Body, &cr.MediaURL,
+		&cr.MediaMime, &cr.CreatedAt, &cr.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &cr, nil
+}
+
+// Upsert creates or replaces a canned response by (session, shortcut).
+func (r *CannedRepository) Upsert(ctx context.Context, cr *models.CannedResponse) error {
+	now := time.Now().UTC()
+	cr.UpdatedAt = now
+	if cr.CreatedAt.IsZero() {
+		cr.CreatedAt = now
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO canned_responses (`+cannedColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (session_id, shortcut)
+		DO UPDATE SET body = EXCLUDED.body, media_url = EXCLUDED.media_url,
+		              media_mime = EXCLUDED.media_mime, updated_at = EXCLUDED.updated_at`,
+		cr.SessionID, cr.Shortcut, cr.Body, cr.MediaURL, cr.MediaMime,
+		cr.CreatedAt, cr.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert canned response: %w", err)
+	}
+	return nil
+}
+
+// GetByShortcut returns one canned response.
+func (r *CannedRepository) GetByShortcut(ctx context.Context, sessionID, shortcut string) (*models.CannedResponse, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+cannedColumns+`
+		FROM canned_responses WHERE session_id = $1 AND shortcut = $2`,
+		sessionID, shortcut)
+	return scanCanned(row)
+}
+
+// Search returns the session's canned responses ordered by shortcut; a
+// non-empty query matches shortcut or body case-insensitively.
+func (r *CannedRepository) Search(ctx context.Context, sessionID, query string, limit int) ([]*models.CannedResponse, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+cannedColumns+`
+		FROM canned_responses
+		WHERE session_id = $1
+		  AND ($2 = '' OR shortcut ILIKE '%' || $2 || '%' OR body ILIKE '%' || $2 || '%')
+		ORDER BY shortcut
+		LIMIT $3`, sessionID, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("search canned responses: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.CannedResponse
+	for rows.Next() {
+		cr, err := scanCanned(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan canned response: %w", err)
+		}
+		out = append(out, cr)
+	}
+	return out, rows.Err()
+}
+
+// Delete removes a canned response.
+func (r *CannedRepository) Delete(ctx context.Context, sessionID, shortcut string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM canned_responses WHERE session_id = $1 AND shortcut = $2`,
+		sessionID, shortcut)
+	if err != nil {
+		return fmt.Errorf("delete canned response: %w", err)
+	}
+	return nil
+}
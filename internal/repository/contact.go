@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// ContactRepository persists contacts in the contacts table.
+type ContactRepository struct {
+	db *sql.DB
+}
+
+// NewContactRepository creates a ContactRepository backed by db.
+func NewContactRepository(db *sql.DB) *ContactRepository {
+	return &ContactRepository{db: db}
+}
+
+// UpsertPushName records the push name observed for a contact, updating
+// the row only when the name actually changed so message traffic does not
+// turn into constant writes.
+func (r *ContactRepository) UpsertPushName(ctx context.Context, sessionID, jid, pushName string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO contacts (session_id, jid, push_name, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (session_id, jid)
+		DO UPDATE SET push_name = EXCLUDED.push_name, updated_at = EXCLUDED.updated_at
+		WHERE contacts.push_name IS DISTINCT FROM EXCLUDED.push_name`,
+		sessionID, jid, pushName, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("upsert contact push name: %w", err)
+	}
+	return nil
+}
+
+// UpsertFullName records the address-book name synced for a contact.
+func (r *ContactRepository) UpsertFullName(ctx context.Context, sessionID, jid, fullName string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO contacts (session_id, jid, full_name, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (session_id, jid)
+		DO UPDATE SET full_name = EXCLUDED.full_name, updated_at = EXCLUDED.updated_at
+		WHERE contacts.full_name IS DISTINCT FROM EXCLUDED.full_name`,
+		sessionID, jid, fullName, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("upsert contact full name: %w", err)
+	}
+	return nil
+}
+
+// SetLanguage records the contact's preferred language.
+func (r *ContactRepository) SetLanguage(ctx context.Context, sessionID, jid, language string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO contacts (session_id, jid, language, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (session_id, jid)
+		DO UPDATE SET language = EXCLUDED.language, updated_at = EXCLUDED.updated_at`,
+		sessionID, jid, language, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("set contact language: %w", err)
+	}
+	return nil
+}
+
+// GetByJID returns a stored contact.
+func (r *ContactRepository) GetByJID(ctx context.Context, sessionID, jid string) (*models.Contact, error) {
+	var ct models.Contact
+	err := r.db.QueryRowContext(ctx, `
+		SELECT session_id, jid, push_name, full_name, language, updated_at
+		FROM contacts WHERE session_id = $1 AND jid = $2`,
+		sessionID, jid).Scan(&ct.SessionID, &ct.JID, &ct.PushName, &ct.FullName,
+		&ct.Language, &ct.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &ct, nil
+}
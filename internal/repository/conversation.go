@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// ConversationRepository persists conversation workflow state in the
+// conversations table.
+type ConversationRepository struct {
+	db *sql.DB
+}
+
+// NewConversationRepository creates a ConversationRepository backed by db.
+func NewConversationRepository(db *sql.DB) *ConversationRepository {
+	return &ConversationRepository{db: db}
+}
+
+// Upsert stores the conversation state and agent.
+func (r *ConversationRepository) Upsert(ctx context.Context, conv *models.Conversation) error {
+	conv.UpdatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO conversations (session_id, chat_jid, state, agent, resolved_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (session_id, chat_jid)
+		DO UPDATE SET state = EXCLUDED.state, agent = EXCLUDED.agent,
+		              resolved_at = EXCLUDED.resolved_at,
+		              updated_at = EXCLUDED.updated_at`,
+		conv.SessionID, conv.ChatJID, conv.State, conv.Agent, conv.ResolvedAt, conv.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert conversation: %w", err)
+	}
+	return nil
+}
+
+// EnsureOpen opens the conversation for a chat on inbound traffic:
+// unknown chats become open, resolved ones reopen, open/pending are left
+// alone. Returns the resulting state when something changed, or "" for a
+// no-op.
+func (r *ConversationRepository) EnsureOpen(ctx context.Context, sessionID, chatJID string) (string, error) {
+	var state string
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO conversations (session_id, chat_jid, state, agent, updated_at)
+		VALUES ($1, $2, 'open', '', $3)
+		ON CONFLICT (session_id, chat_jid)
+		DO UPDATE SET state = 'open', updated_at = EXCLUDED.updated_at
+		WHERE conversations.state = 'resolved'
+		RETURNING state`,
+		sessionID, chatJID, time.Now().UTC()).Scan(&state)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("ensure conversation open: %w", err)
+	}
+	return state, nil
+}
+
+// Get returns the conversation for one chat.
+func (r *ConversationRepository) Get(ctx context.Context, sessionID, chatJID string) (*models.Conversation, error) {
+	var conv models.Conversation
+	err := r.db.QueryRowContext(ctx, `
+		SELECT session_id, chat_jid, state, agent, resolved_at, updated_at
+		FROM conversations WHERE session_id = $1 AND chat_jid = $2`,
+		sessionID, chatJID).Scan(&conv.SessionID, &conv.ChatJID, &conv.State,
+		&conv.Agent, &conv.ResolvedAt, &conv.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &conv, nil
+}
+
+// List returns conversations for a session, optionally filtered by state
+// and/or agent, most recently updated first.
+func (r *ConversationRepository) List(ctx context.Context, sessionID, state, agent string, limit int) ([]*models.Conversation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT session_id, chat_jid, state, agent, resolved_at, updated_at
+		FROM conversations
+		WHERE session_id = $1
+		  AND ($2 = '' OR state = $2)
+		  AND ($3 = '' OR agent = $3)
+		ORDER BY updated_at DESC
+		LIMIT $4`, sessionID, state, agent, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list conversations: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.Conversation
+	for rows.Next() {
+		var conv models.Conversation
+		if err := rows.Scan(&conv.SessionID, &conv.ChatJID, &conv.State,
+			&conv.Agent, &conv.ResolvedAt, &conv.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan conversation: %w", err)
+		}
+		out = append(out, &conv)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// DefaultsRepository persists the operator-configured session defaults
+// as a single-row document in the session_defaults table.
+type DefaultsRepository struct {
+	db *sql.DB
+}
+
+// NewDefaultsRepository creates a DefaultsRepository backed by db.
+func NewDefaultsRepository(db *sql.DB) *DefaultsRepository {
+	return &DefaultsRepository{db: db}
+}
+
+// Get returns the stored defaults, or nil when none are configured.
+func (r *DefaultsRepository) Get(ctx context.Context) (*models.SessionDefaults, error) {
+	var doc []byte
+	var updatedAt time.Time
+	err := r.db.QueryRowContext(ctx,
+		`SELECT doc, updated_at FROM session_defaults WHERE id`).Scan(&doc, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get session defaults: %w", err)
+	}
+	var d models.SessionDefaults
+	if err := json.Unmarshal(doc, &d); err != nil {
+		return nil, fmt.Errorf("decode session defaults: %w", err)
+	}
+	d.UpdatedAt = updatedAt
+	return &d, nil
+}
+
+// Set stores the defaults, replacing any previous document.
+func (r *DefaultsRepository) Set(ctx context.Context, d *models.SessionDefaults) error {
+	d.UpdatedAt = time.Now().UTC()
+	doc, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("encode session defaults: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO session_defaults (id, doc, updated_at)
+		VALUES (TRUE, $1, $2)
+		ON CONFLICT (id) DO UPDATE SET doc = EXCLUDED.doc, updated_at = EXCLUDED.updated_at`,
+		doc, d.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("set session defaults: %w", err)
+	}
+	return nil
+}
+
+// Clear removes the stored defaults, restoring the hardcoded fallbacks.
+func (r *DefaultsRepository) Clear(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM session_defaults`)
+	if err != nil {
+		return fmt.Errorf("clear session defaults: %w", err)
+	}
+	return nil
+}
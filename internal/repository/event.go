@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// EventRepository persists delivered events in the events table so they
+// can be inspected and replayed later.
+type EventRepository struct {
+	db *sql.DB
+}
+
+// NewEventRepository creates an EventRepository backed by db.
+func NewEventRepository(db *sql.DB) *EventRepository {
+	return &EventRepository{db: db}
+}
+
+// Create inserts an event row.
+func (r *EventRepository) Create(ctx context.Context, e *models.StoredEvent) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO events (session_id, type, payload, timestamp)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		e.SessionID, e.Type, e.Payload, e.Timestamp).Scan(&e.ID)
+	if err != nil {
+		return fmt.Errorf("insert event: %w", err)
+	}
+	return nil
+}
+
+// ListByRange returns events for a session within [from, to], optionally
+// restricted to the given event types, in ascending timestamp order.
+func (r *EventRepository) ListByRange(ctx context.Context, sessionID string, from, to time.Time, types []string, limit int) ([]*models.StoredEvent, error) {
+	query := `
+		SELECT id, session_id, type, payload, timestamp FROM events
+		WHERE session_id = $1 AND timestamp >= $2 AND timestamp <= $3`
+	args := []any{sessionID, from, to}
+	if len(types) > 0 {
+		query += ` AND type = ANY($4)`
+		args = append(args, pq.StringArray(types))
+	}
+	// The limit is bound rather than interpolated; nothing in this query
+	// text ever comes from the caller.
+	query += fmt.Sprintf(` ORDER BY timestamp ASC LIMIT $%d`, len(args)+1)
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.StoredEvent
+	for rows.Next() {
+		var e models.StoredEvent
+		if err := rows.Scan(&e.ID, &e.SessionID, &e.Type, &e.Payload, &e.Timestamp); err != nil {
+			return nil, err
+		}
+		out = append(out, &e)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// FeatureRepository persists feature flags in the feature_flags table.
+type FeatureRepository struct {
+	db *sql.DB
+}
+
+// NewFeatureRepository creates a FeatureRepository backed by db.
+func NewFeatureRepository(db *sql.DB) *FeatureRepository {
+	return &FeatureRepository{db: db}
+}
+
+// Upsert stores a flag value, overwriting any previous row for the same
+// name and scope.
+func (r *FeatureRepository) Upsert(ctx context.Context, f *models.FeatureFlag) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO feature_flags (name, session_id, enabled, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name, session_id)
+		DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = EXCLUDED.updated_at`,
+		f.Name, f.SessionID, f.Enabled, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("upsert feature flag: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a flag row; deleting a session override re-exposes the
+// global value.
+func (r *FeatureRepository) Delete(ctx context.Context, name, sessionID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM feature_flags WHERE name = $1 AND session_id = $2`,
+		name, sessionID)
+	if err != nil {
+		return fmt.Errorf("delete feature flag: %w", err)
+	}
+	return nil
+}
+
+// List returns every stored flag, global rows first.
+func (r *FeatureRepository) List(ctx context.Context) ([]*models.FeatureFlag, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT name, session_id, enabled, updated_at
+		FROM feature_flags ORDER BY session_id, name`)
+	if err != nil {
+		return nil, fmt.Errorf("list feature flags: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []*models.FeatureFlag
+	for rows.Next() {
+		var f models.FeatureFlag
+		if err := rows.Scan(&f.Name, &f.SessionID, &f.Enabled, &f.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan feature flag: %w", err)
+		}
+		flags = append(flags, &f)
+	}
+	return flags, rows.Err()
+}
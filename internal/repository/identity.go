@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// IdentityRepository persists phone-JID/LID mappings in the identities
+// table.
+type IdentityRepository struct {
+	db *sql.DB
+}
+
+// NewIdentityRepository creates an IdentityRepository backed by db.
+func NewIdentityRepository(db *sql.DB) *IdentityRepository {
+	return &IdentityRepository{db: db}
+}
+
+// Upsert stores a mapping, replacing the phone JID recorded for the LID.
+func (r *IdentityRepository) Upsert(ctx context.Context, id *models.Identity) error {
+	id.UpdatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO identities (session_id, phone_jid, lid_jid, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (session_id, lid_jid)
+		DO UPDATE SET phone_jid = EXCLUDED.phone_jid, updated_at = EXCLUDED.updated_at`,
+		id.SessionID, id.PhoneJID, id.LIDJID, id.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert identity: %w", err)
+	}
+	return nil
+}
+
+// Resolve returns the identity containing the given JID on either side.
+func (r *IdentityRepository) Resolve(ctx context.Context, sessionID, jid string) (*models.Identity, error) {
+	var id models.Identity
+	err := r.db.QueryRowContext(ctx, `
+		SELECT session_id, phone_jid, lid_jid, updated_at
+		FROM identities
+		WHERE session_id = $1 AND (phone_jid = $2 OR lid_jid = $2)`,
+		sessionID, jid).Scan(&id.SessionID, &id.PhoneJID, &id.LIDJID, &id.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// Delete removes the mapping containing the given JID on either side.
+func (r *IdentityRepository) Delete(ctx context.Context, sessionID, jid string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM identities
+		WHERE session_id = $1 AND (phone_jid = $2 OR lid_jid = $2)`,
+		sessionID, jid)
+	if err != nil {
+		return fmt.Errorf("delete identity: %w", err)
+	}
+	return nil
+}
+
+// List returns all mappings of a session.
+func (r *IdentityRepository) List(ctx context.Context, sessionID string) ([]*models.Identity, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT session_id, phone_jid, lid_jid, updated_at
+		FROM identities WHERE session_id = $1 ORDER BY phone_jid`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("list identities: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.Identity
+	for rows.Next() {
+		var id models.Identity
+		if err := rows.Scan(&id.SessionID, &id.PhoneJID, &id.LIDJID, &id.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan identity: %w", err)
+		}
+		out = append(out, &id)
+	}
+	return out, rows.Err()
+}
+
+// CanonicalMap resolves a batch of JIDs to their canonical (phone) form.
+// JIDs without a mapping are absent from the result.
+func (r *IdentityRepository) CanonicalMap(ctx context.Context, sessionID string, jids []string) (map[string]string, error) {
+	if len(jids) == 0 {
+		return nil, nil
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT phone_jid, lid_jid FROM identities
+		WHERE session_id = $1 AND (phone_jid = ANY($2) OR lid_jid = ANY($2))`,
+		sessionID, pq.StringArray(jids))
+	if err != nil {
+		return nil, fmt.Errorf("canonical map: %w", err)
+	}
+	defer rows.Close()
+
+	out := make(map[string]string)
+	for rows.Next() {
+		var phone, lid string
+		if err := rows.Scan(&phone, &lid); err != nil {
+			return nil, fmt.Errorf("scan canonical map: %w", err)
+		}
+		out[phone] = phone
+		out[lid] = phone
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// LegalHoldRepository persists legal holds in the legal_holds table.
+type LegalHoldRepository struct {
+	db *sql.DB
+}
+
+// NewLegalHoldRepository creates a LegalHoldRepository backed by db.
+func NewLegalHoldRepository(db *sql.DB) *LegalHoldRepository {
+	return &LegalHoldRepository{db: db}
+}
+
+// Add places a hold; re-adding an existing hold updates its reason.
+func (r *LegalHoldRepository) Add(ctx context.Context, h *models.LegalHold) error {
+	h.CreatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO legal_holds (session_id, chat_jid, reason, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (session_id, chat_jid)
+		DO UPDATE SET reason = EXCLUDED.reason`,
+		h.SessionID, h.ChatJID, h.Reason, h.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("add legal hold: %w", err)
+	}
+	return nil
+}
+
+// Remove releases a hold.
+func (r *LegalHoldRepository) Remove(ctx context.Context, sessionID, chatJID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM legal_holds WHERE session_id = $1 AND chat_jid = $2`,
+		sessionID, chatJID)
+	if err != nil {
+		return fmt.Errorf("remove legal hold: %w", err)
+	}
+	return nil
+}
+
+// ListBySession returns all holds for a session, the session-wide hold
+// (empty chat_jid) first.
+func (r *LegalHoldRepository) ListBySession(ctx context.Context, sessionID string) ([]*models.LegalHold, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT session_id, chat_jid, reason, created_at
+		FROM legal_holds WHERE session_id = $1 ORDER BY chat_jid`,
+		sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("list legal holds: %w", err)
+	}
+	defer rows.Close()
+
+	var holds []*models.LegalHold
+	for rows.Next() {
+		var h models.LegalHold
+		if err := rows.Scan(&h.SessionID, &h.ChatJID, &h.Reason, &h.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan legal hold: %w", err)
+		}
+		holds = append(holds, &h)
+	}
+	return holds, rows.Err()
+}
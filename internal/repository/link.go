@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// LinkRepository persists tracked short links in the links table.
+type LinkRepository struct {
+	db *sql.DB
+}
+
+// NewLinkRepository creates a LinkRepository backed by db.
+func NewLinkRepository(db *sql.DB) *LinkRepository {
+	return &LinkRepository{db: db}
+}
+
+const linkColumns = `code, session_id, campaign, target_url, message_id, clicks, created_at, last_click`
+
+func scanLink(row interface{ Scan(...any) error }) (*models.Link, error) {
+	var l models.Link
+	err := row.Scan(&l.Code, &l.SessionID, &l.Campaign, &l.TargetURL,
+		&l.MessageID, &l.Clicks, &l.CreatedAt, &l.LastClick)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// Create inserts a link row.
+func (r *LinkRepository) Create(ctx context.Context, l *models.Link) error {
+	l.CreatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO links (code, session_id, campaign, target_url, message_id, clicks, created_at)
+		VALUES ($1, $2, $3, $4, $5, 0, $6)`,
+		l.Code, l.SessionID, l.Campaign, l.TargetURL, l.MessageID, l.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert link: %w", err)
+	}
+	return nil
+}
+
+// RecordClick bumps the click counter and returns the updated link.
+func (r *LinkRepository) RecordClick(ctx context.Context, code string) (*models.Link, error) {
+	row := r.db.QueryRowContext(ctx, `
+		UPDATE links SET clicks = clicks + 1, last_click = $2
+		WHERE code = $1
+		RETURNING `+linkColumns, code, time.Now().UTC())
+	return scanLink(row)
+}
+
+// AttachMessage back-fills the message id on freshly minted links once
+// the send succeeded and the id is known.
+func (r *LinkRepository) AttachMessage(ctx context.Context, sessionID string, codes []string, messageID string) error {
+	if len(codes) == 0 {
+		return nil
+	}
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE links SET message_id = $3
+		WHERE session_id = $1 AND code = ANY($2)`,
+		sessionID, pq.StringArray(codes), messageID)
+	if err != nil {
+		return fmt.Errorf("attach message to links: %w", err)
+	}
+	return nil
+}
+
+// CampaignStats aggregates link and click counts per campaign.
+func (r *LinkRepository) CampaignStats(ctx context.Context, sessionID string) ([]*models.CampaignStats, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT campaign, COUNT(*), COALESCE(SUM(clicks), 0)
+		FROM links WHERE session_id = $1
+		GROUP BY campaign ORDER BY campaign`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("campaign stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []*models.CampaignStats
+	for rows.Next() {
+		var st models.CampaignStats
+		if err := rows.Scan(&st.Campaign, &st.Links, &st.Clicks); err != nil {
+			return nil, fmt.Errorf("scan campaign stats: %w", err)
+		}
+		stats = append(stats, &st)
+	}
+	return stats, rows.Err()
+}
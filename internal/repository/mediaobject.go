@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// MediaObjectRepository tracks deduplicated media blobs in the bucket by
+// content hash, with reference counts so shared objects survive the
+// cleanup of any single referrer.
+type MediaObjectRepository struct {
+	db *sql.DB
+}
+
+// NewMediaObjectRepository creates a MediaObjectRepository backed by db.
+func NewMediaObjectRepository(db *sql.DB) *MediaObjectRepository {
+	return &MediaObjectRepository{db: db}
+}
+
+// AddRef registers one more reference to the blob with the given hash.
+// It returns the stored path and whether the object is new (and so still
+// needs to be written to the bucket).
+func (r *MediaObjectRepository) AddRef(ctx context.Context, sha256, path, contentType string, size int64) (string, bool, error) {
+	var storedPath string
+	var inserted bool
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO media_objects (sha256, path, content_type, size_bytes, refs, created_at)
+		VALUES ($1, $2, $3, $4, 1, $5)
+		ON CONFLICT (sha256)
+		DO UPDATE SET refs = media_objects.refs + 1
+		RETURNING path, (xmax = 0) AS inserted`,
+		sha256, path, contentType, size, time.Now().UTC()).
+		Scan(&storedPath, &inserted)
+	if err != nil {
+		return "", false, fmt.Errorf("media addref: %w", err)
+	}
+	return storedPath, inserted, nil
+}
+
+// Release drops one reference and deletes the row once none remain,
+// returning the path and whether the blob itself should be removed from
+// the bucket. Unknown hashes are a no-op.
+func (r *MediaObjectRepository) Release(ctx context.Context, sha256 string) (string, bool, error) {
+	var path string
+	var refs int
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE media_objects
+		SET refs = refs - 1
+		WHERE sha256 = $1
+		RETURNING path, refs`, sha256).Scan(&path, &refs)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("media release: %w", err)
+	}
+	if refs > 0 {
+		return path, false, nil
+	}
+	if _, err := r.db.ExecContext(ctx, `
+		DELETE FROM media_objects WHERE sha256 = $1 AND refs <= 0`, sha256); err != nil {
+		return path, false, fmt.Errorf("media release: delete: %w", err)
+	}
+	return path, true, nil
+}
@@ -0,0 +1,746 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// MessageRepository persists messages in the messages table.
+type MessageRepository struct {
+	db *sql.DB
+}
+
+// NewMessageRepository creates a MessageRepository backed by db.
+func NewMessageRepository(db *sql.DB) *MessageRepository {
+	return &MessageRepository{db: db}
+}
+
+const messageColumns = `id, session_id, message_id, chat_jid, sender_jid, from_me,
+	type, body, media_path, media_mime, media_sha256, quoted_id, thread_id, tags, raw,
+	quarantined, quarantine_reason, scan_verdict, extracted_text, transcript, language, mentions, starred, retries, read_at, timestamp, created_at`
+
+func scanMessage(row interface{ Scan(...any) error }) (*models.Message, error) {
+	var m models.Message
+	var tags, mentions pq.StringArray
+	var retries []byte
+	err := row.Scan(&m.ID, &m.SessionID, &m.MessageID, &m.ChatJID, &m.SenderJID,
+		&m.FromMe, &m.Type, &m.Body, &m.MediaPath, &m.MediaMime, &m.MediaSHA256,
+		&m.QuotedID, &m.ThreadID, &tags, &m.Raw, &m.Quarantined, &m.QuarantineReason,
+		&m.ScanVerdict, &m.ExtractedText, &m.Transcript, &m.Language, &mentions,
+		&m.Starred, &retries, &m.ReadAt, &m.Timestamp, &m.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	m.Tags = tags
+	m.Mentions = mentions
+	if len(retries) > 0 {
+		if err := json.Unmarshal(retries, &m.Retries); err != nil {
+			return nil, fmt.Errorf("decode retries: %w", err)
+		}
+	}
+	return &m, nil
+}
+
+// marshalRetries serializes the per-recipient retry counts; nil maps
+// stay as an empty object.
+func marshalRetries(m *models.Message) []byte {
+	if m.Retries == nil {
+		return []byte(`{}`)
+	}
+	raw, _ := json.Marshal(m.Retries)
+	return raw
+}
+
+// Create inserts a message row.
+func (r *MessageRepository) Create(ctx context.Context, m *models.Message) error {
+	m.CreatedAt = time.Now().UTC()
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO messages (session_id, message_id, chat_jid, sender_jid, from_me,
+			type, body, media_path, media_mime, media_sha256, quoted_id, thread_id, tags, raw,
+			quarantined, quarantine_reason, scan_verdict, extracted_text, transcript, language, mentions, starred, retries, timestamp, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25)
+		RETURNING id`,
+		m.SessionID, m.MessageID, m.ChatJID, m.SenderJID, m.FromMe,
+		m.Type, m.Body, m.MediaPath, m.MediaMime, m.MediaSHA256, m.QuotedID, m.ThreadID,
+		pq.StringArray(m.Tags), m.Raw, m.Quarantined, m.QuarantineReason,
+		m.ScanVerdict, m.ExtractedText, m.Transcript, m.Language, pq.StringArray(m.Mentions),
+		m.Starred, marshalRetries(m), m.Timestamp, m.CreatedAt).Scan(&m.ID)
+	if err != nil {
+		return fmt.Errorf("insert message: %w", err)
+	}
+	return nil
+}
+
+// CreateBatch inserts many messages in one round trip using COPY.
+// Intended for the ingestion flusher; ids are not populated on the way
+// out. Conflicting (session_id, message_id) rows abort the batch, so the
+// flusher falls back to row-by-row inserts on error.
+func (r *MessageRepository) CreateBatch(ctx context.Context, msgs []*models.Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("batch insert: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("messages",
+		"session_id", "message_id", "chat_jid", "sender_jid", "from_me",
+		"type", "body", "media_path", "media_mime", "media_sha256", "quoted_id",
+		"thread_id", "tags", "raw", "quarantined", "quarantine_reason",
+		"scan_verdict", "extracted_text", "transcript", "language", "mentions",
+		"starred", "retries", "timestamp", "created_at"))
+	if err != nil {
+		return fmt.Errorf("batch insert: prepare: %w", err)
+	}
+
+	now := time.Now().UTC()
+	for _, m := range msgs {
+		m.CreatedAt = now
+		if _, err := stmt.ExecContext(ctx, m.SessionID, m.MessageID, m.ChatJID,
+			m.SenderJID, m.FromMe, m.Type, m.Body, m.MediaPath, m.MediaMime,
+			m.MediaSHA256, m.QuotedID, m.ThreadID, pq.StringArray(m.Tags), m.Raw,
+			m.Quarantined, m.QuarantineReason, m.ScanVerdict, m.ExtractedText,
+			m.Transcript, m.Language, pq.StringArray(m.Mentions), m.Starred,
+			marshalRetries(m), m.Timestamp, m.CreatedAt); err != nil {
+			stmt.Close()
+			return fmt.Errorf("batch insert: exec: %w", err)
+		}
+	}
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		return fmt.Errorf("batch insert: flush: %w", err)
+	}
+	if err := stmt.Close(); err != nil {
+		return fmt.Errorf("batch insert: close: %w", err)
+	}
+	return tx.Commit()
+}
+
+// PurgeContent blanks message content (body, raw protocol payload and
+// media references) older than before, leaving the metadata row intact.
+// Chats in heldChats are skipped. Returns the number of purged rows and
+// the media content hashes the purged rows referenced (one entry per
+// row), so the caller can drop those blob references.
+func (r *MessageRepository) PurgeContent(ctx context.Context, sessionID string, before time.Time, heldChats []string) (int64, []string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		WITH victims AS (
+			SELECT id, media_sha256 FROM messages
+			WHERE session_id = $1 AND timestamp < $2
+			  AND NOT (chat_jid = ANY($3))
+			  AND (body <> '' OR raw IS NOT NULL OR media_path <> '')
+			FOR UPDATE
+		)
+		UPDATE messages m
+		SET body = '', raw = NULL, media_path = '', media_mime = '',
+		    media_sha256 = '', extracted_text = '', transcript = ''
+		FROM victims v
+		WHERE m.id = v.id
+		RETURNING v.media_sha256`,
+		sessionID, before, pq.StringArray(heldChats))
+	if err != nil {
+		return 0, nil, fmt.Errorf("purge message content: %w", err)
+	}
+	defer rows.Close()
+
+	var purged int64
+	var shas []string
+	for rows.Next() {
+		var sha string
+		if err := rows.Scan(&sha); err != nil {
+			return 0, nil, fmt.Errorf("scan purged hash: %w", err)
+		}
+		purged++
+		if sha != "" {
+			shas = append(shas, sha)
+		}
+	}
+	return purged, shas, rows.Err()
+}
+
+// ResolveThreadID returns the thread id a reply to messageID should
+// inherit: the quoted message's thread when known, else the quoted id
+// itself (the original may not have been stored).
+func (r *MessageRepository) ResolveThreadID(ctx context.Context, sessionID, messageID string) string {
+	var threadID string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(NULLIF(thread_id, ''), message_id)
+		FROM messages WHERE session_id = $1 AND message_id = $2`,
+		sessionID, messageID).Scan(&threadID)
+	if err != nil {
+		return messageID
+	}
+	return threadID
+}
+
+// ListThread returns a reply thread in chronological order, the root
+// message first.
+func (r *MessageRepository) ListThread(ctx context.Context, sessionID, rootID string, limit int) ([]*models.Message, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+messageColumns+` FROM messages
+		WHERE session_id = $1 AND (message_id = $2 OR thread_id = $2)
+		ORDER BY timestamp, id
+		LIMIT $3`, sessionID, rootID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list thread: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.Message
+	for rows.Next() {
+		m, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// MessageFilter narrows the filtered history listing. Zero values mean
+// "no constraint".
+type MessageFilter struct {
+	// ChatJID limits results to one chat.
+	ChatJID string
+	// Mention matches messages @-mentioning this JID.
+	Mention string
+	// Tag matches messages carrying this enrichment tag.
+	Tag string
+	// HasLink keeps only messages whose body contains a URL.
+	HasLink bool
+	// Starred keeps only starred messages.
+	Starred bool
+	// Failed keeps only messages with recorded delivery retries.
+	Failed bool
+	// Type limits results to one message type (text, image, ...).
+	Type string
+	// FromMe filters by direction: sent (true) or received (false); nil
+	// keeps both.
+	FromMe *bool
+	// Query matches bodies case-insensitively as a substring.
+	Query string
+	// SearchConfig is the Postgres text search configuration for Query.
+	// When set to anything but "simple", Query runs as a stemmed
+	// full-text search in that language instead of a substring match.
+	SearchConfig string
+	// Before/After bound the timestamp range; zero means unbounded.
+	Before time.Time
+	After  time.Time
+	// CursorTS/CursorID resume a previous page: only rows strictly older
+	// than this (timestamp, id) position are returned.
+	CursorTS time.Time
+	CursorID int64
+	Limit    int
+}
+
+// ListFiltered returns messages matching the filter, newest first.
+func (r *MessageRepository) ListFiltered(ctx context.Context, sessionID string, f MessageFilter) ([]*models.Message, error) {
+	query := `SELECT ` + messageColumns + ` FROM messages WHERE session_id = $1`
+	args := []any{sessionID}
+	if f.ChatJID != "" {
+		args = append(args, f.ChatJID)
+		query += fmt.Sprintf(" AND chat_jid = $%d", len(args))
+	}
+	if f.Mention != "" {
+		args = append(args, f.Mention)
+		query += fmt.Sprintf(" AND mentions @> ARRAY[$%d]::TEXT[]", len(args))
+	}
+	if f.Tag != "" {
+		args = append(args, f.Tag)
+		query += fmt.Sprintf(" AND tags @> ARRAY[$%d]::TEXT[]", len(args))
+	}
+	if f.HasLink {
+		query += ` AND body ~* 'https?://'`
+	}
+	if f.Starred {
+		query += ` AND starred`
+	}
+	if f.Failed {
+		query += ` AND retries <> '{}'::jsonb`
+	}
+	if f.Type != "" {
+		args = append(args, f.Type)
+		query += fmt.Sprintf(" AND type = $%d", len(args))
+	}
+	if f.FromMe != nil {
+		args = append(args, *f.FromMe)
+		query += fmt.Sprintf(" AND from_me = $%d", len(args))
+	}
+	if f.Query != "" {
+		if f.SearchConfig != "" && f.SearchConfig != "simple" {
+			args = append(args, f.SearchConfig, f.Query)
+			query += fmt.Sprintf(" AND to_tsvector($%d::regconfig, body) @@ plainto_tsquery($%d::regconfig, $%d)",
+				len(args)-1, len(args)-1, len(args))
+		} else {
+			args = append(args, "%"+f.Query+"%")
+			query += fmt.Sprintf(" AND body ILIKE $%d", len(args))
+		}
+	}
+	if !f.Before.IsZero() {
+		args = append(args, f.Before)
+		query += fmt.Sprintf(" AND timestamp < $%d", len(args))
+	}
+	if !f.After.IsZero() {
+		args = append(args, f.After)
+		query += fmt.Sprintf(" AND timestamp > $%d", len(args))
+	}
+	if !f.CursorTS.IsZero() {
+		args = append(args, f.CursorTS, f.CursorID)
+		query += fmt.Sprintf(" AND (timestamp, id) < ($%d, $%d)", len(args)-1, len(args))
+	}
+	args = append(args, f.Limit)
+	query += fmt.Sprintf(" ORDER BY timestamp DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list filtered: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.Message
+	for rows.Next() {
+		m, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// SetStarred updates the star state mirrored from WhatsApp app state.
+func (r *MessageRepository) SetStarred(ctx context.Context, sessionID, messageID string, starred bool) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE messages SET starred = $3 WHERE session_id = $1 AND message_id = $2`,
+		sessionID, messageID, starred)
+	if err != nil {
+		return fmt.Errorf("set starred: %w", err)
+	}
+	return nil
+}
+
+// ReactionSummary aggregates stored reaction rows for the given target
+// message ids into emoji counts, keyed by target id. Only each sender's
+// latest reaction counts, so removals (empty-body reactions) and changed
+// emojis are reflected.
+func (r *MessageRepository) ReactionSummary(ctx context.Context, sessionID string, messageIDs []string) (map[string]map[string]int, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT quoted_id, body, COUNT(*) FROM (
+			SELECT DISTINCT ON (quoted_id, sender_jid) quoted_id, body
+			FROM messages
+			WHERE session_id = $1 AND type = 'reaction' AND quoted_id = ANY($2)
+			ORDER BY quoted_id, sender_jid, timestamp DESC, id DESC
+		) latest
+		WHERE body <> ''
+		GROUP BY quoted_id, body`, sessionID, pq.StringArray(messageIDs))
+	if err != nil {
+		return nil, fmt.Errorf("reaction summary: %w", err)
+	}
+	defer rows.Close()
+
+	out := make(map[string]map[string]int)
+	for rows.Next() {
+		var target, emoji string
+		var count int
+		if err := rows.Scan(&target, &emoji, &count); err != nil {
+			return nil, fmt.Errorf("scan reaction summary: %w", err)
+		}
+		if out[target] == nil {
+			out[target] = make(map[string]int)
+		}
+		out[target][emoji] = count
+	}
+	return out, rows.Err()
+}
+
+// Inbox aggregates recent chats across the given sessions, newest
+// activity first. Unread counts inbound messages newer than the chat's
+// last outgoing message. unreadOnly drops fully-read chats.
+func (r *MessageRepository) Inbox(ctx context.Context, sessionIDs []string, unreadOnly bool, limit int) ([]*models.InboxChat, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		WITH chats AS (
+			SELECT session_id, chat_jid,
+			       MAX(timestamp) AS last_activity,
+			       MAX(timestamp) FILTER (WHERE from_me) AS last_outgoing
+			FROM messages
+			WHERE session_id = ANY($1)
+			GROUP BY session_id, chat_jid
+		)
+		SELECT c.session_id, c.chat_jid, c.last_activity,
+		       last.message_id, last.type, last.body, last.from_me,
+		       (SELECT COUNT(*) FROM messages u
+		        WHERE u.session_id = c.session_id AND u.chat_jid = c.chat_jid
+		          AND NOT u.from_me
+		          AND (c.last_outgoing IS NULL OR u.timestamp > c.last_outgoing)) AS unread
+		FROM chats c
+		JOIN LATERAL (
+			SELECT message_id, type, body, from_me FROM messages l
+			WHERE l.session_id = c.session_id AND l.chat_jid = c.chat_jid
+			ORDER BY l.timestamp DESC, l.id DESC LIMIT 1
+		) last ON true
+		WHERE NOT $2 OR (SELECT COUNT(*) FROM messages u
+		        WHERE u.session_id = c.session_id AND u.chat_jid = c.chat_jid
+		          AND NOT u.from_me
+		          AND (c.last_outgoing IS NULL OR u.timestamp > c.last_outgoing)) > 0
+		ORDER BY c.last_activity DESC
+		LIMIT $3`, pq.StringArray(sessionIDs), unreadOnly, limit)
+	if err != nil {
+		return nil, fmt.Errorf("inbox: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.InboxChat
+	for rows.Next() {
+		var ch models.InboxChat
+		if err := rows.Scan(&ch.SessionID, &ch.ChatJID, &ch.LastActivity,
+			&ch.LastMessageID, &ch.LastMessageType, &ch.LastMessageBody,
+			&ch.LastFromMe, &ch.Unread); err != nil {
+			return nil, fmt.Errorf("scan inbox chat: %w", err)
+		}
+		out = append(out, &ch)
+	}
+	return out, rows.Err()
+}
+
+// ListQuarantined returns quarantined messages for review, newest first.
+func (r *MessageRepository) ListQuarantined(ctx context.Context, sessionID string, limit int) ([]*models.Message, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+messageColumns+` FROM messages
+		WHERE session_id = $1 AND quarantined
+		ORDER BY timestamp DESC, id DESC
+		LIMIT $2`, sessionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list quarantined: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.Message
+	for rows.Next() {
+		m, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// ReleaseQuarantine clears the quarantine flag on one message and
+// returns the released row, or sql.ErrNoRows when no quarantined message
+// matched.
+func (r *MessageRepository) ReleaseQuarantine(ctx context.Context, sessionID, messageID string) (*models.Message, error) {
+	row := r.db.QueryRowContext(ctx, `
+		UPDATE messages
+		SET quarantined = FALSE, quarantine_reason = ''
+		WHERE session_id = $1 AND message_id = $2 AND quarantined
+		RETURNING `+messageColumns, sessionID, messageID)
+	return scanMessage(row)
+}
+
+// IncrementRetry bumps the retry-receipt count one recipient reported
+// for a message and returns the new count, or 0 when the message is not
+// stored.
+func (r *MessageRepository) IncrementRetry(ctx context.Context, sessionID, messageID, recipientJID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE messages
+		SET retries = jsonb_set(COALESCE(retries, '{}'), ARRAY[$3],
+			to_jsonb(COALESCE((retries->>$3)::int, 0) + 1))
+		WHERE session_id = $1 AND message_id = $2
+		RETURNING (retries->>$3)::int`,
+		sessionID, messageID, recipientJID).Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("increment retry: %w", err)
+	}
+	return count, nil
+}
+
+// MarkChatRead stamps every unread inbound message in a chat as read
+// and returns the affected rows (message id and sender only), so the
+// caller can emit the protocol read receipts.
+func (r *MessageRepository) MarkChatRead(ctx context.Context, sessionID, chatJID string) ([]*models.Message, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE messages
+		SET read_at = NOW()
+		WHERE session_id = $1 AND chat_jid = $2 AND NOT from_me AND read_at IS NULL
+		RETURNING message_id, sender_jid`, sessionID, chatJID)
+	if err != nil {
+		return nil, fmt.Errorf("mark chat read: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.Message
+	for rows.Next() {
+		var m models.Message
+		if err := rows.Scan(&m.MessageID, &m.SenderJID); err != nil {
+			return nil, fmt.Errorf("scan read mark: %w", err)
+		}
+		out = append(out, &m)
+	}
+	return out, rows.Err()
+}
+
+// MarkRevoked rewrites a stored message as revoked, blanking its
+// content the way an inbound revocation would.
+func (r *MessageRepository) MarkRevoked(ctx context.Context, sessionID, messageID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE messages
+		SET type = $3, body = '', media_path = '', media_mime = ''
+		WHERE session_id = $1 AND message_id = $2`,
+		sessionID, messageID, models.MessageTypeRevoke)
+	if err != nil {
+		return fmt.Errorf("mark revoked: %w", err)
+	}
+	return nil
+}
+
+// GetByMessageID returns a stored message by its WhatsApp message id.
+func (r *MessageRepository) GetByMessageID(ctx context.Context, sessionID, messageID string) (*models.Message, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+messageColumns+` FROM messages
+		WHERE session_id = $1 AND message_id = $2`, sessionID, messageID)
+	return scanMessage(row)
+}
+
+// OldestInChat returns the earliest stored message of a chat, used as
+// the anchor for on-demand history backfill.
+func (r *MessageRepository) OldestInChat(ctx context.Context, sessionID, chatJID string) (*models.Message, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+messageColumns+` FROM messages
+		WHERE session_id = $1 AND chat_jid = $2
+		ORDER BY timestamp ASC, id ASC LIMIT 1`, sessionID, chatJID)
+	return scanMessage(row)
+}
+
+// ListChatSince returns the messages of one chat newer than since, in
+// chronological order, capped at limit. A zero since means the whole
+// stored history.
+func (r *MessageRepository) ListChatSince(ctx context.Context, sessionID, chatJID string, since time.Time, limit int) ([]*models.Message, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+messageColumns+` FROM messages
+		WHERE session_id = $1 AND chat_jid = $2 AND timestamp > $3
+		ORDER BY timestamp ASC, id ASC
+		LIMIT $4`, sessionID, chatJID, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list chat since: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.Message
+	for rows.Next() {
+		m, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// FirstExchange returns the timestamps of the first inbound message in a
+// chat after since, and of the session's first reply after that inbound
+// message. Either pointer is nil when no such message exists.
+func (r *MessageRepository) FirstExchange(ctx context.Context, sessionID, chatJID string, since time.Time) (firstInbound, firstReply *time.Time, err error) {
+	var inbound sql.NullTime
+	err = r.db.QueryRowContext(ctx, `
+		SELECT MIN(timestamp) FROM messages
+		WHERE session_id = $1 AND chat_jid = $2 AND NOT from_me AND timestamp > $3`,
+		sessionID, chatJID, since).Scan(&inbound)
+	if err != nil {
+		return nil, nil, fmt.Errorf("first inbound: %w", err)
+	}
+	if !inbound.Valid {
+		return nil, nil, nil
+	}
+	var reply sql.NullTime
+	err = r.db.QueryRowContext(ctx, `
+		SELECT MIN(timestamp) FROM messages
+		WHERE session_id = $1 AND chat_jid = $2 AND from_me AND timestamp > $3`,
+		sessionID, chatJID, inbound.Time).Scan(&reply)
+	if err != nil {
+		return nil, nil, fmt.Errorf("first reply: %w", err)
+	}
+	if !reply.Valid {
+		return &inbound.Time, nil, nil
+	}
+	return &inbound.Time, &reply.Time, nil
+}
+
+// SenderCount is one row of a chat's top-sender ranking.
+type SenderCount struct {
+	SenderJID string `json:"sender_jid"`
+	Count     int64  `json:"count"`
+}
+
+// ChatSummary aggregates one chat's stored history. AvgResponseSeconds
+// is the mean gap between an inbound message and the session's next
+// reply; nil when the chat has no such pair.
+type ChatSummary struct {
+	TotalMessages      int64            `json:"total_messages"`
+	Sent               int64            `json:"sent"`
+	Received           int64            `json:"received"`
+	MediaMessages      int64            `json:"media_messages"`
+	ByType             map[string]int64 `json:"by_type"`
+	FirstMessageAt     *time.Time       `json:"first_message_at,omitempty"`
+	LastMessageAt      *time.Time       `json:"last_message_at,omitempty"`
+	TopSenders         []SenderCount    `json:"top_senders"`
+	AvgResponseSeconds *float64         `json:"avg_response_seconds,omitempty"`
+}
+
+// ChatSummary computes message, media and sender aggregates for one chat
+// directly in SQL, so summaries stay cheap on long histories.
+func (r *MessageRepository) ChatSummary(ctx context.Context, sessionID, chatJID string) (*ChatSummary, error) {
+	sum := &ChatSummary{ByType: map[string]int64{}}
+	var first, last sql.NullTime
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE from_me),
+		       COUNT(*) FILTER (WHERE type IN ('image', 'video', 'audio', 'document', 'sticker')),
+		       MIN(timestamp), MAX(timestamp)
+		FROM messages WHERE session_id = $1 AND chat_jid = $2`,
+		sessionID, chatJID).Scan(&sum.TotalMessages, &sum.Sent, &sum.MediaMessages, &first, &last)
+	if err != nil {
+		return nil, fmt.Errorf("chat summary totals: %w", err)
+	}
+	sum.Received = sum.TotalMessages - sum.Sent
+	if first.Valid {
+		sum.FirstMessageAt = &first.Time
+	}
+	if last.Valid {
+		sum.LastMessageAt = &last.Time
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT type, COUNT(*) FROM messages
+		WHERE session_id = $1 AND chat_jid = $2
+		GROUP BY type`, sessionID, chatJID)
+	if err != nil {
+		return nil, fmt.Errorf("chat summary types: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var typ string
+		var count int64
+		if err := rows.Scan(&typ, &count); err != nil {
+			return nil, fmt.Errorf("scan type count: %w", err)
+		}
+		sum.ByType[typ] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	senderRows, err := r.db.QueryContext(ctx, `
+		SELECT sender_jid, COUNT(*) FROM messages
+		WHERE session_id = $1 AND chat_jid = $2 AND NOT from_me
+		GROUP BY sender_jid
+		ORDER BY COUNT(*) DESC, sender_jid
+		LIMIT 5`, sessionID, chatJID)
+	if err != nil {
+		return nil, fmt.Errorf("chat summary senders: %w", err)
+	}
+	defer senderRows.Close()
+	for senderRows.Next() {
+		var sc SenderCount
+		if err := senderRows.Scan(&sc.SenderJID, &sc.Count); err != nil {
+			return nil, fmt.Errorf("scan sender count: %w", err)
+		}
+		sum.TopSenders = append(sum.TopSenders, sc)
+	}
+	if err := senderRows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Response time: for each outbound message directly following an
+	// inbound one, take the gap, then average.
+	var avg sql.NullFloat64
+	err = r.db.QueryRowContext(ctx, `
+		SELECT EXTRACT(EPOCH FROM AVG(gap)) FROM (
+			SELECT timestamp - lag(timestamp) OVER w AS gap,
+			       from_me, lag(from_me) OVER w AS prev_from_me
+			FROM messages
+			WHERE session_id = $1 AND chat_jid = $2
+			WINDOW w AS (ORDER BY timestamp, id)
+		) pairs
+		WHERE from_me AND NOT prev_from_me`, sessionID, chatJID).Scan(&avg)
+	if err != nil {
+		return nil, fmt.Errorf("chat summary response time: %w", err)
+	}
+	if avg.Valid {
+		sum.AvgResponseSeconds = &avg.Float64
+	}
+	return sum, nil
+}
+
+// CreateIfAbsent inserts a message row unless (session_id, message_id)
+// already exists; backfilled history overlaps stored traffic by design.
+// Reports whether a row was inserted.
+func (r *MessageRepository) CreateIfAbsent(ctx context.Context, m *models.Message) (bool, error) {
+	m.CreatedAt = time.Now().UTC()
+	res, err := r.db.ExecContext(ctx, `
+		INSERT INTO messages (session_id, message_id, chat_jid, sender_jid, from_me,
+			type, body, media_path, media_mime, media_sha256, quoted_id, thread_id, tags, raw,
+			quarantined, quarantine_reason, scan_verdict, extracted_text, transcript, language, mentions, starred, retries, timestamp, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25)
+		ON CONFLICT (session_id, message_id) DO NOTHING`,
+		m.SessionID, m.MessageID, m.ChatJID, m.SenderJID, m.FromMe,
+		m.Type, m.Body, m.MediaPath, m.MediaMime, m.MediaSHA256, m.QuotedID, m.ThreadID,
+		pq.StringArray(m.Tags), m.Raw, m.Quarantined, m.QuarantineReason,
+		m.ScanVerdict, m.ExtractedText, m.Transcript, m.Language, pq.StringArray(m.Mentions),
+		m.Starred, marshalRetries(m), m.Timestamp, m.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("insert backfilled message: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// ExternalIDRef correlates a client-supplied external id with the
+// WhatsApp message id the send produced, unique per session.
+type ExternalIDRef struct {
+	SessionID  string    `json:"-" db:"session_id"`
+	ExternalID string    `json:"external_id" db:"external_id"`
+	MessageID  string    `json:"message_id" db:"message_id"`
+	ChatJID    string    `json:"chat_jid" db:"chat_jid"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// SaveExternalID records the external id mapping for a sent message.
+func (r *MessageRepository) SaveExternalID(ctx context.Context, ref *ExternalIDRef) error {
+	ref.CreatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO message_external_ids (session_id, external_id, message_id, chat_jid, created_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		ref.SessionID, ref.ExternalID, ref.MessageID, ref.ChatJID, ref.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("save external id: %w", err)
+	}
+	return nil
+}
+
+// GetExternalID resolves an external id to its mapping.
+func (r *MessageRepository) GetExternalID(ctx context.Context, sessionID, externalID string) (*ExternalIDRef, error) {
+	ref := &ExternalIDRef{SessionID: sessionID, ExternalID: externalID}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT message_id, chat_jid, created_at FROM message_external_ids
+		WHERE session_id = $1 AND external_id = $2`,
+		sessionID, externalID).Scan(&ref.MessageID, &ref.ChatJID, &ref.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// ModerationRepository persists the group moderation log in the
+// moderation_actions table.
+type ModerationRepository struct {
+	db *sql.DB
+}
+
+// NewModerationRepository creates a ModerationRepository backed by db.
+func NewModerationRepository(db *sql.DB) *ModerationRepository {
+	return &ModerationRepository{db: db}
+}
+
+// Log records one moderation action.
+func (r *ModerationRepository) Log(ctx context.Context, a *models.ModerationAction) error {
+	a.CreatedAt = time.Now().UTC()
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO moderation_actions (session_id, group_jid, target_jid, message_id, action, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`,
+		a.SessionID, a.GroupJID, a.TargetJID, a.MessageID, a.Action,
+		a.Reason, a.CreatedAt).Scan(&a.ID)
+	if err != nil {
+		return fmt.Errorf("insert moderation action: %w", err)
+	}
+	return nil
+}
+
+// CountOffenses returns how many delete actions have been recorded for a
+// participant in one group, i.e. the offense count thresholds compare
+// against.
+func (r *ModerationRepository) CountOffenses(ctx context.Context, sessionID, groupJID, targetJID string) (int, error) {
+	var n int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM moderation_actions
+		WHERE session_id = $1 AND group_jid = $2 AND target_jid = $3 AND action = $4`,
+		sessionID, groupJID, targetJID, models.ModerationActionDelete).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("count offenses: %w", err)
+	}
+	return n, nil
+}
+
+// List returns moderation log entries, newest first, optionally filtered
+// to one group.
+func (r *ModerationRepository) List(ctx context.Context, sessionID, groupJID string, limit int) ([]*models.ModerationAction, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, session_id, group_jid, target_jid, message_id, action, reason, created_at
+		FROM moderation_actions
+		WHERE session_id = $1 AND ($2 = '' OR group_jid = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3`, sessionID, groupJID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list moderation actions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.ModerationAction
+	for rows.Next() {
+		var a models.ModerationAction
+		if err := rows.Scan(&a.ID, &a.SessionID, &a.GroupJID, &a.TargetJID,
+			&a.MessageID, &a.Action, &a.Reason, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan moderation action: %w", err)
+		}
+		out = append(out, &a)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// ReceiptRepository persists delivery/read receipts in the receipts
+// table. Receipts are stored at full fidelity regardless of the webhook
+// sampling rate so the delivery timeline stays accurate.
+type ReceiptRepository struct {
+	db *sql.DB
+}
+
+// NewReceiptRepository creates a ReceiptRepository backed by db.
+func NewReceiptRepository(db *sql.DB) *ReceiptRepository {
+	return &ReceiptRepository{db: db}
+}
+
+// Record stores one receipt covering the given message ids.
+func (r *ReceiptRepository) Record(ctx context.Context, rc *models.Receipt, messageIDs []string) error {
+	for _, id := range messageIDs {
+		_, err := r.db.ExecContext(ctx, `
+			INSERT INTO receipts (session_id, message_id, chat_jid, sender_jid, type, timestamp)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (session_id, message_id, sender_jid, type) DO NOTHING`,
+			rc.SessionID, id, rc.ChatJID, rc.SenderJID, rc.Type, rc.Timestamp)
+		if err != nil {
+			return fmt.Errorf("insert receipt: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListByMessageID returns all receipts recorded for a message in
+// chronological order.
+func (r *ReceiptRepository) ListByMessageID(ctx context.Context, sessionID, messageID string) ([]*models.Receipt, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT session_id, message_id, chat_jid, sender_jid, type, timestamp
+		FROM receipts
+		WHERE session_id = $1 AND message_id = $2
+		ORDER BY timestamp`, sessionID, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("list receipts: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.Receipt
+	for rows.Next() {
+		var rc models.Receipt
+		if err := rows.Scan(&rc.SessionID, &rc.MessageID, &rc.ChatJID,
+			&rc.SenderJID, &rc.Type, &rc.Timestamp); err != nil {
+			return nil, err
+		}
+		out = append(out, &rc)
+	}
+	return out, rows.Err()
+}
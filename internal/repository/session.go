@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// ErrNotFound is returned when a lookup matches no rows.
+var ErrNotFound = sql.ErrNoRows
+
+// ErrNameTaken is returned when a session insert or rename collides with
+// another session's name.
+var ErrNameTaken = errors.New("session name already in use")
+
+// isNameConflict reports whether err is a unique violation on the
+// session name index.
+func isNameConflict(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" &&
+		pqErr.Constraint == "idx_sessions_name"
+}
+
+// SessionRepository persists sessions in the sessions table.
+type SessionRepository struct {
+	db *sql.DB
+}
+
+// NewSessionRepository creates a SessionRepository backed by db.
+func NewSessionRepository(db *sql.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+const sessionColumns = `id, name, device_jid, status, api_key, webhook_url, events, proxy_url, egress_proxy_url, receipt_sample_rate, presence_min_interval, locale, timezone, retention_days, dedupe_window_seconds, business_hours, inbound_filters, group_moderation, media_policy, quota, api_version, presence_heartbeat_seconds,
+	inline_media_max_bytes, sandbox, paired_at, metadata, created_at, updated_at`
+
+func scanSession(row interface{ Scan(...any) error }) (*models.Session, error) {
+	var s models.Session
+	var events pq.StringArray
+	var businessHours, inboundFilters, groupModeration, mediaPolicy, quota, metadata []byte
+	err := row.Scan(&s.ID, &s.Name, &s.DeviceJID, &s.Status, &s.APIKey,
+		&s.WebhookURL, &events, &s.ProxyURL, &s.EgressProxyURL,
+		&s.ReceiptSampleRate, &s.PresenceMinInterval, &s.Locale, &s.Timezone,
+		&s.RetentionDays, &s.DedupeWindowSeconds, &businessHours,
+		&inboundFilters, &groupModeration, &mediaPolicy, &quota, &s.APIVersion,
+		&s.PresenceHeartbeatSeconds, &s.InlineMediaMaxBytes, &s.Sandbox,
+		&s.PairedAt, &metadata, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	s.Events = events
+	if len(businessHours) > 0 {
+		if err := json.Unmarshal(businessHours, &s.BusinessHours); err != nil {
+			return nil, fmt.Errorf("decode business hours: %w", err)
+		}
+	}
+	if len(inboundFilters) > 0 {
+		if err := json.Unmarshal(inboundFilters, &s.InboundFilters); err != nil {
+			return nil, fmt.Errorf("decode inbound filters: %w", err)
+		}
+	}
+	if len(groupModeration) > 0 {
+		if err := json.Unmarshal(groupModeration, &s.GroupModeration); err != nil {
+			return nil, fmt.Errorf("decode group moderation: %w", err)
+		}
+	}
+	if len(mediaPolicy) > 0 {
+		if err := json.Unmarshal(mediaPolicy, &s.MediaPolicy); err != nil {
+			return nil, fmt.Errorf("decode media policy: %w", err)
+		}
+	}
+	if len(quota) > 0 {
+		if err := json.Unmarshal(quota, &s.Quota); err != nil {
+			return nil, fmt.Errorf("decode quota: %w", err)
+		}
+	}
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &s.Metadata); err != nil {
+			return nil, fmt.Errorf("decode metadata: %w", err)
+		}
+	}
+	return &s, nil
+}
+
+// marshalBusinessHours serializes the optional business hours config for
+// its JSONB column; nil stays NULL.
+func marshalBusinessHours(s *models.Session) any {
+	if s.BusinessHours == nil {
+		return nil
+	}
+	raw, _ := json.Marshal(s.BusinessHours)
+	return raw
+}
+
+// marshalInboundFilters serializes the optional inbound filter config
+// for its JSONB column; nil stays NULL.
+func marshalInboundFilters(s *models.Session) any {
+	if s.InboundFilters == nil {
+		return nil
+	}
+	raw, _ := json.Marshal(s.InboundFilters)
+	return raw
+}
+
+// marshalGroupModeration serializes the optional group moderation
+// config for its JSONB column; nil stays NULL.
+func marshalGroupModeration(s *models.Session) any {
+	if s.GroupModeration == nil {
+		return nil
+	}
+	raw, _ := json.Marshal(s.GroupModeration)
+	return raw
+}
+
+// marshalMediaPolicy serializes the optional media policy config for
+// its JSONB column; nil stays NULL.
+func marshalMediaPolicy(s *models.Session) any {
+	if s.MediaPolicy == nil {
+		return nil
+	}
+	raw, _ := json.Marshal(s.MediaPolicy)
+	return raw
+}
+
+// marshalQuota serializes the optional quota config for its JSONB
+// column; nil stays NULL.
+func marshalQuota(s *models.Session) any {
+	if s.Quota == nil {
+		return nil
+	}
+	raw, _ := json.Marshal(s.Quota)
+	return raw
+}
+
+// marshalMetadata serializes the session metadata document; nil maps
+// stay as an empty object.
+func marshalMetadata(s *models.Session) []byte {
+	if s.Metadata == nil {
+		return []byte(`{}`)
+	}
+	raw, _ := json.Marshal(s.Metadata)
+	return raw
+}
+
+// Create inserts a new session.
+func (r *SessionRepository) Create(ctx context.Context, s *models.Session) error {
+	now := time.Now().UTC()
+	s.CreatedAt, s.UpdatedAt = now, now
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO sessions (`+sessionColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28)`,
+		s.ID, s.Name, s.DeviceJID, s.Status, s.APIKey, s.WebhookURL,
+		pq.StringArray(s.Events), s.ProxyURL, s.EgressProxyURL,
+		s.ReceiptSampleRate, s.PresenceMinInterval, s.Locale, s.Timezone,
+		s.RetentionDays, s.DedupeWindowSeconds, marshalBusinessHours(s),
+		marshalInboundFilters(s), marshalGroupModeration(s), marshalMediaPolicy(s),
+		marshalQuota(s), s.APIVersion, s.PresenceHeartbeatSeconds,
+		s.InlineMediaMaxBytes, s.Sandbox, s.PairedAt, marshalMetadata(s), s.CreatedAt, s.UpdatedAt)
+	if isNameConflict(err) {
+		return ErrNameTaken
+	}
+	if err != nil {
+		return fmt.Errorf("insert session: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns the session with the given id.
+func (r *SessionRepository) GetByID(ctx context.Context, id string) (*models.Session, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+sessionColumns+` FROM sessions WHERE id = $1`, id)
+	return scanSession(row)
+}
+
+// GetByName returns the session with the given unique name.
+func (r *SessionRepository) GetByName(ctx context.Context, name string) (*models.Session, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+sessionColumns+` FROM sessions WHERE name = $1`, name)
+	return scanSession(row)
+}
+
+// GetByAPIKey returns the session owning the given API key.
+func (r *SessionRepository) GetByAPIKey(ctx context.Context, key string) (*models.Session, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+sessionColumns+` FROM sessions WHERE api_key = $1`, key)
+	return scanSession(row)
+}
+
+// List returns all sessions ordered by creation time.
+func (r *SessionRepository) List(ctx context.Context) ([]*models.Session, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+sessionColumns+` FROM sessions ORDER BY created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.Session
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+// ListPage returns sessions created after the (createdAt, id) cursor
+// position, ordered by creation time, for cursor pagination. A zero
+// cursor starts from the beginning.
+func (r *SessionRepository) ListPage(ctx context.Context, afterCreatedAt time.Time, afterID string, limit int) ([]*models.Session, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+sessionColumns+` FROM sessions
+		WHERE (created_at, id) > ($1, $2)
+		ORDER BY created_at, id
+		LIMIT $3`, afterCreatedAt, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions page: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.Session
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+// Update persists mutable session fields.
+func (r *SessionRepository) Update(ctx context.Context, s *models.Session) error {
+	s.UpdatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE sessions
+		SET name = $2, device_jid = $3, status = $4, webhook_url = $5,
+		    events = $6, proxy_url = $7, egress_proxy_url = $8,
+		    receipt_sample_rate = $9, presence_min_interval = $10,
+		    locale = $11, timezone = $12, retention_days = $13,
+		    dedupe_window_seconds = $14, business_hours = $15,
+		    inbound_filters = $16, group_moderation = $17, media_policy = $18,
+		    quota = $19, api_version = $20, presence_heartbeat_seconds = $21,
+		    inline_media_max_bytes = $22, sandbox = $23, paired_at = $24,
+		    metadata = $25, updated_at = $26
+		WHERE id = $1`,
+		s.ID, s.Name, s.DeviceJID, s.Status, s.WebhookURL,
+		pq.StringArray(s.Events), s.ProxyURL, s.EgressProxyURL,
+		s.ReceiptSampleRate, s.PresenceMinInterval, s.Locale, s.Timezone,
+		s.RetentionDays, s.DedupeWindowSeconds, marshalBusinessHours(s),
+		marshalInboundFilters(s), marshalGroupModeration(s), marshalMediaPolicy(s),
+		marshalQuota(s), s.APIVersion, s.PresenceHeartbeatSeconds,
+		s.InlineMediaMaxBytes, s.Sandbox, s.PairedAt, marshalMetadata(s), s.UpdatedAt)
+	if isNameConflict(err) {
+		return ErrNameTaken
+	}
+	if err != nil {
+		return fmt.Errorf("update session: %w", err)
+	}
+	return nil
+}
+
+// UpdateStatus updates only the status column.
+func (r *SessionRepository) UpdateStatus(ctx context.Context, id, status string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE sessions SET status = $2, updated_at = $3 WHERE id = $1`,
+		id, status, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("update session status: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a session row.
+func (r *SessionRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM sessions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete session: %w", err)
+	}
+	return nil
+}
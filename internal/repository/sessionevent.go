@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// SessionEventRepository persists the session lifecycle timeline in the
+// session_events table.
+type SessionEventRepository struct {
+	db *sql.DB
+}
+
+// NewSessionEventRepository creates a SessionEventRepository backed by db.
+func NewSessionEventRepository(db *sql.DB) *SessionEventRepository {
+	return &SessionEventRepository{db: db}
+}
+
+// Record appends one lifecycle event.
+func (r *SessionEventRepository) Record(ctx context.Context, sessionID, kind, detail string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO session_events (session_id, kind, detail, created_at)
+		VALUES ($1, $2, $3, $4)`,
+		sessionID, kind, detail, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("record session event: %w", err)
+	}
+	return nil
+}
+
+// List returns lifecycle events newest first. beforeID limits the page
+// to events older than the given id (0 starts from the newest).
+func (r *SessionEventRepository) List(ctx context.Context, sessionID string, beforeID int64, limit int) ([]*models.SessionEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, session_id, kind, detail, created_at
+		FROM session_events
+		WHERE session_id = $1 AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3`, sessionID, beforeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list session events: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.SessionEvent
+	for rows.Next() {
+		var e models.SessionEvent
+		if err := rows.Scan(&e.ID, &e.SessionID, &e.Kind, &e.Detail, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan session event: %w", err)
+		}
+		out = append(out, &e)
+	}
+	return out, rows.Err()
+}
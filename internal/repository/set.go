@@ -0,0 +1,54 @@
+package repository
+
+import "database/sql"
+
+// Set bundles all repositories over the shared database pool, so
+// constructors that need several of them take one dependency.
+type Set struct {
+	Sessions  *SessionRepository
+	Messages  *MessageRepository
+	Events    *EventRepository
+	Contacts  *ContactRepository
+	Receipts  *ReceiptRepository
+	Features  *FeatureRepository
+	Holds     *LegalHoldRepository
+	Links     *LinkRepository
+	Templates *TemplateRepository
+	Convos    *ConversationRepository
+	Canned    *CannedRepository
+	ModLog    *ModerationRepository
+	Subs      *SubscriptionRepository
+	IDs       *IdentityRepository
+	Timeline  *SessionEventRepository
+	Usage     *UsageRepository
+	Media     *MediaObjectRepository
+	Defaults  *DefaultsRepository
+	Announces *AnnouncementRepository
+	SLA       *SLARepository
+}
+
+// NewSet builds every repository over db.
+func NewSet(db *sql.DB) *Set {
+	return &Set{
+		Sessions:  NewSessionRepository(db),
+		Messages:  NewMessageRepository(db),
+		Events:    NewEventRepository(db),
+		Contacts:  NewContactRepository(db),
+		Receipts:  NewReceiptRepository(db),
+		Features:  NewFeatureRepository(db),
+		Holds:     NewLegalHoldRepository(db),
+		Links:     NewLinkRepository(db),
+		Templates: NewTemplateRepository(db),
+		Convos:    NewConversationRepository(db),
+		Canned:    NewCannedRepository(db),
+		ModLog:    NewModerationRepository(db),
+		Subs:      NewSubscriptionRepository(db),
+		IDs:       NewIdentityRepository(db),
+		Timeline:  NewSessionEventRepository(db),
+		Usage:     NewUsageRepository(db),
+		Media:     NewMediaObjectRepository(db),
+		Defaults:  NewDefaultsRepository(db),
+		Announces: NewAnnouncementRepository(db),
+		SLA:       NewSLARepository(db),
+	}
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// SLARepository persists per-conversation service-level measurements in
+// the conversation_sla table.
+type SLARepository struct {
+	db *sql.DB
+}
+
+// NewSLARepository creates an SLARepository backed by db.
+func NewSLARepository(db *sql.DB) *SLARepository {
+	return &SLARepository{db: db}
+}
+
+// Record stores one resolved conversation's measurements.
+func (r *SLARepository) Record(ctx context.Context, s *models.ConversationSLA) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO conversation_sla (session_id, chat_jid, agent,
+			first_response_seconds, resolution_seconds, resolved_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`,
+		s.SessionID, s.ChatJID, s.Agent, s.FirstResponseSeconds,
+		s.ResolutionSeconds, s.ResolvedAt).Scan(&s.ID)
+	if err != nil {
+		return fmt.Errorf("record conversation sla: %w", err)
+	}
+	return nil
+}
+
+// SLAAggregate is one agent's rollup of recorded measurements. Responded
+// counts conversations that got a first reply before resolution; the
+// first-response averages cover only those.
+type SLAAggregate struct {
+	Agent                   string   `json:"agent"`
+	Conversations           int64    `json:"conversations"`
+	Responded               int64    `json:"responded"`
+	AvgFirstResponseSeconds *float64 `json:"avg_first_response_seconds,omitempty"`
+	MaxFirstResponseSeconds *float64 `json:"max_first_response_seconds,omitempty"`
+	AvgResolutionSeconds    float64  `json:"avg_resolution_seconds"`
+	MaxResolutionSeconds    float64  `json:"max_resolution_seconds"`
+}
+
+// Aggregates rolls measurements up per agent, optionally narrowed to one
+// agent and/or a minimum resolution time.
+func (r *SLARepository) Aggregates(ctx context.Context, sessionID, agent string, since time.Time) ([]*SLAAggregate, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT agent, COUNT(*),
+		       COUNT(first_response_seconds),
+		       AVG(first_response_seconds), MAX(first_response_seconds),
+		       AVG(resolution_seconds), MAX(resolution_seconds)
+		FROM conversation_sla
+		WHERE session_id = $1
+		  AND ($2 = '' OR agent = $2)
+		  AND resolved_at > $3
+		GROUP BY agent
+		ORDER BY agent`, sessionID, agent, since)
+	if err != nil {
+		return nil, fmt.Errorf("sla aggregates: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*SLAAggregate
+	for rows.Next() {
+		var a SLAAggregate
+		var avgFirst, maxFirst sql.NullFloat64
+		if err := rows.Scan(&a.Agent, &a.Conversations, &a.Responded,
+			&avgFirst, &maxFirst, &a.AvgResolutionSeconds, &a.MaxResolutionSeconds); err != nil {
+			return nil, fmt.Errorf("scan sla aggregate: %w", err)
+		}
+		if avgFirst.Valid {
+			a.AvgFirstResponseSeconds = &avgFirst.Float64
+		}
+		if maxFirst.Valid {
+			a.MaxFirstResponseSeconds = &maxFirst.Float64
+		}
+		out = append(out, &a)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// SubscriptionRepository persists topic subscriber lists in the
+// subscriptions table.
+type SubscriptionRepository struct {
+	db *sql.DB
+}
+
+// NewSubscriptionRepository creates a SubscriptionRepository backed by db.
+func NewSubscriptionRepository(db *sql.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+// Subscribe adds a contact to a topic; resubscribing is a no-op.
+func (r *SubscriptionRepository) Subscribe(ctx context.Context, sessionID, topic, jid string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO subscriptions (session_id, topic, jid, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (session_id, topic, jid) DO NOTHING`,
+		sessionID, topic, jid, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("subscribe: %w", err)
+	}
+	return nil
+}
+
+// Unsubscribe removes a contact from a topic. Returns whether a
+// subscription actually existed.
+func (r *SubscriptionRepository) Unsubscribe(ctx context.Context, sessionID, topic, jid string) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM subscriptions
+		WHERE session_id = $1 AND topic = $2 AND jid = $3`,
+		sessionID, topic, jid)
+	if err != nil {
+		return false, fmt.Errorf("unsubscribe: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// UnsubscribeAll removes a contact from every topic (the "stop" opt-out).
+// Returns how many subscriptions were dropped.
+func (r *SubscriptionRepository) UnsubscribeAll(ctx context.Context, sessionID, jid string) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM subscriptions WHERE session_id = $1 AND jid = $2`,
+		sessionID, jid)
+	if err != nil {
+		return 0, fmt.Errorf("unsubscribe all: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// Subscribers returns every subscriber JID of a topic.
+func (r *SubscriptionRepository) Subscribers(ctx context.Context, sessionID, topic string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT jid FROM subscriptions
+		WHERE session_id = $1 AND topic = $2
+		ORDER BY created_at`, sessionID, topic)
+	if err != nil {
+		return nil, fmt.Errorf("list subscribers: %w", err)
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var jid string
+		if err := rows.Scan(&jid); err != nil {
+			return nil, fmt.Errorf("scan subscriber: %w", err)
+		}
+		out = append(out, jid)
+	}
+	return out, rows.Err()
+}
+
+// Topics returns the session's topics with subscriber counts.
+func (r *SubscriptionRepository) Topics(ctx context.Context, sessionID string) ([]*models.TopicSummary, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT topic, COUNT(*) FROM subscriptions
+		WHERE session_id = $1
+		GROUP BY topic ORDER BY topic`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("list topics: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.TopicSummary
+	for rows.Next() {
+		var t models.TopicSummary
+		if err := rows.Scan(&t.Topic, &t.Subscribers); err != nil {
+			return nil, fmt.Errorf("scan topic: %w", err)
+		}
+		out = append(out, &t)
+	}
+	return out, rows.Err()
+}
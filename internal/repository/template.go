@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// TemplateRepository persists message templates in the templates table.
+type TemplateRepository struct {
+	db *sql.DB
+}
+
+// NewTemplateRepository creates a TemplateRepository backed by db.
+func NewTemplateRepository(db *sql.DB) *TemplateRepository {
+	return &TemplateRepository{db: db}
+}
+
+func scanTemplate(row interface{ Scan(...any) error }) (*models.Template, error) {
+	var t models.Template
+	var source []byte
+	err := row.Scan(&t.SessionID, &t.Name, &t.Body, &source, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if len(source) > 0 {
+		if err := json.Unmarshal(source, &t.Source); err != nil {
+			return nil, fmt.Errorf("decode template source: %w", err)
+		}
+	}
+	return &t, nil
+}
+
+func marshalSource(t *models.Template) any {
+	if t.Source == nil {
+		return nil
+	}
+	raw, _ := json.Marshal(t.Source)
+	return raw
+}
+
+// Upsert creates or replaces a template by (session, name).
+func (r *TemplateRepository) Upsert(ctx context.Context, t *models.Template) error {
+	now := time.Now().UTC()
+	t.UpdatedAt = now
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = now
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO templates (session_id, name, body, source, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (session_id, name)
+		DO UPDATE SET body = EXCLUDED.body, source = EXCLUDED.source,
+		              updated_at = EXCLUDED.updated_at`,
+		t.SessionID, t.Name, t.Body, marshalSource(t), t.CreatedAt, t.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert template: %w", err)
+	}
+	return nil
+}
+
+// GetByName returns one template.
+func (r *TemplateRepository) GetByName(ctx context.Context, sessionID, name string) (*models.Template, error) {
+	row := r.db.QueryRowContext(ctx, `
+		SELECT session_id, name, body, source, created_at, updated_at
+		FROM templates WHERE session_id = $1 AND name = $2`, sessionID, name)
+	return scanTemplate(row)
+}
+
+// List returns all templates for a session ordered by name.
+func (r *TemplateRepository) List(ctx context.Context, sessionID string) ([]*models.Template, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT session_id, name, body, source, created_at, updated_at
+		FROM templates WHERE session_id = $1 ORDER BY name`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("list templates: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.Template
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// Delete removes a template.
+func (r *TemplateRepository) Delete(ctx context.Context, sessionID, name string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM templates WHERE session_id = $1 AND name = $2`, sessionID, name)
+	if err != nil {
+		return fmt.Errorf("delete template: %w", err)
+	}
+	return nil
+}
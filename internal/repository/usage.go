@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// UsageRepository meters daily per-session consumption in the
+// usage_daily table.
+type UsageRepository struct {
+	db *sql.DB
+}
+
+// NewUsageRepository creates a UsageRepository backed by db.
+func NewUsageRepository(db *sql.DB) *UsageRepository {
+	return &UsageRepository{db: db}
+}
+
+// usageColumns whitelists the counter columns add may touch. The column
+// name has to be spliced into the statement (Postgres cannot bind
+// identifiers), so anything outside the whitelist is rejected before it
+// reaches the query text.
+var usageColumns = map[string]bool{
+	"messages_sent":      true,
+	"media_bytes":        true,
+	"webhook_deliveries": true,
+}
+
+// add upserts today's row and bumps one counter column.
+func (r *UsageRepository) add(ctx context.Context, sessionID, column string, delta int64) error {
+	if !usageColumns[column] {
+		return fmt.Errorf("meter: unknown usage column %q", column)
+	}
+	_, err := r.db.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO usage_daily (session_id, day, %s)
+		VALUES ($1, CURRENT_DATE, $2)
+		ON CONFLICT (session_id, day)
+		DO UPDATE SET %s = usage_daily.%s + EXCLUDED.%s`,
+		column, column, column, column),
+		sessionID, delta)
+	if err != nil {
+		return fmt.Errorf("meter %s: %w", column, err)
+	}
+	return nil
+}
+
+// AddMessages meters n sent messages against today.
+func (r *UsageRepository) AddMessages(ctx context.Context, sessionID string, n int64) error {
+	return r.add(ctx, sessionID, "messages_sent", n)
+}
+
+// AddMediaBytes meters n bytes of media against today.
+func (r *UsageRepository) AddMediaBytes(ctx context.Context, sessionID string, n int64) error {
+	return r.add(ctx, sessionID, "media_bytes", n)
+}
+
+// AddWebhookDeliveries meters n webhook deliveries against today.
+func (r *UsageRepository) AddWebhookDeliveries(ctx context.Context, sessionID string, n int64) error {
+	return r.add(ctx, sessionID, "webhook_deliveries", n)
+}
+
+// Today returns today's usage row; a zero-valued row when nothing was
+// metered yet.
+func (r *UsageRepository) Today(ctx context.Context, sessionID string) (*models.Usage, error) {
+	u := &models.Usage{SessionID: sessionID, Day: time.Now().UTC().Truncate(24 * time.Hour)}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT day, messages_sent, media_bytes, webhook_deliveries
+		FROM usage_daily
+		WHERE session_id = $1 AND day = CURRENT_DATE`, sessionID).
+		Scan(&u.Day, &u.MessagesSent, &u.MediaBytes, &u.WebhookDeliveries)
+	if err == sql.ErrNoRows {
+		return u, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("usage today: %w", err)
+	}
+	return u, nil
+}
+
+// List returns the session's daily usage rows for the last days days,
+// newest first.
+func (r *UsageRepository) List(ctx context.Context, sessionID string, days int) ([]*models.Usage, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT session_id, day, messages_sent, media_bytes, webhook_deliveries
+		FROM usage_daily
+		WHERE session_id = $1 AND day > CURRENT_DATE - $2::int
+		ORDER BY day DESC`, sessionID, days)
+	if err != nil {
+		return nil, fmt.Errorf("list usage: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.Usage
+	for rows.Next() {
+		var u models.Usage
+		if err := rows.Scan(&u.SessionID, &u.Day, &u.MessagesSent,
+			&u.MediaBytes, &u.WebhookDeliveries); err != nil {
+			return nil, fmt.Errorf("scan usage: %w", err)
+		}
+		out = append(out, &u)
+	}
+	return out, rows.Err()
+}
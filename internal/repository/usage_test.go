@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"testing"
+)
+
+// FuzzUsageColumn drives arbitrary column names through the meter's
+// whitelist check. Anything outside the whitelist must be rejected
+// before the column name can reach the query text.
+func FuzzUsageColumn(f *testing.F) {
+	f.Add("messages_sent")
+	f.Add("media_bytes; DROP TABLE usage_daily;--")
+	f.Add("messages_sent OR 1=1")
+	f.Add(`"webhook_deliveries"`)
+	f.Add("webhook_deliveries ")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, column string) {
+		if usageColumns[column] {
+			t.Skip("whitelisted columns proceed to the database")
+		}
+		r := NewUsageRepository(nil)
+		if err := r.add(context.Background(), "test-session", column, 1); err == nil {
+			t.Errorf("column %q passed the whitelist", column)
+		}
+	})
+}
+
+func TestUsageColumnWhitelist(t *testing.T) {
+	for _, col := range []string{"messages_sent", "media_bytes", "webhook_deliveries"} {
+		if !usageColumns[col] {
+			t.Errorf("metered column %q is not whitelisted", col)
+		}
+	}
+}
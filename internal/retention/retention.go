@@ -0,0 +1,141 @@
+// Package retention purges aged message content for sessions with a
+// retention policy, honouring legal holds and writing an audit event for
+// every purge run, as required in regulated deployments.
+package retention
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+	"github.com/fgsgalvaosz/zemeow/internal/media"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/repository"
+)
+
+// Runner sweeps all sessions on a fixed interval. Content is blanked in
+// place (metadata rows survive), so conversation statistics and exports
+// keep working after a purge. Archived media blobs are shared between
+// messages with identical content, so purging only deletes a blob once
+// its reference count drops to zero.
+type Runner struct {
+	repos    *repository.Set
+	storage  *media.Storage
+	interval time.Duration
+	log      zerolog.Logger
+}
+
+// NewRunner builds a Runner from the retention config.
+func NewRunner(repos *repository.Set, storage *media.Storage, cfg config.RetentionConfig, log zerolog.Logger) *Runner {
+	return &Runner{
+		repos:    repos,
+		storage:  storage,
+		interval: cfg.Interval,
+		log:      log.With().Str("component", "retention").Logger(),
+	}
+}
+
+// Run sweeps until ctx is cancelled. The first sweep happens one full
+// interval after startup so boot is not slowed down.
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.sweep(ctx)
+		}
+	}
+}
+
+func (r *Runner) sweep(ctx context.Context) {
+	sessions, err := r.repos.Sessions.List(ctx)
+	if err != nil {
+		r.log.Error().Err(err).Msg("list sessions")
+		return
+	}
+	for _, s := range sessions {
+		if s.RetentionDays <= 0 {
+			continue
+		}
+		if err := r.purgeSession(ctx, s); err != nil {
+			r.log.Error().Err(err).Str("session_id", s.ID).Msg("purge run failed")
+		}
+	}
+}
+
+func (r *Runner) purgeSession(ctx context.Context, s *models.Session) error {
+	holds, err := r.repos.Holds.ListBySession(ctx, s.ID)
+	if err != nil {
+		return err
+	}
+	heldChats, sessionWide := partitionHolds(holds)
+	if sessionWide {
+		// Session-wide hold: nothing may be purged.
+		return nil
+	}
+
+	before := time.Now().UTC().AddDate(0, 0, -s.RetentionDays)
+	purged, shas, err := r.repos.Messages.PurgeContent(ctx, s.ID, before, heldChats)
+	if err != nil {
+		return err
+	}
+	if purged == 0 {
+		return nil
+	}
+	r.releaseMedia(ctx, s.ID, shas)
+
+	r.log.Info().Str("session_id", s.ID).Int64("purged", purged).Msg("purged message content")
+	payload, _ := json.Marshal(map[string]any{
+		"purged":         purged,
+		"before":         before,
+		"retention_days": s.RetentionDays,
+		"held_chats":     len(heldChats),
+	})
+	return r.repos.Events.Create(ctx, &models.StoredEvent{
+		SessionID: s.ID,
+		Type:      models.EventRetentionPurge,
+		Payload:   payload,
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// partitionHolds splits a session's legal holds into the chats they
+// cover and reports whether any hold is session-wide (empty chat JID),
+// which blocks the purge entirely.
+func partitionHolds(holds []*models.LegalHold) (heldChats []string, sessionWide bool) {
+	heldChats = make([]string, 0, len(holds))
+	for _, h := range holds {
+		if h.ChatJID == "" {
+			return nil, true
+		}
+		heldChats = append(heldChats, h.ChatJID)
+	}
+	return heldChats, false
+}
+
+// releaseMedia drops one blob reference per purged media message and
+// deletes the stored object once nothing references it anymore. Release
+// failures are logged and skipped: a leaked blob is recoverable, a
+// deleted shared one is not.
+func (r *Runner) releaseMedia(ctx context.Context, sessionID string, shas []string) {
+	for _, sha := range shas {
+		path, deletable, err := r.repos.Media.Release(ctx, sha)
+		if err != nil {
+			r.log.Error().Err(err).Str("session_id", sessionID).Str("sha256", sha).
+				Msg("release media reference")
+			continue
+		}
+		if !deletable {
+			continue
+		}
+		if err := r.storage.Remove(ctx, path); err != nil {
+			r.log.Error().Err(err).Str("path", path).Msg("remove orphaned media blob")
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package retention
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+func TestPartitionHolds(t *testing.T) {
+	held, wide := partitionHolds(nil)
+	if wide || len(held) != 0 {
+		t.Errorf("no holds: held=%v wide=%v", held, wide)
+	}
+
+	held, wide = partitionHolds([]*models.LegalHold{
+		{ChatJID: "1@s.whatsapp.net"},
+		{ChatJID: "2@g.us"},
+	})
+	if wide {
+		t.Error("chat-scoped holds reported as session-wide")
+	}
+	if want := []string{"1@s.whatsapp.net", "2@g.us"}; !reflect.DeepEqual(held, want) {
+		t.Errorf("held chats = %v, want %v", held, want)
+	}
+
+	// One session-wide hold blocks the purge regardless of chat holds.
+	_, wide = partitionHolds([]*models.LegalHold{
+		{ChatJID: "1@s.whatsapp.net"},
+		{ChatJID: ""},
+	})
+	if !wide {
+		t.Error("session-wide hold not detected")
+	}
+}
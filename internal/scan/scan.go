@@ -0,0 +1,116 @@
+// Package scan integrates an external ClamAV daemon for malware
+// scanning of document media. The scanner is optional: a nil *Scanner
+// is valid and reports every blob as unscanned.
+package scan
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// Verdicts returned by Scan.
+const (
+	VerdictClean    = "clean"
+	VerdictInfected = "infected"
+)
+
+// Scanner streams blobs to a clamd instance over its INSTREAM protocol.
+type Scanner struct {
+	address string
+	timeout time.Duration
+	log     zerolog.Logger
+}
+
+// NewScanner builds a Scanner from config, or returns nil when no clamd
+// address is configured.
+func NewScanner(cfg config.ScanConfig, log zerolog.Logger) *Scanner {
+	if cfg.ClamdAddress == "" {
+		return nil
+	}
+	return &Scanner{
+		address: cfg.ClamdAddress,
+		timeout: cfg.Timeout,
+		log:     log.With().Str("component", "scan").Logger(),
+	}
+}
+
+// Enabled reports whether scanning is configured, nil-safe.
+func (s *Scanner) Enabled() bool { return s != nil }
+
+// instreamChunkSize keeps chunks under clamd's default StreamMaxLength
+// accounting granularity.
+const instreamChunkSize = 64 * 1024
+
+// Result is the outcome of one scan.
+type Result struct {
+	Verdict string `json:"verdict"`
+	// Signature names the matched malware signature when infected.
+	Signature string `json:"signature,omitempty"`
+}
+
+// Scan streams data to clamd and returns the verdict. A transport or
+// protocol failure returns an error; callers decide whether to fail
+// open or closed.
+func (s *Scanner) Scan(ctx context.Context, data []byte) (Result, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return Result{}, fmt.Errorf("scan: dial clamd: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else if s.timeout > 0 {
+		conn.SetDeadline(time.Now().Add(s.timeout))
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, fmt.Errorf("scan: send command: %w", err)
+	}
+	var size [4]byte
+	for off := 0; off < len(data); off += instreamChunkSize {
+		end := off + instreamChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		binary.BigEndian.PutUint32(size[:], uint32(end-off))
+		if _, err := conn.Write(size[:]); err != nil {
+			return Result{}, fmt.Errorf("scan: send chunk: %w", err)
+		}
+		if _, err := conn.Write(data[off:end]); err != nil {
+			return Result{}, fmt.Errorf("scan: send chunk: %w", err)
+		}
+	}
+	binary.BigEndian.PutUint32(size[:], 0)
+	if _, err := conn.Write(size[:]); err != nil {
+		return Result{}, fmt.Errorf("scan: finish stream: %w", err)
+	}
+
+	reply := make([]byte, 512)
+	n, err := conn.Read(reply)
+	if err != nil {
+		return Result{}, fmt.Errorf("scan: read verdict: %w", err)
+	}
+	return parseReply(strings.TrimRight(string(reply[:n]), "\x00\n")), nil
+}
+
+// parseReply maps a clamd reply line ("stream: OK" or
+// "stream: Name FOUND") onto a Result.
+func parseReply(line string) Result {
+	if strings.HasSuffix(line, " FOUND") {
+		sig := strings.TrimSuffix(line, " FOUND")
+		if _, rest, ok := strings.Cut(sig, ": "); ok {
+			sig = rest
+		}
+		return Result{Verdict: VerdictInfected, Signature: sig}
+	}
+	return Result{Verdict: VerdictClean}
+}
@@ -0,0 +1,53 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/appstate"
+)
+
+// appStateNames maps the API-facing resync names onto the app state
+// patch collections that hold them.
+var appStateNames = map[string][]appstate.WAPatchName{
+	"contacts":  {appstate.WAPatchCriticalUnblockLow},
+	"chats":     {appstate.WAPatchRegularLow, appstate.WAPatchRegularHigh},
+	"blocklist": {appstate.WAPatchCriticalBlock},
+	"labels":    {appstate.WAPatchRegular},
+}
+
+// ErrUnknownAppStateName is returned for resync names outside the
+// supported set.
+var ErrUnknownAppStateName = fmt.Errorf("unknown app state name")
+
+// ResyncAppState forces a full app state fetch for the named
+// collections (all of them when names is empty) and reports the outcome
+// per name. Used when local contact or chat settings drift from the
+// phone.
+func (m *Manager) ResyncAppState(ctx context.Context, c *Client, names []string) (map[string]string, error) {
+	if err := m.EnsureSendable(c); err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		names = []string{"contacts", "chats", "blocklist", "labels"}
+	}
+	for _, name := range names {
+		if _, ok := appStateNames[name]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownAppStateName, name)
+		}
+	}
+
+	results := make(map[string]string, len(names))
+	for _, name := range names {
+		results[name] = "ok"
+		for _, patch := range appStateNames[name] {
+			if err := c.WA.FetchAppState(patch, true, false); err != nil {
+				m.log.Error().Err(err).Str("session_id", c.Session.ID).
+					Str("name", name).Msg("app state resync")
+				results[name] = err.Error()
+				break
+			}
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,51 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// awayState throttles the auto-away reply per chat so a conversation gets
+// at most one reply per configured interval.
+type awayState struct {
+	mu        sync.Mutex
+	lastReply map[string]time.Time
+}
+
+// maybeAutoReply sends the configured away message for inbound direct
+// messages that arrive outside business hours. Group and own messages
+// never trigger it.
+func (m *Manager) maybeAutoReply(ctx context.Context, c *Client, e *events.Message) {
+	bh := c.Session.BusinessHours
+	if bh == nil || !bh.Enabled || e.Info.IsFromMe || e.Info.IsGroup {
+		return
+	}
+	if bh.IsOpen(e.Info.Timestamp, c.Session.Location()) {
+		return
+	}
+
+	chat := e.Info.Chat.ToNonAD().String()
+	c.away.mu.Lock()
+	if c.away.lastReply == nil {
+		c.away.lastReply = make(map[string]time.Time)
+	}
+	if last, ok := c.away.lastReply[chat]; ok && time.Since(last) < bh.ReplyInterval() {
+		c.away.mu.Unlock()
+		return
+	}
+	c.away.lastReply[chat] = time.Now()
+	c.away.mu.Unlock()
+
+	to, err := types.ParseJID(chat)
+	if err != nil {
+		return
+	}
+	if _, err := m.SendText(ctx, c, to, bh.AwayMessage); err != nil {
+		m.log.Error().Err(err).Str("session_id", c.Session.ID).
+			Str("chat_jid", m.redactor.JID(chat)).Msg("send auto-away reply")
+	}
+}
@@ -0,0 +1,17 @@
+package session
+
+import (
+	"context"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// ProfilePictureInfo resolves the current profile picture of a contact or
+// group. preview selects the low-resolution thumbnail variant.
+func (m *Manager) ProfilePictureInfo(ctx context.Context, c *Client, jid types.JID, preview bool) (*types.ProfilePictureInfo, error) {
+	if err := m.EnsureSendable(c); err != nil {
+		return nil, err
+	}
+	return c.WA.GetProfilePictureInfo(jid, &whatsmeow.GetProfilePictureParams{Preview: preview})
+}
@@ -0,0 +1,132 @@
+package session
+
+import (
+	"context"
+	"errors"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/fgsgalvaosz/zemeow/internal/langdetect"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// ErrNoAnchorMessage means a backfill was requested for a chat with no
+// stored message to anchor the history request on.
+var ErrNoAnchorMessage = errors.New("no stored message to anchor backfill")
+
+// RequestHistorySync asks the paired phone for count messages older than
+// the oldest one we have stored for the chat. The phone answers
+// asynchronously with a history sync payload, which is ingested by
+// ingestHistorySync; callers learn completion from the history_sync
+// event.
+func (m *Manager) RequestHistorySync(ctx context.Context, c *Client, chatJID types.JID, count int) error {
+	if c.WA == nil || !c.WA.IsConnected() {
+		return ErrSessionNotConnected
+	}
+	oldest, err := m.repos.Messages.OldestInChat(ctx, c.Session.ID, chatJID.String())
+	if err != nil {
+		return ErrNoAnchorMessage
+	}
+	sender, err := types.ParseJID(oldest.SenderJID)
+	if err != nil {
+		return err
+	}
+	info := &types.MessageInfo{
+		MessageSource: types.MessageSource{
+			Chat:     chatJID,
+			Sender:   sender,
+			IsFromMe: oldest.FromMe,
+			IsGroup:  chatJID.Server == types.GroupServer,
+		},
+		ID:        oldest.MessageID,
+		Timestamp: oldest.Timestamp,
+	}
+	req := c.WA.BuildHistorySyncRequest(info, count)
+	_, err = c.WA.SendMessage(ctx, c.WA.Store.ID.ToNonAD(), req, whatsmeow.SendRequestExtra{Peer: true})
+	return err
+}
+
+// ingestHistorySync stores every message of a history sync payload that
+// we do not already have. Historic messages take a reduced path: no
+// webhook per message, no auto-reply, no conversation reopening — years
+// of backlog must not trigger present-day automation.
+func (m *Manager) ingestHistorySync(ctx context.Context, c *Client, e *events.HistorySync) {
+	perChat := make(map[string]int)
+	for _, conv := range e.Data.GetConversations() {
+		chatJID, err := types.ParseJID(conv.GetID())
+		if err != nil {
+			continue
+		}
+		for _, hmsg := range conv.GetMessages() {
+			evt, err := c.WA.ParseWebMessage(chatJID, hmsg.GetMessage())
+			if err != nil {
+				m.log.Debug().Err(err).Str("session_id", c.Session.ID).
+					Str("chat_jid", m.redactor.JID(chatJID.String())).
+					Msg("unparseable history message")
+				continue
+			}
+			if m.storeHistoryMessage(ctx, c, evt) {
+				perChat[chatJID.String()]++
+			}
+		}
+	}
+	if len(perChat) == 0 {
+		return
+	}
+	total := 0
+	for _, n := range perChat {
+		total += n
+	}
+	m.log.Info().Str("session_id", c.Session.ID).Int("messages", total).
+		Int("chats", len(perChat)).Msg("history sync ingested")
+	m.Dispatch(ctx, c.Session, models.EventHistorySync, map[string]any{
+		"sync_type": e.Data.GetSyncType().String(),
+		"chats":     perChat,
+		"total":     total,
+	})
+}
+
+// storeHistoryMessage persists one parsed historic message, reporting
+// whether it was new. Classification, threading and tagging match the
+// live path so backfilled rows are indistinguishable in queries.
+func (m *Manager) storeHistoryMessage(ctx context.Context, c *Client, e *events.Message) bool {
+	msg := &models.Message{
+		SessionID: c.Session.ID,
+		MessageID: e.Info.ID,
+		ChatJID:   e.Info.Chat.String(),
+		SenderJID: e.Info.Sender.String(),
+		FromMe:    e.Info.IsFromMe,
+		Timestamp: e.Info.Timestamp.UTC(),
+	}
+	msg.Type, msg.Body = classifyMessage(e)
+	if raw, err := proto.Marshal(e.RawMessage); err == nil {
+		msg.Raw = raw
+	}
+	if ci := e.Message.GetExtendedTextMessage().GetContextInfo(); ci != nil {
+		msg.QuotedID = ci.GetStanzaID()
+		msg.Mentions = ci.GetMentionedJID()
+	}
+	if rx := e.Message.GetReactionMessage(); rx != nil {
+		msg.QuotedID = rx.GetKey().GetID()
+	}
+	if msg.QuotedID != "" {
+		msg.ThreadID = m.repos.Messages.ResolveThreadID(ctx, c.Session.ID, msg.QuotedID)
+	} else {
+		msg.ThreadID = msg.MessageID
+	}
+	if !msg.FromMe {
+		msg.Language = langdetect.Detect(msg.Body)
+	}
+	msg.Tags = m.enrichers.Run(msg)
+
+	inserted, err := m.repos.Messages.CreateIfAbsent(ctx, msg)
+	if err != nil {
+		m.log.Error().Err(err).Str("session_id", c.Session.ID).
+			Str("message_id", msg.MessageID).Msg("store backfilled message")
+		return false
+	}
+	return inserted
+}
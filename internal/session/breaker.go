@@ -0,0 +1,79 @@
+package session
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Circuit breaker tuning for the send path. After consecutive send
+// failures the breaker opens and sends fail fast with ErrCircuitOpen;
+// after the open duration one probe send is allowed through (half-open)
+// and its outcome decides whether the breaker closes again.
+const (
+	breakerFailureThreshold = 5
+	breakerOpenDuration     = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned while a session's send circuit is open.
+var ErrCircuitOpen = errors.New("send circuit open")
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// breaker is a per-session circuit breaker for outbound sends.
+type breaker struct {
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+// allow reports whether a send may proceed, transitioning open→half-open
+// once the open duration has elapsed.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerClosed:
+		return true
+	case breakerOpen:
+		if time.Since(b.openedAt) >= breakerOpenDuration {
+			b.state = breakerHalfOpen
+			return true
+		}
+		return false
+	default: // half-open: only the probe that triggered the transition
+		return false
+	}
+}
+
+// onSuccess resets the breaker after a successful send.
+func (b *breaker) onSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = breakerClosed
+	b.failures = 0
+}
+
+// onFailure records a failed send, opening the breaker at the threshold
+// or re-opening it after a failed half-open probe.
+func (b *breaker) onFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == breakerHalfOpen {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+		return
+	}
+	b.failures++
+	if b.failures >= breakerFailureThreshold {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
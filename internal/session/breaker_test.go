@@ -0,0 +1,73 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreakerOpensAtThreshold(t *testing.T) {
+	var b breaker
+	for i := 0; i < breakerFailureThreshold-1; i++ {
+		b.onFailure()
+		if !b.allow() {
+			t.Fatalf("breaker opened after %d failures, threshold is %d", i+1, breakerFailureThreshold)
+		}
+	}
+	b.onFailure()
+	if b.allow() {
+		t.Fatal("breaker still allows sends at the failure threshold")
+	}
+}
+
+func TestBreakerSuccessResets(t *testing.T) {
+	var b breaker
+	for i := 0; i < breakerFailureThreshold-1; i++ {
+		b.onFailure()
+	}
+	b.onSuccess()
+	for i := 0; i < breakerFailureThreshold-1; i++ {
+		b.onFailure()
+	}
+	if !b.allow() {
+		t.Fatal("success did not reset the failure count")
+	}
+}
+
+func TestBreakerHalfOpenProbe(t *testing.T) {
+	var b breaker
+	for i := 0; i < breakerFailureThreshold; i++ {
+		b.onFailure()
+	}
+	// Not enough time elapsed: still open.
+	if b.allow() {
+		t.Fatal("open breaker allowed a send before the open duration elapsed")
+	}
+
+	b.mu.Lock()
+	b.openedAt = time.Now().Add(-breakerOpenDuration)
+	b.mu.Unlock()
+	if !b.allow() {
+		t.Fatal("breaker did not allow the half-open probe")
+	}
+	if b.allow() {
+		t.Fatal("half-open breaker allowed a second send alongside the probe")
+	}
+
+	// A failed probe re-opens the breaker for another full window.
+	b.onFailure()
+	if b.allow() {
+		t.Fatal("breaker closed again after a failed probe")
+	}
+
+	// A successful probe closes it.
+	b.mu.Lock()
+	b.openedAt = time.Now().Add(-breakerOpenDuration)
+	b.mu.Unlock()
+	if !b.allow() {
+		t.Fatal("re-opened breaker did not allow the next probe")
+	}
+	b.onSuccess()
+	if !b.allow() {
+		t.Fatal("breaker did not close after a successful probe")
+	}
+}
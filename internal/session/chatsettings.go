@@ -0,0 +1,22 @@
+package session
+
+import (
+	"context"
+	"errors"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// ErrNoStore is returned when a session has no device store yet (never
+// paired).
+var ErrNoStore = errors.New("session has no device store")
+
+// ChatSettings reads the locally synced settings of one chat (mute,
+// pin, archive state) from the whatsmeow store. This works while
+// disconnected: the store is populated by app state sync and persists.
+func (m *Manager) ChatSettings(ctx context.Context, c *Client, chat types.JID) (types.LocalChatSettings, error) {
+	if c.WA == nil || c.WA.Store == nil || c.WA.Store.ChatSettings == nil {
+		return types.LocalChatSettings{}, ErrNoStore
+	}
+	return c.WA.Store.ChatSettings.GetChatSettings(chat)
+}
@@ -0,0 +1,26 @@
+package session
+
+import (
+	"context"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// UserInfoBatch resolves user info for several contacts in one protocol
+// round trip. Callers are expected to cap the batch size at the API
+// layer.
+func (m *Manager) UserInfoBatch(ctx context.Context, c *Client, jids []types.JID) (map[types.JID]types.UserInfo, error) {
+	if err := m.EnsureSendable(c); err != nil {
+		return nil, err
+	}
+	return c.WA.GetUserInfo(jids)
+}
+
+// BusinessProfile fetches the business profile of one contact; nil when
+// the contact is not a business account.
+func (m *Manager) BusinessProfile(ctx context.Context, c *Client, jid types.JID) (*types.BusinessProfile, error) {
+	if err := m.EnsureSendable(c); err != nil {
+		return nil, err
+	}
+	return c.WA.GetBusinessProfile(jid)
+}
@@ -0,0 +1,52 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/proto/waSyncAction"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// ContactImport is one address book entry to push to the phone.
+type ContactImport struct {
+	JID       types.JID
+	FullName  string
+	FirstName string
+}
+
+// ImportContacts pushes contact name mappings into the session's address
+// book through an app state contact patch, so the saved names show up on
+// the paired phone and in group member lists. All entries go out as one
+// patch; the local contacts table is updated alongside.
+func (m *Manager) ImportContacts(ctx context.Context, c *Client, entries []ContactImport) error {
+	if err := m.EnsureSendable(c); err != nil {
+		return err
+	}
+	patch := appstate.PatchInfo{Type: appstate.WAPatchCriticalUnblockLow}
+	for _, e := range entries {
+		patch.Mutations = append(patch.Mutations, appstate.MutationInfo{
+			Index:   []string{appstate.IndexContact, e.JID.String()},
+			Version: 2,
+			Value: &waSyncAction.SyncActionValue{
+				ContactAction: &waSyncAction.ContactAction{
+					FullName:  proto.String(e.FullName),
+					FirstName: proto.String(e.FirstName),
+				},
+			},
+		})
+	}
+	if err := c.WA.SendAppState(patch); err != nil {
+		return fmt.Errorf("send contact patch: %w", err)
+	}
+	for _, e := range entries {
+		if err := m.repos.Contacts.UpsertFullName(ctx, c.Session.ID,
+			e.JID.ToNonAD().String(), e.FullName); err != nil {
+			m.log.Error().Err(err).Str("jid", m.redactor.JID(e.JID.String())).
+				Msg("store imported contact")
+		}
+	}
+	return nil
+}
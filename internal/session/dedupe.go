@@ -0,0 +1,53 @@
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// ErrDuplicateSend is returned when an identical text is sent to the
+// same recipient within the session's dedupe window.
+var ErrDuplicateSend = errors.New("duplicate send within dedupe window")
+
+// dedupeState remembers recent (recipient, text) sends so buggy upstream
+// systems cannot accidentally double-send. Entries are pruned lazily on
+// each check.
+type dedupeState struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// guardDuplicateText rejects a text send when the same content went to
+// the same recipient within the session window. A window of zero
+// disables the guard. The send is recorded optimistically; a failed send
+// still counts, which errs on the side of not double-delivering.
+func (m *Manager) guardDuplicateText(c *Client, to types.JID, text string) error {
+	window := time.Duration(c.Session.DedupeWindowSeconds) * time.Second
+	if window <= 0 {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(to.ToNonAD().String() + "\x00" + text))
+	key := hex.EncodeToString(sum[:16])
+	now := time.Now()
+
+	c.dedupe.mu.Lock()
+	defer c.dedupe.mu.Unlock()
+	if c.dedupe.seen == nil {
+		c.dedupe.seen = make(map[string]time.Time)
+	}
+	for k, t := range c.dedupe.seen {
+		if now.Sub(t) > window {
+			delete(c.dedupe.seen, k)
+		}
+	}
+	if t, ok := c.dedupe.seen[key]; ok && now.Sub(t) <= window {
+		return ErrDuplicateSend
+	}
+	c.dedupe.seen[key] = now
+	return nil
+}
@@ -0,0 +1,75 @@
+package session
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+func dedupeClient(windowSeconds int) *Client {
+	return &Client{Session: &models.Session{
+		ID:                  "test-session",
+		DedupeWindowSeconds: windowSeconds,
+	}}
+}
+
+func TestGuardDuplicateText(t *testing.T) {
+	m := &Manager{}
+	c := dedupeClient(60)
+	to := types.NewJID("5511999999999", types.DefaultUserServer)
+
+	if err := m.guardDuplicateText(c, to, "hello"); err != nil {
+		t.Fatalf("first send: %v", err)
+	}
+	if err := m.guardDuplicateText(c, to, "hello"); !errors.Is(err, ErrDuplicateSend) {
+		t.Fatalf("duplicate send: got %v, want ErrDuplicateSend", err)
+	}
+	if err := m.guardDuplicateText(c, to, "different text"); err != nil {
+		t.Fatalf("different text: %v", err)
+	}
+	other := types.NewJID("5511888888888", types.DefaultUserServer)
+	if err := m.guardDuplicateText(c, other, "hello"); err != nil {
+		t.Fatalf("same text, different recipient: %v", err)
+	}
+}
+
+func TestGuardDuplicateTextDisabled(t *testing.T) {
+	m := &Manager{}
+	c := dedupeClient(0)
+	to := types.NewJID("5511999999999", types.DefaultUserServer)
+	for i := 0; i < 3; i++ {
+		if err := m.guardDuplicateText(c, to, "hello"); err != nil {
+			t.Fatalf("send %d with window disabled: %v", i+1, err)
+		}
+	}
+}
+
+func TestGuardDuplicateTextPrunesExpired(t *testing.T) {
+	m := &Manager{}
+	c := dedupeClient(60)
+	to := types.NewJID("5511999999999", types.DefaultUserServer)
+
+	if err := m.guardDuplicateText(c, to, "hello"); err != nil {
+		t.Fatalf("first send: %v", err)
+	}
+	// Age every recorded entry past the window; the repeat must pass and
+	// the stale entry must be pruned rather than accumulate.
+	c.dedupe.mu.Lock()
+	for k := range c.dedupe.seen {
+		c.dedupe.seen[k] = time.Now().Add(-61 * time.Second)
+	}
+	c.dedupe.mu.Unlock()
+
+	if err := m.guardDuplicateText(c, to, "hello"); err != nil {
+		t.Fatalf("send after window elapsed: %v", err)
+	}
+	c.dedupe.mu.Lock()
+	defer c.dedupe.mu.Unlock()
+	if len(c.dedupe.seen) != 1 {
+		t.Errorf("seen map holds %d entries after pruning, want 1", len(c.dedupe.seen))
+	}
+}
@@ -0,0 +1,119 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// Errors returned by DeleteMessage and RevokeOwnMessage.
+var (
+	ErrRevokeTargetUnknown = errors.New("message to revoke is not in the store")
+	ErrNotGroupAdmin       = errors.New("session is not an admin of this group")
+	ErrRevokeNotOwn        = errors.New("only own messages can be revoked outside groups")
+	ErrRevokeWindowExpired = errors.New("revoke window has expired")
+)
+
+// revokeWindow matches WhatsApp's delete-for-everyone limit. Revokes
+// sent after it are silently ignored by recipients, so reject them
+// upfront instead.
+const revokeWindow = 60 * time.Hour
+
+// DeleteMessage revokes a message for everyone. Own messages revoke
+// directly; revoking another participant's message is an admin action
+// that requires the session to be a group admin, checked against the
+// group metadata before the revoke is attempted.
+func (m *Manager) DeleteMessage(ctx context.Context, c *Client, chat types.JID, messageID string) (whatsmeow.SendResponse, error) {
+	orig, err := m.repos.Messages.GetByMessageID(ctx, c.Session.ID, messageID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return whatsmeow.SendResponse{}, ErrRevokeTargetUnknown
+	}
+	if err != nil {
+		return whatsmeow.SendResponse{}, fmt.Errorf("look up revoke target: %w", err)
+	}
+
+	// Own messages build a plain revoke key. Someone else's message needs
+	// the sender as participant and admin rights on the group.
+	sender := types.EmptyJID
+	if !orig.FromMe {
+		if chat.Server != types.GroupServer {
+			return whatsmeow.SendResponse{}, ErrRevokeNotOwn
+		}
+		if sender, err = types.ParseJID(orig.SenderJID); err != nil {
+			return whatsmeow.SendResponse{}, fmt.Errorf("parse original sender: %w", err)
+		}
+		admin, err := m.isGroupAdmin(ctx, c, chat)
+		if err != nil {
+			return whatsmeow.SendResponse{}, err
+		}
+		if !admin {
+			return whatsmeow.SendResponse{}, ErrNotGroupAdmin
+		}
+	}
+
+	if err := m.EnsureSendable(c); err != nil {
+		return whatsmeow.SendResponse{}, err
+	}
+	return m.Send(ctx, c, chat, c.WA.BuildRevoke(chat, sender, types.MessageID(messageID)))
+}
+
+// isGroupAdmin reports whether the session's own JID holds admin rights
+// in the group.
+func (m *Manager) isGroupAdmin(ctx context.Context, c *Client, group types.JID) (bool, error) {
+	info, err := m.GroupInfo(ctx, c, group, false)
+	if err != nil {
+		return false, fmt.Errorf("fetch group info: %w", err)
+	}
+	if c.WA == nil || c.WA.Store.ID == nil {
+		return false, ErrSessionNotConnected
+	}
+	own := c.WA.Store.ID.ToNonAD()
+	for _, p := range info.Participants {
+		if p.JID.ToNonAD() == own {
+			return p.IsAdmin || p.IsSuperAdmin, nil
+		}
+	}
+	return false, nil
+}
+
+// RevokeOwnMessage is the undo-send path: it resolves the chat from the
+// stored message (no request body needed), refuses messages that are not
+// ours or older than the revoke window, sends the revoke and marks the
+// stored row revoked.
+func (m *Manager) RevokeOwnMessage(ctx context.Context, c *Client, messageID string) (whatsmeow.SendResponse, error) {
+	orig, err := m.repos.Messages.GetByMessageID(ctx, c.Session.ID, messageID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return whatsmeow.SendResponse{}, ErrRevokeTargetUnknown
+	}
+	if err != nil {
+		return whatsmeow.SendResponse{}, fmt.Errorf("look up revoke target: %w", err)
+	}
+	if !orig.FromMe {
+		return whatsmeow.SendResponse{}, ErrRevokeNotOwn
+	}
+	if time.Since(orig.Timestamp) > revokeWindow {
+		return whatsmeow.SendResponse{}, ErrRevokeWindowExpired
+	}
+	chat, err := types.ParseJID(orig.ChatJID)
+	if err != nil {
+		return whatsmeow.SendResponse{}, fmt.Errorf("parse stored chat jid: %w", err)
+	}
+
+	if err := m.EnsureSendable(c); err != nil {
+		return whatsmeow.SendResponse{}, err
+	}
+	resp, err := m.Send(ctx, c, chat, c.WA.BuildRevoke(chat, types.EmptyJID, types.MessageID(messageID)))
+	if err != nil {
+		return resp, err
+	}
+	if err := m.repos.Messages.MarkRevoked(ctx, c.Session.ID, messageID); err != nil {
+		m.log.Error().Err(err).Str("session_id", c.Session.ID).
+			Str("message_id", messageID).Msg("mark message revoked")
+	}
+	return resp, nil
+}
@@ -0,0 +1,57 @@
+package session
+
+import (
+	"context"
+	"errors"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+)
+
+// Errors returned by DownloadMedia.
+var (
+	ErrNotMedia     = errors.New("message has no downloadable media")
+	ErrNoRawMessage = errors.New("message has no stored protocol payload")
+)
+
+// MediaDownload is the decrypted blob of a stored media message plus
+// the metadata needed to serve it.
+type MediaDownload struct {
+	Data     []byte
+	MimeType string
+	FileName string
+}
+
+// DownloadMedia fetches and decrypts the media blob of a stored message.
+// The stored raw protobuf carries the media key and direct path, so the
+// download works for any message we ingested — including backfilled
+// history — as long as WhatsApp still hosts the blob.
+func (m *Manager) DownloadMedia(ctx context.Context, c *Client, messageID string) (*MediaDownload, error) {
+	msg, err := m.repos.Messages.GetByMessageID(ctx, c.Session.ID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if len(msg.Raw) == 0 {
+		return nil, ErrNoRawMessage
+	}
+	var raw waE2E.Message
+	if err := proto.Unmarshal(msg.Raw, &raw); err != nil {
+		return nil, ErrNoRawMessage
+	}
+	part := downloadableMediaPart(&raw)
+	if part == nil {
+		return nil, ErrNotMedia
+	}
+	if c.WA == nil || !c.WA.IsConnected() {
+		return nil, ErrSessionNotConnected
+	}
+	data, err := c.WA.Download(part)
+	if err != nil {
+		return nil, err
+	}
+	dl := &MediaDownload{Data: data, MimeType: part.GetMimetype()}
+	if doc := raw.GetDocumentMessage(); doc != nil {
+		dl.FileName = doc.GetFileName()
+	}
+	return dl, nil
+}
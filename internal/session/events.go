@@ -0,0 +1,352 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/fgsgalvaosz/zemeow/internal/langdetect"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// handleEvent is installed as the whatsmeow event handler for every
+// connected client. It normalizes protocol events into the shapes stored
+// in the database and delivered to webhooks.
+func (m *Manager) handleEvent(c *Client, evt any) {
+	ctx := context.Background()
+	switch e := evt.(type) {
+	case *events.Connected:
+		m.setStatus(ctx, c, models.SessionStatusConnected)
+		m.recordTimeline(ctx, c, models.SessionEventConnected, c.Session.DeviceJID)
+		m.startPresenceHeartbeat(c)
+		m.Dispatch(ctx, c.Session, models.EventConnected,
+			map[string]any{"device_jid": c.Session.DeviceJID})
+
+	case *events.PairSuccess:
+		c.Session.DeviceJID = e.ID.String()
+		now := time.Now().UTC()
+		c.Session.PairedAt = &now
+		m.recordTimeline(ctx, c, models.SessionEventPaired, c.Session.DeviceJID)
+		if err := m.repos.Sessions.Update(ctx, c.Session); err != nil {
+			m.log.Error().Err(err).Str("session_id", c.Session.ID).Msg("persist pairing")
+		}
+
+	case *events.Disconnected:
+		m.stopPresenceHeartbeat(c)
+		m.setStatus(ctx, c, models.SessionStatusDisconnected)
+		m.recordTimeline(ctx, c, models.SessionEventDisconnected, "")
+		m.Dispatch(ctx, c.Session, models.EventDisconnected, map[string]any{})
+
+	case *events.LoggedOut:
+		m.stopPresenceHeartbeat(c)
+		m.setStatus(ctx, c, models.SessionStatusLoggedOut)
+		m.recordTimeline(ctx, c, models.SessionEventLoggedOut, e.Reason.String())
+		m.Dispatch(ctx, c.Session, models.EventLoggedOut,
+			map[string]any{"reason": e.Reason.String()})
+
+	case *events.Message:
+		m.handleMessage(ctx, c, e)
+
+	case *events.HistorySync:
+		// Payloads can carry thousands of messages; ingest off the event
+		// goroutine so live traffic keeps flowing.
+		go m.ingestHistorySync(ctx, c, e)
+
+	case *events.Receipt:
+		// Receipts are persisted at full fidelity; sampling only gates
+		// the webhook/queue dispatch below.
+		if err := m.repos.Receipts.Record(ctx, &models.Receipt{
+			SessionID: c.Session.ID,
+			ChatJID:   e.Chat.String(),
+			SenderJID: e.Sender.String(),
+			Type:      string(e.Type),
+			Timestamp: e.Timestamp.UTC(),
+		}, e.MessageIDs); err != nil {
+			m.log.Error().Err(err).Msg("store receipts")
+		}
+		// Retry receipts escalate separately and are never sampled away.
+		if e.Type == types.ReceiptTypeRetry {
+			m.handleRetryReceipt(ctx, c, e)
+		}
+		if !c.sampleReceipt() {
+			return
+		}
+		m.Dispatch(ctx, c.Session, models.EventReceipt, map[string]any{
+			"chat_jid":    e.Chat.String(),
+			"sender_jid":  e.Sender.String(),
+			"message_ids": e.MessageIDs,
+			"receipt":     string(e.Type),
+			"timestamp":   e.Timestamp.UTC(),
+		})
+
+	case *events.StreamError:
+		countProtocolError(c.Session.ID, "stream_error")
+		m.recordTimeline(ctx, c, models.SessionEventStreamError, e.Code)
+
+	case *events.ConnectFailure:
+		countProtocolError(c.Session.ID, "connect_failure")
+		m.recordTimeline(ctx, c, models.SessionEventConnectFailed, e.Reason.String())
+
+	case *events.TemporaryBan:
+		countProtocolError(c.Session.ID, "temporary_ban")
+		m.recordTimeline(ctx, c, models.SessionEventTemporaryBan, e.String())
+		m.NotifyAdmin("session_banned", c.Session.ID, map[string]any{
+			"reason": e.String(),
+		})
+
+	case *events.Picture:
+		m.Dispatch(ctx, c.Session, models.EventPicture, map[string]any{
+			"jid":        e.JID.String(),
+			"author_jid": e.Author.String(),
+			"removed":    e.Remove,
+			"picture_id": e.PictureID,
+			"timestamp":  e.Timestamp.UTC(),
+		})
+
+	case *events.Contact:
+		fullName := e.Action.GetFullName()
+		if err := m.repos.Contacts.UpsertFullName(ctx, c.Session.ID,
+			e.JID.ToNonAD().String(), fullName); err != nil {
+			m.log.Error().Err(err).Str("jid", m.redactor.JID(e.JID.String())).Msg("store contact update")
+		}
+		m.Dispatch(ctx, c.Session, models.EventContact, map[string]any{
+			"jid":       e.JID.String(),
+			"full_name": fullName,
+			"timestamp": e.Timestamp.UTC(),
+		})
+
+	case *events.PushName:
+		if err := m.repos.Contacts.UpsertPushName(ctx, c.Session.ID,
+			e.JID.ToNonAD().String(), e.NewPushName); err != nil {
+			m.log.Error().Err(err).Str("jid", m.redactor.JID(e.JID.String())).Msg("store push name update")
+		}
+		m.Dispatch(ctx, c.Session, models.EventContact, map[string]any{
+			"jid":       e.JID.String(),
+			"push_name": e.NewPushName,
+		})
+
+	case *events.Star:
+		// Stars arrive via app state sync (from this or another device);
+		// mirroring them onto the row powers the starred history filter.
+		if err := m.repos.Messages.SetStarred(ctx, c.Session.ID, e.MessageID, e.Action.GetStarred()); err != nil {
+			m.log.Error().Err(err).Str("session_id", c.Session.ID).Msg("store star update")
+		}
+
+	case *events.ChatPresence:
+		// Map the protocol states onto the UX vocabulary: composing,
+		// recording, or paused.
+		state := string(e.State)
+		if e.State == types.ChatPresenceComposing && e.Media == types.ChatPresenceMediaAudio {
+			state = "recording"
+		}
+		if !c.debounceChatPresence(e.Chat.String()+"|"+e.Sender.String(), state) {
+			return
+		}
+		m.Dispatch(ctx, c.Session, models.EventChatPresence, map[string]any{
+			"chat_jid":   e.Chat.String(),
+			"sender_jid": e.Sender.String(),
+			"state":      state,
+		})
+
+	case *events.Presence:
+		if !c.throttlePresence(e.From.String()) {
+			return
+		}
+		m.Dispatch(ctx, c.Session, models.EventPresence, map[string]any{
+			"jid":         e.From.String(),
+			"unavailable": e.Unavailable,
+			"last_seen":   e.LastSeen.UTC(),
+		})
+	}
+}
+
+func (m *Manager) handleMessage(ctx context.Context, c *Client, e *events.Message) {
+	msg := &models.Message{
+		SessionID: c.Session.ID,
+		MessageID: e.Info.ID,
+		ChatJID:   e.Info.Chat.String(),
+		SenderJID: e.Info.Sender.String(),
+		FromMe:    e.Info.IsFromMe,
+		Timestamp: e.Info.Timestamp.UTC(),
+	}
+	msg.Type, msg.Body = classifyMessage(e)
+	if raw, err := proto.Marshal(e.RawMessage); err == nil {
+		msg.Raw = raw
+	}
+	// Followed newsletter channels deliver plaintext broadcast messages;
+	// they are stored under the channel JID and take their own event path.
+	if e.Info.Chat.Server == types.NewsletterServer {
+		m.handleNewsletterMessage(ctx, c, e, msg)
+		return
+	}
+	if ci := e.Message.GetExtendedTextMessage().GetContextInfo(); ci != nil {
+		msg.QuotedID = ci.GetStanzaID()
+		msg.Mentions = ci.GetMentionedJID()
+	}
+	// Reactions carry their target in the protocol key; recording it as
+	// the quoted id is what lets history aggregate them per message.
+	if rx := e.Message.GetReactionMessage(); rx != nil {
+		msg.QuotedID = rx.GetKey().GetID()
+	}
+	// Replies inherit the quoted message's thread; everything else roots
+	// its own.
+	if msg.QuotedID != "" {
+		msg.ThreadID = m.repos.Messages.ResolveThreadID(ctx, c.Session.ID, msg.QuotedID)
+	} else {
+		msg.ThreadID = msg.MessageID
+	}
+
+	m.extractMediaText(ctx, c, e, msg)
+	m.transcribeVoiceNote(ctx, c, e, msg)
+	// Detect the sender's language for routing; outbound text would only
+	// report the agent's language, which is not useful.
+	if !msg.FromMe {
+		msg.Language = langdetect.Detect(msg.Body)
+	}
+	msg.Tags = m.enrichers.Run(msg)
+	m.scanInboundDocument(ctx, c, e, msg)
+
+	// Inbound filter matches are stored for review but go no further:
+	// no webhook, no conversation tracking, no auto-reply.
+	if reason := m.quarantineReason(ctx, c, msg); reason != "" {
+		msg.Quarantined = true
+		msg.QuarantineReason = reason
+		m.enqueueMessage(ctx, msg)
+		m.log.Debug().Str("session_id", c.Session.ID).Str("reason", reason).
+			Str("sender", m.redactor.JID(msg.SenderJID)).Msg("message quarantined")
+		return
+	}
+	m.enqueueMessage(ctx, msg)
+
+	// Inbound traffic opens (or reopens) the conversation workflow entry.
+	if !msg.FromMe {
+		state, err := m.repos.Convos.EnsureOpen(ctx, c.Session.ID, msg.ChatJID)
+		if err != nil {
+			m.log.Error().Err(err).Str("session_id", c.Session.ID).Msg("open conversation")
+		} else if state != "" {
+			m.Dispatch(ctx, c.Session, models.EventConversation, map[string]any{
+				"chat_jid": msg.ChatJID,
+				"state":    state,
+				"reason":   "inbound_message",
+			})
+		}
+	}
+
+	// Backfill the sender's push name into the contacts table; message
+	// events are the only reliable source for names of non-contacts.
+	if !e.Info.IsFromMe && e.Info.PushName != "" {
+		if err := m.repos.Contacts.UpsertPushName(ctx, c.Session.ID,
+			e.Info.Sender.ToNonAD().String(), e.Info.PushName); err != nil {
+			m.log.Error().Err(err).Str("jid", m.redactor.JID(e.Info.Sender.String())).Msg("backfill push name")
+		}
+	}
+
+	payload := map[string]any{
+		"message_id": msg.MessageID,
+		"chat_jid":   msg.ChatJID,
+		"sender_jid": msg.SenderJID,
+		"from_me":    msg.FromMe,
+		"type":       msg.Type,
+		"body":       msg.Body,
+		"quoted_id":  msg.QuotedID,
+		"thread_id":  msg.ThreadID,
+		"tags":       msg.Tags,
+		"timestamp":  msg.Timestamp,
+		"push_name":  e.Info.PushName,
+		"context": map[string]any{
+			"is_group": e.Info.IsGroup,
+			"mentions": mentionedJIDs(e),
+		},
+	}
+	if msg.QuotedID != "" {
+		payload["quoted"] = m.quotedContext(ctx, c, msg)
+	}
+	if msg.ScanVerdict != "" {
+		payload["scan_verdict"] = msg.ScanVerdict
+	}
+	if msg.ExtractedText != "" {
+		payload["extracted_text"] = msg.ExtractedText
+	}
+	if msg.Transcript != "" {
+		payload["transcript"] = msg.Transcript
+	}
+	if msg.Language != "" {
+		payload["language"] = msg.Language
+	}
+	m.attachInlineMedia(c, e, payload)
+	// Annotate inbound text with a provider translation when configured;
+	// failures just leave the annotation off.
+	if !msg.FromMe && m.translator.Enabled() {
+		if translated := m.translator.Translate(ctx, msg.Body, m.translator.TargetLang()); translated != "" && translated != msg.Body {
+			payload["translated"] = translated
+			payload["translated_lang"] = m.translator.TargetLang()
+		}
+	}
+	m.Dispatch(ctx, c.Session, models.EventMessage, payload)
+
+	m.moderateGroupMessage(ctx, c, e, msg)
+	m.handleSubscriptionKeywords(ctx, c, e, msg)
+	m.maybeAutoReply(ctx, c, e)
+}
+
+// quotedContext resolves the quoted message from the store so webhook
+// consumers get the referenced content inline instead of just a stanza
+// id. Falls back to the bare id when the original was never stored.
+func (m *Manager) quotedContext(ctx context.Context, c *Client, msg *models.Message) map[string]any {
+	quoted, err := m.repos.Messages.GetByMessageID(ctx, c.Session.ID, msg.QuotedID)
+	if err != nil {
+		return map[string]any{"message_id": msg.QuotedID}
+	}
+	return map[string]any{
+		"message_id": quoted.MessageID,
+		"sender_jid": quoted.SenderJID,
+		"from_me":    quoted.FromMe,
+		"type":       quoted.Type,
+		"body":       quoted.Body,
+		"timestamp":  quoted.Timestamp,
+	}
+}
+
+// mentionedJIDs extracts @-mention targets from the message context info.
+func mentionedJIDs(e *events.Message) []string {
+	ci := e.Message.GetExtendedTextMessage().GetContextInfo()
+	if ci == nil {
+		return nil
+	}
+	return ci.GetMentionedJID()
+}
+
+// classifyMessage maps a protocol message onto the stored type and text
+// body. Media bodies carry the caption when present.
+func classifyMessage(e *events.Message) (string, string) {
+	msg := e.Message
+	switch {
+	case msg.GetConversation() != "":
+		return models.MessageTypeText, msg.GetConversation()
+	case msg.GetExtendedTextMessage() != nil:
+		return models.MessageTypeText, msg.GetExtendedTextMessage().GetText()
+	case msg.GetImageMessage() != nil:
+		return models.MessageTypeImage, msg.GetImageMessage().GetCaption()
+	case msg.GetVideoMessage() != nil:
+		return models.MessageTypeVideo, msg.GetVideoMessage().GetCaption()
+	case msg.GetAudioMessage() != nil:
+		return models.MessageTypeAudio, ""
+	case msg.GetDocumentMessage() != nil:
+		return models.MessageTypeDocument, msg.GetDocumentMessage().GetCaption()
+	case msg.GetStickerMessage() != nil:
+		return models.MessageTypeSticker, ""
+	case msg.GetLocationMessage() != nil:
+		return models.MessageTypeLocation, msg.GetLocationMessage().GetName()
+	case msg.GetContactMessage() != nil:
+		return models.MessageTypeContact, msg.GetContactMessage().GetDisplayName()
+	case msg.GetReactionMessage() != nil:
+		return models.MessageTypeReaction, msg.GetReactionMessage().GetText()
+	case msg.GetProtocolMessage() != nil && msg.GetProtocolMessage().GetType() == 0:
+		return models.MessageTypeRevoke, ""
+	default:
+		return models.MessageTypeUnknown, ""
+	}
+}
@@ -0,0 +1,50 @@
+package session
+
+import (
+	"context"
+	"strings"
+
+	"go.mau.fi/whatsmeow/types/events"
+
+	"github.com/fgsgalvaosz/zemeow/internal/extract"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// extractMediaText runs the OCR/extraction provider over inbound images
+// and PDF documents and records the result on the message. It runs
+// before the enrichers so keyword routing sees media content too.
+// Extraction failures leave the text empty rather than blocking
+// ingestion.
+func (m *Manager) extractMediaText(ctx context.Context, c *Client, e *events.Message, msg *models.Message) {
+	if !m.extractor.Enabled() || msg.FromMe {
+		return
+	}
+	media := downloadableMediaPart(e.Message)
+	if media == nil {
+		return
+	}
+
+	var kind string
+	switch msg.Type {
+	case models.MessageTypeImage:
+		kind = extract.KindImage
+	case models.MessageTypeDocument:
+		if strings.EqualFold(media.GetMimetype(), "application/pdf") {
+			kind = extract.KindPDF
+		}
+	}
+	if kind == "" {
+		return
+	}
+	if limit := m.extractor.MaxBytes(); limit > 0 && media.GetFileLength() > uint64(limit) {
+		return
+	}
+
+	data, err := c.WA.Download(media)
+	if err != nil {
+		m.log.Warn().Err(err).Str("session_id", c.Session.ID).
+			Str("message_id", msg.MessageID).Msg("download media for extraction")
+		return
+	}
+	msg.ExtractedText = m.extractor.Text(ctx, kind, media.GetMimetype(), data)
+}
@@ -0,0 +1,84 @@
+package session
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// filterURLPattern matches http(s) URLs for the link-blocking filter.
+var filterURLPattern = regexp.MustCompile(`https?://[^\s]+`)
+
+// filterState tracks per-sender message counts for the unknown-sender
+// throttle in one-minute windows.
+type filterState struct {
+	mu     sync.Mutex
+	window time.Time
+	counts map[string]int
+}
+
+// bump counts one message from sender and reports whether the sender
+// exceeded max within the current window.
+func (f *filterState) bump(sender string, max int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	if f.counts == nil || now.Sub(f.window) >= time.Minute {
+		f.window = now
+		f.counts = make(map[string]int)
+	}
+	f.counts[sender]++
+	return f.counts[sender] > max
+}
+
+// quarantineReason evaluates the session's inbound filters against an
+// inbound message and returns the quarantine reason, or "" when the
+// message passes. Outbound messages always pass.
+func (m *Manager) quarantineReason(ctx context.Context, c *Client, msg *models.Message) string {
+	f := c.Session.InboundFilters
+	if f == nil || msg.FromMe {
+		return ""
+	}
+
+	// Patterns are validated at set time; compile errors here are skipped.
+	for _, p := range f.BlockPatterns {
+		re, err := regexp.Compile("(?i)" + p)
+		if err != nil {
+			continue
+		}
+		if msg.Body != "" && re.MatchString(msg.Body) {
+			return "blocked_pattern"
+		}
+	}
+
+	if f.BlockLinks && filterURLPattern.MatchString(msg.Body) {
+		return "blocked_link"
+	}
+
+	if f.UnknownSenderMaxPerMinute > 0 && !m.isKnownContact(ctx, c, msg.SenderJID) {
+		if c.filters.bump(msg.SenderJID, f.UnknownSenderMaxPerMinute) {
+			return "unknown_sender_throttle"
+		}
+	}
+	return ""
+}
+
+// isKnownContact reports whether the sender is in the address book (a
+// contact row with a synced full name). Push names arrive with the first
+// message, so they deliberately do not count as "known".
+func (m *Manager) isKnownContact(ctx context.Context, c *Client, senderJID string) bool {
+	// Contacts are keyed by the non-AD JID.
+	if jid, err := types.ParseJID(senderJID); err == nil {
+		senderJID = jid.ToNonAD().String()
+	}
+	contact, err := m.repos.Contacts.GetByJID(ctx, c.Session.ID, senderJID)
+	if err != nil {
+		return false
+	}
+	return contact.FullName != ""
+}
@@ -0,0 +1,119 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// groupCacheTTL is how long cached group metadata is served before a
+// lookup goes back to the server. The refresh endpoint bypasses it.
+const groupCacheTTL = 10 * time.Minute
+
+// groupCache holds per-session group metadata keyed by group JID.
+type groupCache struct {
+	mu      sync.RWMutex
+	entries map[types.JID]*groupCacheEntry
+}
+
+type groupCacheEntry struct {
+	info      *types.GroupInfo
+	fetchedAt time.Time
+}
+
+// GroupInfo returns group metadata, served from the cache while fresh.
+// forceRefresh bypasses the TTL and repopulates the entry.
+func (m *Manager) GroupInfo(ctx context.Context, c *Client, jid types.JID, forceRefresh bool) (*types.GroupInfo, error) {
+	if !forceRefresh {
+		c.groups.mu.RLock()
+		entry, ok := c.groups.entries[jid]
+		c.groups.mu.RUnlock()
+		if ok && time.Since(entry.fetchedAt) < groupCacheTTL {
+			return entry.info, nil
+		}
+	}
+
+	if err := m.EnsureSendable(c); err != nil {
+		return nil, err
+	}
+	info, err := c.WA.GetGroupInfo(jid)
+	if err != nil {
+		return nil, err
+	}
+
+	c.groups.mu.Lock()
+	if c.groups.entries == nil {
+		c.groups.entries = make(map[types.JID]*groupCacheEntry)
+	}
+	c.groups.entries[jid] = &groupCacheEntry{info: info, fetchedAt: time.Now()}
+	c.groups.mu.Unlock()
+
+	m.captureIdentities(ctx, c, info)
+	return info, nil
+}
+
+// JoinedGroupsCached returns the group list from the metadata cache
+// when it is primed, so listing hundreds of groups does not block on a
+// server round trip. An empty cache falls through to JoinedGroups.
+func (m *Manager) JoinedGroupsCached(ctx context.Context, c *Client) ([]*types.GroupInfo, error) {
+	c.groups.mu.RLock()
+	cached := make([]*types.GroupInfo, 0, len(c.groups.entries))
+	for _, entry := range c.groups.entries {
+		cached = append(cached, entry.info)
+	}
+	c.groups.mu.RUnlock()
+	if len(cached) > 0 {
+		return cached, nil
+	}
+	return m.JoinedGroups(ctx, c)
+}
+
+// JoinedGroups lists all groups the session participates in and primes
+// the cache with the returned metadata.
+func (m *Manager) JoinedGroups(ctx context.Context, c *Client) ([]*types.GroupInfo, error) {
+	if err := m.EnsureSendable(c); err != nil {
+		return nil, err
+	}
+	groups, err := c.WA.GetJoinedGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	c.groups.mu.Lock()
+	if c.groups.entries == nil {
+		c.groups.entries = make(map[types.JID]*groupCacheEntry)
+	}
+	for _, g := range groups {
+		c.groups.entries[g.JID] = &groupCacheEntry{info: g, fetchedAt: now}
+	}
+	c.groups.mu.Unlock()
+
+	for _, g := range groups {
+		m.captureIdentities(ctx, c, g)
+	}
+	return groups, nil
+}
+
+// captureIdentities records phone/LID pairs exposed in group participant
+// lists, the one place the server hands us both identifiers for the same
+// person. Best effort; failures only log.
+func (m *Manager) captureIdentities(ctx context.Context, c *Client, info *types.GroupInfo) {
+	for _, p := range info.Participants {
+		if p.LID.IsEmpty() || p.JID.Server != types.DefaultUserServer {
+			continue
+		}
+		id := &models.Identity{
+			SessionID: c.Session.ID,
+			PhoneJID:  p.JID.ToNonAD().String(),
+			LIDJID:    p.LID.ToNonAD().String(),
+		}
+		if err := m.repos.IDs.Upsert(ctx, id); err != nil {
+			m.log.Error().Err(err).Str("session_id", c.Session.ID).Msg("store identity mapping")
+		}
+	}
+}
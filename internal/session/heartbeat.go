@@ -0,0 +1,77 @@
+package session
+
+import (
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// ConfigureKeepalive applies the configured WebSocket keepalive ping
+// window to whatsmeow. The library exposes these as package-level
+// settings, so this runs once at startup, not per session.
+func ConfigureKeepalive(cfg config.WhatsAppConfig) {
+	if cfg.KeepAliveIntervalMin > 0 {
+		whatsmeow.KeepAliveIntervalMin = cfg.KeepAliveIntervalMin
+	}
+	if cfg.KeepAliveIntervalMax > 0 {
+		whatsmeow.KeepAliveIntervalMax = cfg.KeepAliveIntervalMax
+	}
+}
+
+// heartbeatState owns the per-session presence heartbeat goroutine.
+type heartbeatState struct {
+	mu   sync.Mutex
+	stop chan struct{}
+}
+
+// startPresenceHeartbeat begins re-announcing "available" presence every
+// PresenceHeartbeatSeconds while the session is connected. Some flows
+// only receive certain messages while the number appears online, so the
+// heartbeat keeps the presence fresh instead of letting it lapse after
+// the last send. A no-op when the session does not configure it.
+func (m *Manager) startPresenceHeartbeat(c *Client) {
+	interval := c.Session.PresenceHeartbeatSeconds
+	if interval <= 0 {
+		return
+	}
+	m.stopPresenceHeartbeat(c)
+
+	c.heartbeat.mu.Lock()
+	stop := make(chan struct{})
+	c.heartbeat.stop = stop
+	c.heartbeat.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+		for {
+			wa := c.WA
+			if wa == nil || !wa.IsConnected() {
+				return
+			}
+			if err := wa.SendPresence(types.PresenceAvailable); err != nil {
+				m.log.Warn().Err(err).Str("session_id", c.Session.ID).
+					Msg("presence heartbeat")
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// stopPresenceHeartbeat halts the heartbeat goroutine if one is running.
+func (m *Manager) stopPresenceHeartbeat(c *Client) {
+	c.heartbeat.mu.Lock()
+	defer c.heartbeat.mu.Unlock()
+	if c.heartbeat.stop != nil {
+		close(c.heartbeat.stop)
+		c.heartbeat.stop = nil
+	}
+}
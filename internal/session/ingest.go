@@ -0,0 +1,78 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/metrics"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// Ingestion batching bounds. Messages are buffered and flushed to the
+// database in one COPY either when the buffer fills or on the ticker,
+// whichever comes first. At low volume the latency cost is one flush
+// interval; at high volume writes collapse into large batches.
+const (
+	ingestBatchSize     = 500
+	ingestFlushInterval = 200 * time.Millisecond
+	ingestQueueDepth    = 10000
+)
+
+// enqueueMessage hands a stored message to the ingestion flusher. When
+// the queue is saturated it falls back to a synchronous insert rather
+// than dropping the message.
+func (m *Manager) enqueueMessage(ctx context.Context, msg *models.Message) {
+	select {
+	case m.ingestCh <- msg:
+		metrics.IngestQueueDepth.Set(float64(len(m.ingestCh)))
+	default:
+		metrics.IngestOverflow.Inc()
+		if err := m.repos.Messages.Create(ctx, msg); err != nil {
+			m.log.Error().Err(err).Str("message_id", msg.MessageID).
+				Msg("store message (ingest queue full)")
+		}
+	}
+}
+
+// runIngest is the single flusher goroutine, started by NewManager and
+// stopped by closing ingestCh.
+func (m *Manager) runIngest() {
+	ticker := time.NewTicker(ingestFlushInterval)
+	defer ticker.Stop()
+
+	buf := make([]*models.Message, 0, ingestBatchSize)
+	flush := func() {
+		if len(buf) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := m.repos.Messages.CreateBatch(ctx, buf); err != nil {
+			m.log.Warn().Err(err).Int("batch", len(buf)).
+				Msg("batch insert failed, retrying row by row")
+			for _, msg := range buf {
+				if err := m.repos.Messages.Create(ctx, msg); err != nil {
+					m.log.Error().Err(err).Str("message_id", msg.MessageID).Msg("store message")
+				}
+			}
+		}
+		buf = buf[:0]
+	}
+
+	for {
+		select {
+		case msg, ok := <-m.ingestCh:
+			if !ok {
+				flush()
+				return
+			}
+			metrics.IngestQueueDepth.Set(float64(len(m.ingestCh)))
+			buf = append(buf, msg)
+			if len(buf) >= ingestBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+func TestEnqueueMessageBuffers(t *testing.T) {
+	m := &Manager{ingestCh: make(chan *models.Message, 4)}
+	first := &models.Message{MessageID: "m1"}
+	second := &models.Message{MessageID: "m2"}
+	m.enqueueMessage(context.Background(), first)
+	m.enqueueMessage(context.Background(), second)
+
+	if got := len(m.ingestCh); got != 2 {
+		t.Fatalf("queue depth = %d, want 2", got)
+	}
+	if got := <-m.ingestCh; got != first {
+		t.Errorf("dequeued %q first, want m1", got.MessageID)
+	}
+	if got := <-m.ingestCh; got != second {
+		t.Errorf("dequeued %q second, want m2", got.MessageID)
+	}
+}
+
+func TestRunIngestStopsOnClose(t *testing.T) {
+	m := &Manager{ingestCh: make(chan *models.Message, 4)}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.runIngest()
+	}()
+	close(m.ingestCh)
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("runIngest did not stop after ingestCh closed")
+	}
+}
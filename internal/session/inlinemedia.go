@@ -0,0 +1,70 @@
+package session
+
+import (
+	"encoding/base64"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// attachInlineMedia adds a "media" block to the message webhook payload
+// when the session opts in. Blobs up to InlineMediaMaxBytes are
+// downloaded and embedded as base64 for consumers that cannot fetch
+// URLs (serverless functions); larger blobs get the metadata and the CDN
+// URL only. Download failures leave the payload without the block.
+func (m *Manager) attachInlineMedia(c *Client, e *events.Message, payload map[string]any) {
+	limit := c.Session.InlineMediaMaxBytes
+	if limit <= 0 {
+		return
+	}
+	part := downloadableMediaPart(e.Message)
+	if part == nil {
+		return
+	}
+	media := map[string]any{
+		"mime_type":  part.GetMimetype(),
+		"size_bytes": part.GetFileLength(),
+	}
+	if part.GetFileLength() > uint64(limit) {
+		media["url"] = part.GetURL()
+		payload["media"] = media
+		return
+	}
+	data, err := c.WA.Download(part)
+	if err != nil {
+		m.log.Warn().Err(err).Str("session_id", c.Session.ID).
+			Msg("download media for inline webhook")
+		return
+	}
+	media["base64"] = base64.StdEncoding.EncodeToString(data)
+	payload["media"] = media
+}
+
+// mediaPart is the downloadable slice of a media message; every media
+// type in waE2E satisfies it.
+type mediaPart interface {
+	whatsmeow.DownloadableMessage
+	GetMimetype() string
+	GetFileLength() uint64
+	GetURL() string
+}
+
+// downloadableMediaPart returns the downloadable part of a media
+// message, nil for text and other non-media types.
+func downloadableMediaPart(msg *waE2E.Message) mediaPart {
+	switch {
+	case msg.GetImageMessage() != nil:
+		return msg.GetImageMessage()
+	case msg.GetVideoMessage() != nil:
+		return msg.GetVideoMessage()
+	case msg.GetAudioMessage() != nil:
+		return msg.GetAudioMessage()
+	case msg.GetDocumentMessage() != nil:
+		return msg.GetDocumentMessage()
+	case msg.GetStickerMessage() != nil:
+		return msg.GetStickerMessage()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,90 @@
+package session
+
+import (
+	"context"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// IntegrityIssue is one session whose stored device credentials are
+// gone from the whatsmeow store.
+type IntegrityIssue struct {
+	SessionID string `json:"session_id"`
+	DeviceJID string `json:"device_jid"`
+}
+
+// IntegrityReport summarizes a cross-check of the sessions table
+// against the whatsmeow device store.
+type IntegrityReport struct {
+	// MissingDevices lists sessions pointing at credentials that no
+	// longer exist; repairing marks them logged_out so they re-pair
+	// instead of crash-looping on connect.
+	MissingDevices []IntegrityIssue `json:"missing_devices"`
+	// OrphanDevices lists device JIDs the store holds with no owning
+	// session, for the operator to clean up.
+	OrphanDevices []string `json:"orphan_devices"`
+	// Repaired reports whether the detected issues were repaired in
+	// this run or merely reported.
+	Repaired bool `json:"repaired"`
+}
+
+// VerifyStoreIntegrity cross-checks the sessions table against the
+// whatsmeow device store and returns what it found. With repair set,
+// sessions referencing missing credentials are downgraded to
+// logged_out; without it the store is left untouched, so the admin
+// endpoint can report without mutating. Orphaned devices are only ever
+// reported.
+func (m *Manager) VerifyStoreIntegrity(ctx context.Context, repair bool) (*IntegrityReport, error) {
+	report := &IntegrityReport{
+		MissingDevices: []IntegrityIssue{},
+		OrphanDevices:  []string{},
+		Repaired:       repair,
+	}
+	devices, err := m.container.GetAllDevices()
+	if err != nil {
+		return nil, err
+	}
+	deviceJIDs := make(map[string]bool, len(devices))
+	for _, d := range devices {
+		if d.ID != nil {
+			deviceJIDs[d.ID.String()] = true
+		}
+	}
+
+	sessions, err := m.repos.Sessions.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	claimed := make(map[string]bool, len(sessions))
+	for _, s := range sessions {
+		if s.DeviceJID == "" {
+			continue
+		}
+		claimed[s.DeviceJID] = true
+		if !deviceJIDs[s.DeviceJID] {
+			report.MissingDevices = append(report.MissingDevices, IntegrityIssue{
+				SessionID: s.ID,
+				DeviceJID: s.DeviceJID,
+			})
+			if !repair {
+				continue
+			}
+			m.log.Warn().Str("session_id", s.ID).Str("device_jid", s.DeviceJID).
+				Msg("session references missing device credentials, marking logged out")
+			s.DeviceJID = ""
+			s.Status = models.SessionStatusLoggedOut
+			if err := m.repos.Sessions.Update(ctx, s); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for jid := range deviceJIDs {
+		if !claimed[jid] {
+			m.log.Warn().Str("device_jid", jid).
+				Msg("whatsmeow store holds credentials with no owning session")
+			report.OrphanDevices = append(report.OrphanDevices, jid)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,73 @@
+package session
+
+import (
+	"context"
+
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// Logout unpairs the session from WhatsApp and wipes its credentials
+// from the whatsmeow store. The session record itself survives, so the
+// same session id can be re-paired later. When the socket is up the
+// server is told about the unpair; otherwise the local credentials are
+// deleted directly.
+func (m *Manager) Logout(ctx context.Context, id string) error {
+	c, ok := m.Get(id)
+	if !ok {
+		return ErrSessionNotFound
+	}
+	c.lifecycle.Lock()
+	defer c.lifecycle.Unlock()
+	return m.logoutLocked(ctx, c)
+}
+
+// logoutLocked is Logout with the client's lifecycle mutex held.
+func (m *Manager) logoutLocked(ctx context.Context, c *Client) error {
+	if c.WA != nil && c.WA.IsConnected() && c.WA.Store.ID != nil {
+		if err := c.WA.Logout(); err != nil {
+			// The server-side unpair failed; fall through and wipe the
+			// local credentials anyway so the session cannot reconnect
+			// with stale keys.
+			m.log.Warn().Err(err).Str("session_id", c.Session.ID).Msg("server logout failed, wiping local store")
+			c.WA.Disconnect()
+			if err := m.wipeDevice(ctx, c); err != nil {
+				return err
+			}
+		}
+	} else {
+		if c.WA != nil {
+			c.WA.Disconnect()
+		}
+		if err := m.wipeDevice(ctx, c); err != nil {
+			return err
+		}
+	}
+
+	c.WA = nil
+	c.Session.DeviceJID = ""
+	c.Session.PairedAt = nil
+	c.Session.Status = models.SessionStatusLoggedOut
+	if err := m.repos.Sessions.Update(ctx, c.Session); err != nil {
+		return err
+	}
+	m.Dispatch(ctx, c.Session, models.EventLoggedOut, map[string]any{"reason": "api_logout"})
+	return nil
+}
+
+// wipeDevice deletes the stored whatsmeow device for the session, if any.
+func (m *Manager) wipeDevice(ctx context.Context, c *Client) error {
+	if c.Session.DeviceJID == "" {
+		return nil
+	}
+	jid, err := types.ParseJID(c.Session.DeviceJID)
+	if err != nil {
+		return err
+	}
+	device, err := m.container.GetDevice(jid)
+	if err != nil || device == nil {
+		return err
+	}
+	return m.container.DeleteDevice(device)
+}
@@ -0,0 +1,80 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// remindEvery is the minimum gap between repeat reminders for the same
+// session, so a short sweep interval does not spam the webhook.
+const remindEvery = 24 * time.Hour
+
+// RunMaintenanceReminders sweeps sessions on the configured interval and
+// emits a maintenance_reminder event when a pairing has been live longer
+// than the configured maximum, or when a session sits logged out and
+// needs re-pairing. The events give operators a window to schedule
+// re-pairing before WhatsApp forces a surprise logout.
+func (m *Manager) RunMaintenanceReminders(ctx context.Context, cfg config.MaintenanceConfig) {
+	if cfg.MaxPairedAgeDays <= 0 {
+		return
+	}
+	lastReminded := make(map[string]time.Time)
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.sweepMaintenance(ctx, cfg, lastReminded)
+		}
+	}
+}
+
+func (m *Manager) sweepMaintenance(ctx context.Context, cfg config.MaintenanceConfig, lastReminded map[string]time.Time) {
+	sessions, err := m.repos.Sessions.List(ctx)
+	if err != nil {
+		m.log.Error().Err(err).Msg("list sessions for maintenance sweep")
+		return
+	}
+	now := time.Now().UTC()
+	for _, s := range sessions {
+		if time.Since(lastReminded[s.ID]) < remindEvery {
+			continue
+		}
+		payload := m.maintenanceDue(s, cfg, now)
+		if payload == nil {
+			continue
+		}
+		lastReminded[s.ID] = now
+		m.Dispatch(ctx, s, models.EventMaintenance, payload)
+		m.NotifyAdmin("maintenance_reminder", s.ID, payload)
+	}
+}
+
+// maintenanceDue decides whether a session needs a reminder and builds
+// the event payload; nil means the session is healthy.
+func (m *Manager) maintenanceDue(s *models.Session, cfg config.MaintenanceConfig, now time.Time) map[string]any {
+	if s.Status == models.SessionStatusLoggedOut {
+		return map[string]any{
+			"reason": "logged_out",
+			"detail": "session credentials were invalidated and need re-pairing",
+		}
+	}
+	if s.PairedAt == nil {
+		return nil
+	}
+	ageDays := int(now.Sub(*s.PairedAt).Hours() / 24)
+	if ageDays < cfg.MaxPairedAgeDays {
+		return nil
+	}
+	return map[string]any{
+		"reason":       "paired_age",
+		"paired_at":    s.PairedAt,
+		"age_days":     ageDays,
+		"max_age_days": cfg.MaxPairedAgeDays,
+	}
+}
@@ -0,0 +1,368 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/store/sqlstore"
+	"go.mau.fi/whatsmeow/types"
+
+	"github.com/fgsgalvaosz/zemeow/internal/enrich"
+	"github.com/fgsgalvaosz/zemeow/internal/extract"
+	"github.com/fgsgalvaosz/zemeow/internal/features"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/policy"
+	"github.com/fgsgalvaosz/zemeow/internal/privacy"
+	"github.com/fgsgalvaosz/zemeow/internal/queue"
+	"github.com/fgsgalvaosz/zemeow/internal/repository"
+	"github.com/fgsgalvaosz/zemeow/internal/scan"
+	"github.com/fgsgalvaosz/zemeow/internal/transcribe"
+	"github.com/fgsgalvaosz/zemeow/internal/translate"
+	"github.com/fgsgalvaosz/zemeow/internal/webhook"
+)
+
+// Errors returned by Manager operations.
+var (
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrSessionNotConnected = errors.New("session not connected")
+	ErrAlreadyConnected    = errors.New("session already connected")
+)
+
+// Client pairs a session record with its live whatsmeow client. WA is nil
+// while the session is not connected.
+type Client struct {
+	Session *models.Session
+	WA      *whatsmeow.Client
+
+	// lifecycle serializes connect/disconnect/pause/logout transitions so
+	// concurrent API calls cannot interleave partial state changes.
+	lifecycle sync.Mutex
+
+	presence     presenceState
+	chatPresence chatPresenceState
+	groups       groupCache
+	newsletters  newsletterCache
+	away         awayState
+	heartbeat    heartbeatState
+	breaker      breaker
+	uploads      uploadCache
+	dedupe       dedupeState
+	filters      filterState
+	sendq        sendQueue
+	logs         logState
+	quotaAlert   quotaAlertState
+}
+
+// Manager owns the lifecycle of all whatsmeow clients and fans incoming
+// events out to the event store, the session webhook and the queue.
+type Manager struct {
+	mu        sync.RWMutex
+	clients   map[string]*Client
+	container *sqlstore.Container
+
+	repos       *repository.Set
+	deliverer   *webhook.Deliverer
+	publisher   *queue.Publisher
+	features    *features.Service
+	redactor    *privacy.Redactor
+	translator  *translate.Translator
+	scanner     *scan.Scanner
+	extractor   *extract.Extractor
+	transcriber *transcribe.Transcriber
+	policies    *policy.Engine
+	ingestCh    chan *models.Message
+	enrichers   *enrich.Registry
+	watchers    watchRegistry
+	log         zerolog.Logger
+}
+
+// NewManager creates a Manager. The whatsmeow container shares the
+// application's Postgres database.
+func NewManager(container *sqlstore.Container, repos *repository.Set,
+	deliverer *webhook.Deliverer, publisher *queue.Publisher,
+	featureSvc *features.Service, redactor *privacy.Redactor,
+	translator *translate.Translator, scanner *scan.Scanner,
+	extractor *extract.Extractor, transcriber *transcribe.Transcriber,
+	policies *policy.Engine, log zerolog.Logger) *Manager {
+	m := &Manager{
+		clients:     make(map[string]*Client),
+		container:   container,
+		repos:       repos,
+		deliverer:   deliverer,
+		publisher:   publisher,
+		features:    featureSvc,
+		redactor:    redactor,
+		translator:  translator,
+		scanner:     scanner,
+		extractor:   extractor,
+		transcriber: transcriber,
+		policies:    policies,
+		ingestCh:    make(chan *models.Message, ingestQueueDepth),
+		enrichers:   enrich.NewRegistry(),
+		log:         log.With().Str("component", "session").Logger(),
+	}
+	go m.runIngest()
+	return m
+}
+
+// Enrichers exposes the tagging pipeline so deployments can register
+// additional enrichers before connecting sessions.
+func (m *Manager) Enrichers() *enrich.Registry {
+	return m.enrichers
+}
+
+// Get returns the live client for a session id.
+func (m *Manager) Get(id string) (*Client, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.clients[id]
+	return c, ok
+}
+
+// Register adds a session to the manager without connecting it. Called on
+// startup for every persisted session and on session creation.
+func (m *Manager) Register(s *models.Session) *Client {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := &Client{Session: s}
+	m.clients[s.ID] = c
+	return c
+}
+
+// Unregister drops a session from the manager, disconnecting it first.
+func (m *Manager) Unregister(id string) {
+	m.mu.Lock()
+	c, ok := m.clients[id]
+	delete(m.clients, id)
+	m.mu.Unlock()
+	if ok && c.WA != nil {
+		c.WA.Disconnect()
+	}
+}
+
+// Connect attaches a whatsmeow client to the session and connects the
+// socket. For sessions without stored credentials the QR channel is
+// surfaced through qrcode events.
+func (m *Manager) Connect(ctx context.Context, id string) error {
+	c, ok := m.Get(id)
+	if !ok {
+		return ErrSessionNotFound
+	}
+	c.lifecycle.Lock()
+	defer c.lifecycle.Unlock()
+	return m.connectLocked(ctx, c)
+}
+
+// connectLocked is Connect with the client's lifecycle mutex held.
+func (m *Manager) connectLocked(ctx context.Context, c *Client) error {
+	if c.WA != nil && c.WA.IsConnected() {
+		return ErrAlreadyConnected
+	}
+	if c.Session.Status == models.SessionStatusPaused {
+		return ErrSessionPaused
+	}
+
+	device, err := m.deviceForSession(ctx, c.Session)
+	if err != nil {
+		return err
+	}
+	wa := whatsmeow.NewClient(device, sessionLogger{c: c})
+	wa.AddEventHandler(func(evt any) { m.handleEvent(c, evt) })
+	c.WA = wa
+
+	if wa.Store.ID == nil {
+		qrChan, err := wa.GetQRChannel(ctx)
+		if err != nil {
+			return fmt.Errorf("get qr channel: %w", err)
+		}
+		go m.pumpQR(c, qrChan)
+	}
+
+	if err := wa.Connect(); err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	m.setStatus(ctx, c, models.SessionStatusConnecting)
+	return nil
+}
+
+// Disconnect closes the socket but keeps credentials intact.
+func (m *Manager) Disconnect(ctx context.Context, id string) error {
+	c, ok := m.Get(id)
+	if !ok {
+		return ErrSessionNotFound
+	}
+	c.lifecycle.Lock()
+	defer c.lifecycle.Unlock()
+	if c.WA == nil {
+		return ErrSessionNotConnected
+	}
+	c.WA.Disconnect()
+	c.WA = nil
+	m.setStatus(ctx, c, models.SessionStatusDisconnected)
+	return nil
+}
+
+func (m *Manager) deviceForSession(ctx context.Context, s *models.Session) (*store.Device, error) {
+	if s.DeviceJID != "" {
+		jid, err := types.ParseJID(s.DeviceJID)
+		if err != nil {
+			return nil, fmt.Errorf("parse device jid: %w", err)
+		}
+		device, err := m.container.GetDevice(jid)
+		if err != nil {
+			return nil, fmt.Errorf("get device: %w", err)
+		}
+		if device != nil {
+			return device, nil
+		}
+	}
+	return m.container.NewDevice(), nil
+}
+
+func (m *Manager) pumpQR(c *Client, qrChan <-chan whatsmeow.QRChannelItem) {
+	for item := range qrChan {
+		switch item.Event {
+		case "code":
+			m.recordTimeline(context.Background(), c, models.SessionEventQRGenerated, "")
+			m.Dispatch(context.Background(), c.Session, models.EventQRCode,
+				map[string]any{"code": item.Code, "timeout": item.Timeout.Seconds()})
+		case "success":
+			// PairSuccess below persists the JID; nothing to do here.
+		default:
+			m.log.Debug().Str("session_id", c.Session.ID).
+				Str("qr_event", item.Event).Msg("qr channel event")
+		}
+	}
+}
+
+// recordTimeline appends to the session's lifecycle debugging timeline.
+// Best effort; failures only log.
+func (m *Manager) recordTimeline(ctx context.Context, c *Client, kind, detail string) {
+	if err := m.repos.Timeline.Record(ctx, c.Session.ID, kind, detail); err != nil {
+		m.log.Error().Err(err).Str("session_id", c.Session.ID).Msg("record timeline event")
+	}
+}
+
+func (m *Manager) setStatus(ctx context.Context, c *Client, status string) {
+	c.Session.Status = status
+	if err := m.repos.Sessions.UpdateStatus(ctx, c.Session.ID, status); err != nil {
+		m.log.Error().Err(err).Str("session_id", c.Session.ID).Msg("persist status")
+	}
+}
+
+// Dispatch persists an event and fans it out to the webhook and the queue
+// according to the session subscription.
+func (m *Manager) Dispatch(ctx context.Context, s *models.Session, eventType string, payload any) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		m.log.Error().Err(err).Str("event", eventType).Msg("marshal event payload")
+		return
+	}
+	now := time.Now().UTC()
+	m.notifyWatchers(s.ID, WatchEvent{Type: eventType, Payload: body, Timestamp: now})
+
+	if err := m.repos.Events.Create(ctx, &models.StoredEvent{
+		SessionID: s.ID,
+		Type:      eventType,
+		Payload:   body,
+		Timestamp: now,
+	}); err != nil {
+		m.log.Error().Err(err).Str("event", eventType).Msg("store event")
+	}
+
+	if s.WantsEvent(eventType) {
+		env := &webhook.Envelope{SessionID: s.ID, Event: eventType, Timestamp: now, Payload: body}
+		go func() {
+			dctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			if err := m.deliverer.Deliver(dctx, s, env); err != nil {
+				m.log.Error().Err(err).Str("event", eventType).
+					Str("session_id", s.ID).Msg("webhook delivery exhausted retries")
+				m.NotifyAdmin("webhook_failing", s.ID, map[string]any{
+					"event": eventType,
+					"error": err.Error(),
+				})
+				return
+			}
+			if err := m.repos.Usage.AddWebhookDeliveries(dctx, s.ID, 1); err != nil {
+				m.log.Error().Err(err).Str("session_id", s.ID).Msg("meter webhook delivery")
+			}
+		}()
+	}
+
+	if err := m.publisher.Publish(ctx, s.ID, eventType, payload); err != nil {
+		m.log.Error().Err(err).Str("event", eventType).Msg("queue publish")
+	}
+}
+
+// NotifyAdmin delivers a platform-level event to the operator's admin
+// webhook in the background. A no-op when none is configured.
+func (m *Manager) NotifyAdmin(eventType, sessionID string, payload any) {
+	if !m.deliverer.AdminEnabled() {
+		return
+	}
+	env := &webhook.AdminEnvelope{
+		Event:     eventType,
+		SessionID: sessionID,
+		Timestamp: time.Now().UTC(),
+		Payload:   payload,
+	}
+	go func() {
+		dctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := m.deliverer.DeliverAdmin(dctx, env); err != nil {
+			m.log.Error().Err(err).Str("event", eventType).
+				Msg("admin webhook delivery exhausted retries")
+		}
+	}()
+}
+
+// TestWebhook sends a synthetic event of the given type to the session
+// webhook, bypassing the subscription filter and the event store, and
+// reports how the receiver answered.
+func (m *Manager) TestWebhook(ctx context.Context, s *models.Session, eventType string, payload any) (*webhook.TestResult, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal test payload: %w", err)
+	}
+	env := &webhook.Envelope{
+		SessionID: s.ID,
+		Event:     eventType,
+		Timestamp: time.Now().UTC(),
+		Payload:   body,
+	}
+	return m.deliverer.TestFire(ctx, s, env)
+}
+
+// VerifyWebhook runs the challenge handshake against a webhook URL
+// before it is activated on a session.
+func (m *Manager) VerifyWebhook(ctx context.Context, url, egressProxyURL string) error {
+	return m.deliverer.Verify(ctx, url, egressProxyURL)
+}
+
+// ConnectedCount reports how many registered sessions are currently
+// connected.
+func (m *Manager) ConnectedCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	n := 0
+	for _, c := range m.clients {
+		if c.Session.Status == models.SessionStatusConnected {
+			n++
+		}
+	}
+	return n
+}
+
+// WebhookAvgLatency reports the average latency of recent webhook
+// deliveries across all sessions.
+func (m *Manager) WebhookAvgLatency() time.Duration {
+	return m.deliverer.AvgLatency()
+}
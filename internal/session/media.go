@@ -0,0 +1,132 @@
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// uploadReuseTTL bounds how long an upload is reused before the blob is
+// proactively re-uploaded. WhatsApp expires uploaded media server-side;
+// staying well under that window avoids handing out dead MediaKeys.
+const uploadReuseTTL = 20 * time.Hour
+
+// uploadCache remembers recent media uploads per session, keyed by the
+// SHA-256 of the plaintext, so resending the same blob reuses the upload.
+type uploadCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedUpload
+}
+
+type cachedUpload struct {
+	resp       whatsmeow.UploadResponse
+	uploadedAt time.Time
+}
+
+func (u *uploadCache) get(key string) (whatsmeow.UploadResponse, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	entry, ok := u.entries[key]
+	if !ok || time.Since(entry.uploadedAt) > uploadReuseTTL {
+		return whatsmeow.UploadResponse{}, false
+	}
+	return entry.resp, true
+}
+
+func (u *uploadCache) put(key string, resp whatsmeow.UploadResponse) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.entries == nil {
+		u.entries = make(map[string]cachedUpload)
+	}
+	u.entries[key] = cachedUpload{resp: resp, uploadedAt: time.Now()}
+}
+
+func (u *uploadCache) invalidate(key string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.entries, key)
+}
+
+// uploadMedia uploads data for the given media type, reusing a fresh
+// cached upload of the same blob when available.
+func (m *Manager) uploadMedia(ctx context.Context, c *Client, data []byte, mt whatsmeow.MediaType) (whatsmeow.UploadResponse, string, error) {
+	sum := sha256.Sum256(data)
+	key := string(mt) + ":" + hex.EncodeToString(sum[:])
+	if resp, ok := c.uploads.get(key); ok {
+		return resp, key, nil
+	}
+	resp, err := c.WA.Upload(ctx, data, mt)
+	if err != nil {
+		return whatsmeow.UploadResponse{}, key, err
+	}
+	c.uploads.put(key, resp)
+	return resp, key, nil
+}
+
+// SendImage uploads (or reuses) the image blob and sends it. When the
+// send fails because the cached upload's MediaKey expired server-side,
+// the blob is re-uploaded once and the send retried.
+func (m *Manager) SendImage(ctx context.Context, c *Client, to types.JID, data []byte, mimeType, caption string) (whatsmeow.SendResponse, error) {
+	if err := m.EnsureSendable(c); err != nil {
+		return whatsmeow.SendResponse{}, err
+	}
+	caption, err := m.policies.Apply(ctx, c.Session.ID, caption)
+	if err != nil {
+		return whatsmeow.SendResponse{}, err
+	}
+	if c.Session.Sandbox {
+		// No socket means no upload; Send echoes the message locally.
+		return m.Send(ctx, c, to, imageMessage(whatsmeow.UploadResponse{}, data, mimeType, caption))
+	}
+
+	upload, key, err := m.uploadMedia(ctx, c, data, whatsmeow.MediaImage)
+	if err != nil {
+		return whatsmeow.SendResponse{}, err
+	}
+	resp, err := m.Send(ctx, c, to, imageMessage(upload, data, mimeType, caption))
+	if err != nil && isMediaExpiredError(err) {
+		m.log.Warn().Str("session_id", c.Session.ID).
+			Msg("cached media upload expired, re-uploading")
+		c.uploads.invalidate(key)
+		upload, _, err = m.uploadMedia(ctx, c, data, whatsmeow.MediaImage)
+		if err != nil {
+			return whatsmeow.SendResponse{}, err
+		}
+		resp, err = m.Send(ctx, c, to, imageMessage(upload, data, mimeType, caption))
+	}
+	return resp, err
+}
+
+func imageMessage(upload whatsmeow.UploadResponse, data []byte, mimeType, caption string) *waE2E.Message {
+	return &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+		URL:           proto.String(upload.URL),
+		DirectPath:    proto.String(upload.DirectPath),
+		MediaKey:      upload.MediaKey,
+		FileEncSHA256: upload.FileEncSHA256,
+		FileSHA256:    upload.FileSHA256,
+		FileLength:    proto.Uint64(uint64(len(data))),
+		Mimetype:      proto.String(mimeType),
+		Caption:       proto.String(caption),
+	}}
+}
+
+// isMediaExpiredError detects server rejections caused by an expired or
+// unknown media upload reference.
+func isMediaExpiredError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "media") &&
+		(strings.Contains(msg, "expired") || strings.Contains(msg, "not found") ||
+			strings.Contains(msg, "410") || strings.Contains(msg, "404"))
+}
@@ -0,0 +1,107 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// moderateGroupMessage applies the session's group moderation rules to
+// an inbound group message: matching messages are revoked and logged,
+// and repeat offenders are warned or removed depending on the configured
+// thresholds. Failures are logged but never block the event pipeline.
+func (m *Manager) moderateGroupMessage(ctx context.Context, c *Client, e *events.Message, msg *models.Message) {
+	mod := c.Session.GroupModeration
+	if mod == nil || msg.FromMe || !e.Info.IsGroup || !mod.Applies(msg.ChatJID) {
+		return
+	}
+	if !m.matchesModeration(mod, msg.Body) {
+		return
+	}
+
+	// Patterns are validated at set time; revoke the offending message
+	// first so it disappears even when the follow-up actions fail.
+	if _, err := m.Send(ctx, c, e.Info.Chat, c.WA.BuildRevoke(e.Info.Chat, e.Info.Sender, e.Info.ID)); err != nil {
+		m.log.Error().Err(err).Str("session_id", c.Session.ID).
+			Str("group", m.redactor.JID(msg.ChatJID)).Msg("moderation revoke")
+		return
+	}
+	m.recordModeration(ctx, c, msg, models.ModerationActionDelete, "matched moderation pattern")
+
+	offenses, err := m.repos.ModLog.CountOffenses(ctx, c.Session.ID, msg.ChatJID, msg.SenderJID)
+	if err != nil {
+		m.log.Error().Err(err).Str("session_id", c.Session.ID).Msg("count offenses")
+		return
+	}
+
+	switch {
+	case mod.KickThreshold > 0 && offenses >= mod.KickThreshold:
+		target := e.Info.Sender.ToNonAD()
+		if _, err := c.WA.UpdateGroupParticipants(e.Info.Chat,
+			[]types.JID{target}, whatsmeow.ParticipantChangeRemove); err != nil {
+			m.log.Error().Err(err).Str("session_id", c.Session.ID).
+				Str("group", m.redactor.JID(msg.ChatJID)).Msg("moderation kick")
+			return
+		}
+		m.recordModeration(ctx, c, msg, models.ModerationActionKick,
+			fmt.Sprintf("removed after %d offenses", offenses))
+
+	case mod.WarnThreshold > 0 && offenses >= mod.WarnThreshold:
+		warning := fmt.Sprintf("@%s your message was removed for violating the group rules (%d offenses).",
+			e.Info.Sender.User, offenses)
+		if _, err := m.SendText(ctx, c, e.Info.Chat, warning); err != nil {
+			m.log.Error().Err(err).Str("session_id", c.Session.ID).
+				Str("group", m.redactor.JID(msg.ChatJID)).Msg("moderation warn")
+			return
+		}
+		m.recordModeration(ctx, c, msg, models.ModerationActionWarn,
+			fmt.Sprintf("warned after %d offenses", offenses))
+	}
+}
+
+// matchesModeration reports whether the body trips any moderation
+// pattern.
+func (m *Manager) matchesModeration(mod *models.GroupModeration, body string) bool {
+	if body == "" {
+		return false
+	}
+	for _, p := range mod.Patterns {
+		re, err := regexp.Compile("(?i)" + p)
+		if err != nil {
+			continue
+		}
+		if re.MatchString(body) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordModeration appends to the moderation log and emits the
+// moderation_action event.
+func (m *Manager) recordModeration(ctx context.Context, c *Client, msg *models.Message, action, reason string) {
+	entry := &models.ModerationAction{
+		SessionID: c.Session.ID,
+		GroupJID:  msg.ChatJID,
+		TargetJID: msg.SenderJID,
+		MessageID: msg.MessageID,
+		Action:    action,
+		Reason:    reason,
+	}
+	if err := m.repos.ModLog.Log(ctx, entry); err != nil {
+		m.log.Error().Err(err).Str("session_id", c.Session.ID).Msg("log moderation action")
+	}
+	m.Dispatch(ctx, c.Session, models.EventModeration, map[string]any{
+		"group_jid":  msg.ChatJID,
+		"target_jid": msg.SenderJID,
+		"message_id": msg.MessageID,
+		"action":     action,
+		"reason":     reason,
+	})
+}
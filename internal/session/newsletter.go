@@ -0,0 +1,81 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// newsletterCacheTTL is how long cached channel metadata is served
+// before a lookup goes back to the server.
+const newsletterCacheTTL = 30 * time.Minute
+
+// newsletterCache holds per-session channel metadata keyed by channel JID.
+type newsletterCache struct {
+	mu      sync.RWMutex
+	entries map[types.JID]*newsletterCacheEntry
+}
+
+type newsletterCacheEntry struct {
+	meta      *types.NewsletterMetadata
+	fetchedAt time.Time
+}
+
+// handleNewsletterMessage persists a message from a followed channel and
+// dispatches it as a newsletter event. Channels are broadcast-only, so
+// the conversation, moderation and auto-reply paths do not apply.
+func (m *Manager) handleNewsletterMessage(ctx context.Context, c *Client, e *events.Message, msg *models.Message) {
+	m.enqueueMessage(ctx, msg)
+
+	payload := map[string]any{
+		"message_id":  msg.MessageID,
+		"channel_jid": msg.ChatJID,
+		"type":        msg.Type,
+		"body":        msg.Body,
+		"timestamp":   msg.Timestamp,
+	}
+	if meta := m.newsletterMeta(c, e.Info.Chat); meta != nil {
+		payload["channel"] = map[string]any{
+			"jid":         meta.ID.String(),
+			"name":        meta.ThreadMeta.Name.Text,
+			"description": meta.ThreadMeta.Description.Text,
+			"subscribers": meta.ThreadMeta.SubscriberCount,
+		}
+	}
+	m.Dispatch(ctx, c.Session, models.EventNewsletterMessage, payload)
+}
+
+// newsletterMeta returns channel metadata, served from the cache while
+// fresh. Lookup failures only log: the message event still goes out,
+// just without the channel block.
+func (m *Manager) newsletterMeta(c *Client, jid types.JID) *types.NewsletterMetadata {
+	c.newsletters.mu.RLock()
+	entry, ok := c.newsletters.entries[jid]
+	c.newsletters.mu.RUnlock()
+	if ok && time.Since(entry.fetchedAt) < newsletterCacheTTL {
+		return entry.meta
+	}
+
+	meta, err := c.WA.GetNewsletterInfo(jid)
+	if err != nil {
+		m.log.Warn().Err(err).Str("session_id", c.Session.ID).
+			Str("channel_jid", jid.String()).Msg("fetch newsletter metadata")
+		if ok {
+			return entry.meta
+		}
+		return nil
+	}
+
+	c.newsletters.mu.Lock()
+	if c.newsletters.entries == nil {
+		c.newsletters.entries = make(map[types.JID]*newsletterCacheEntry)
+	}
+	c.newsletters.entries[jid] = &newsletterCacheEntry{meta: meta, fetchedAt: time.Now()}
+	c.newsletters.mu.Unlock()
+	return meta
+}
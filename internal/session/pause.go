@@ -0,0 +1,70 @@
+package session
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// ErrSessionPaused is returned by send paths while a session is paused.
+var ErrSessionPaused = errors.New("session is paused")
+
+// Pause disconnects the socket but keeps credentials and the store
+// intact, and marks the session so send paths refuse with a PAUSED code
+// until Resume is called. Pausing an already paused session is a no-op.
+func (m *Manager) Pause(ctx context.Context, id string) error {
+	c, ok := m.Get(id)
+	if !ok {
+		return ErrSessionNotFound
+	}
+	c.lifecycle.Lock()
+	defer c.lifecycle.Unlock()
+	if c.Session.Status == models.SessionStatusPaused {
+		return nil
+	}
+	m.stopPresenceHeartbeat(c)
+	if c.WA != nil {
+		c.WA.Disconnect()
+		c.WA = nil
+	}
+	m.setStatus(ctx, c, models.SessionStatusPaused)
+	return nil
+}
+
+// Resume lifts a pause and reconnects using the stored credentials.
+func (m *Manager) Resume(ctx context.Context, id string) error {
+	c, ok := m.Get(id)
+	if !ok {
+		return ErrSessionNotFound
+	}
+	c.lifecycle.Lock()
+	defer c.lifecycle.Unlock()
+	if c.Session.Status != models.SessionStatusPaused {
+		return ErrSessionNotPaused
+	}
+	m.setStatus(ctx, c, models.SessionStatusDisconnected)
+	return m.connectLocked(ctx, c)
+}
+
+// ErrSessionNotPaused is returned when resuming a session that is not
+// paused.
+var ErrSessionNotPaused = errors.New("session is not paused")
+
+// EnsureSendable is the guard every send path calls before hitting the
+// socket. It distinguishes "paused" from plain "not connected" so the API
+// can surface the dedicated PAUSED error code.
+func (m *Manager) EnsureSendable(c *Client) error {
+	if c.Session.Status == models.SessionStatusPaused {
+		return ErrSessionPaused
+	}
+	// Sandbox sessions are always sendable: their sends never reach the
+	// socket, so no connection is required.
+	if c.Session.Sandbox {
+		return nil
+	}
+	if c.WA == nil || !c.WA.IsConnected() {
+		return ErrSessionNotConnected
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package session
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fgsgalvaosz/zemeow/internal/metrics"
+)
+
+// Priority orders outbound sends on one session. High is for OTP and
+// other transactional traffic, bulk for campaign fan-outs; normal is the
+// default for interactive sends.
+type Priority int
+
+// Send priorities, highest first.
+const (
+	PriorityHigh Priority = iota
+	PriorityNormal
+	PriorityBulk
+)
+
+var priorityNames = [...]string{"high", "normal", "bulk"}
+
+func (p Priority) String() string {
+	if p < PriorityHigh || p > PriorityBulk {
+		return "normal"
+	}
+	return priorityNames[p]
+}
+
+// ParsePriority maps the API's priority names onto Priority; ok is false
+// for unknown names.
+func ParsePriority(s string) (Priority, bool) {
+	switch s {
+	case "high":
+		return PriorityHigh, true
+	case "", "normal":
+		return PriorityNormal, true
+	case "bulk":
+		return PriorityBulk, true
+	}
+	return PriorityNormal, false
+}
+
+type priorityCtxKey struct{}
+
+// WithPriority tags a context with a send priority. Sends without a tag
+// run at normal priority.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityCtxKey{}, p)
+}
+
+func priorityFrom(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityCtxKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityNormal
+}
+
+// sendQueue serializes a session's outbound sends and releases waiters
+// in priority order, so bulk fan-outs cannot starve interactive traffic
+// sharing the session.
+type sendQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	waiting [3]int
+	busy    bool
+}
+
+// enter blocks until the send slot is free and no higher-priority sender
+// is waiting for it.
+func (q *sendQueue) enter(sessionID string, p Priority) {
+	q.mu.Lock()
+	if q.cond == nil {
+		q.cond = sync.NewCond(&q.mu)
+	}
+	q.waiting[p]++
+	metrics.SendQueueDepth.WithLabelValues(sessionID, p.String()).Set(float64(q.waiting[p]))
+	for q.busy || q.higherWaiting(p) {
+		q.cond.Wait()
+	}
+	q.waiting[p]--
+	q.busy = true
+	metrics.SendQueueDepth.WithLabelValues(sessionID, p.String()).Set(float64(q.waiting[p]))
+	q.mu.Unlock()
+}
+
+// leave frees the send slot and wakes waiters.
+func (q *sendQueue) leave() {
+	q.mu.Lock()
+	q.busy = false
+	q.mu.Unlock()
+	if q.cond != nil {
+		q.cond.Broadcast()
+	}
+}
+
+// higherWaiting reports whether a sender with priority above p is
+// queued. Callers hold q.mu.
+func (q *sendQueue) higherWaiting(p Priority) bool {
+	for i := PriorityHigh; i < p; i++ {
+		if q.waiting[i] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// depths snapshots the per-priority queue depth.
+func (q *sendQueue) depths() map[string]int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	out := make(map[string]int, len(q.waiting))
+	for p, n := range q.waiting {
+		out[Priority(p).String()] = n
+	}
+	return out
+}
+
+// QueueDepths exposes the client's send-queue depth per priority for the
+// session status endpoint.
+func (c *Client) QueueDepths() map[string]int {
+	return c.sendq.depths()
+}
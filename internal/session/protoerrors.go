@@ -0,0 +1,37 @@
+package session
+
+import (
+	"context"
+	"errors"
+
+	"go.mau.fi/whatsmeow"
+
+	"github.com/fgsgalvaosz/zemeow/internal/metrics"
+)
+
+// classifyProtocolError buckets a send-path error into a stable metric
+// label. Unrecognized errors fall into "other" rather than exploding the
+// label cardinality with raw error strings.
+func classifyProtocolError(err error) string {
+	switch {
+	case errors.Is(err, whatsmeow.ErrIQTimedOut):
+		return "iq_timeout"
+	case errors.Is(err, whatsmeow.ErrNotConnected):
+		return "not_connected"
+	case errors.Is(err, whatsmeow.ErrNotLoggedIn):
+		return "not_logged_in"
+	case errors.Is(err, whatsmeow.ErrMessageTimedOut):
+		return "message_timeout"
+	case errors.Is(err, whatsmeow.ErrServerReturnedError):
+		return "server_error"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+	default:
+		return "other"
+	}
+}
+
+// countProtocolError records a protocol error for the session's metrics.
+func countProtocolError(sessionID, errType string) {
+	metrics.ProtocolErrors.WithLabelValues(sessionID, errType).Inc()
+}
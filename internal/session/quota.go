@@ -0,0 +1,66 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExceeded is returned by send paths when the session's daily
+// quota is used up.
+var ErrQuotaExceeded = errors.New("daily quota exceeded")
+
+// quotaAlertState debounces the quota_exceeded admin event to one per
+// session per UTC day.
+type quotaAlertState struct {
+	mu  sync.Mutex
+	day string
+}
+
+// checkMessageQuota enforces the session's daily message quota before a
+// send. Metering failures do not block sends: quota is a billing guard,
+// not a correctness invariant, and a flaky database should not take
+// messaging down with it.
+func (m *Manager) checkMessageQuota(ctx context.Context, c *Client) error {
+	q := c.Session.Quota
+	if q == nil || q.MaxMessagesPerDay <= 0 {
+		return nil
+	}
+	usage, err := m.repos.Usage.Today(ctx, c.Session.ID)
+	if err != nil {
+		m.log.Error().Err(err).Str("session_id", c.Session.ID).Msg("read usage for quota check")
+		return nil
+	}
+	if usage.MessagesSent >= q.MaxMessagesPerDay {
+		m.notifyQuotaExceeded(c, usage.MessagesSent, q.MaxMessagesPerDay)
+		return ErrQuotaExceeded
+	}
+	return nil
+}
+
+// notifyQuotaExceeded raises the quota_exceeded admin event, at most
+// once per session per UTC day: the first rejection is the signal, the
+// rest of the day's rejections are noise.
+func (m *Manager) notifyQuotaExceeded(c *Client, sent, max int64) {
+	day := time.Now().UTC().Format("2006-01-02")
+	c.quotaAlert.mu.Lock()
+	already := c.quotaAlert.day == day
+	c.quotaAlert.day = day
+	c.quotaAlert.mu.Unlock()
+	if already {
+		return
+	}
+	m.NotifyAdmin("quota_exceeded", c.Session.ID, map[string]any{
+		"messages_sent":        sent,
+		"max_messages_per_day": max,
+	})
+}
+
+// meterMessage records one sent message against today's usage,
+// best-effort.
+func (m *Manager) meterMessage(ctx context.Context, c *Client) {
+	if err := m.repos.Usage.AddMessages(ctx, c.Session.ID, 1); err != nil {
+		m.log.Error().Err(err).Str("session_id", c.Session.ID).Msg("meter message")
+	}
+}
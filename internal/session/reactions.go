@@ -0,0 +1,44 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// ErrReactionTargetUnknown is returned when the message being reacted to
+// was never stored, so a correct MessageKey cannot be built.
+var ErrReactionTargetUnknown = errors.New("message to react to is not in the store")
+
+// ReactToMessage sends an emoji reaction to a stored message, or removes
+// an earlier reaction when remove is set. The original message is looked
+// up in the store so the reaction key carries the right FromMe flag and,
+// in groups, the right participant.
+func (m *Manager) ReactToMessage(ctx context.Context, c *Client, chat types.JID, messageID, emoji string, remove bool) (whatsmeow.SendResponse, error) {
+	orig, err := m.repos.Messages.GetByMessageID(ctx, c.Session.ID, messageID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return whatsmeow.SendResponse{}, ErrReactionTargetUnknown
+	}
+	if err != nil {
+		return whatsmeow.SendResponse{}, fmt.Errorf("look up reaction target: %w", err)
+	}
+
+	sender, err := types.ParseJID(orig.SenderJID)
+	if err != nil {
+		return whatsmeow.SendResponse{}, fmt.Errorf("parse original sender: %w", err)
+	}
+	if err := m.EnsureSendable(c); err != nil {
+		return whatsmeow.SendResponse{}, err
+	}
+
+	// Removal is a reaction with an empty body on the same key.
+	if remove {
+		emoji = ""
+	}
+	msg := c.WA.BuildReaction(chat, sender, types.MessageID(messageID), emoji)
+	return m.Send(ctx, c, chat, msg)
+}
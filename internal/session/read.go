@@ -0,0 +1,49 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// markReadBatchSize caps how many message ids go into one MarkRead call.
+const markReadBatchSize = 50
+
+// MarkChatRead marks every unread inbound message in a chat as read:
+// locally by stamping read_at, and on the protocol side by sending read
+// receipts in batches grouped per sender (group chats carry messages
+// from many senders, and a receipt addresses one). Returns how many
+// messages were processed.
+func (m *Manager) MarkChatRead(ctx context.Context, c *Client, chat types.JID) (int, error) {
+	if err := m.EnsureSendable(c); err != nil {
+		return 0, err
+	}
+	unread, err := m.repos.Messages.MarkChatRead(ctx, c.Session.ID, chat.String())
+	if err != nil {
+		return 0, err
+	}
+	bySender := make(map[string][]types.MessageID)
+	for _, msg := range unread {
+		bySender[msg.SenderJID] = append(bySender[msg.SenderJID], msg.MessageID)
+	}
+	now := time.Now()
+	for raw, ids := range bySender {
+		sender, err := types.ParseJID(raw)
+		if err != nil {
+			m.log.Warn().Err(err).Str("sender", m.redactor.JID(raw)).Msg("skip read receipt")
+			continue
+		}
+		for start := 0; start < len(ids); start += markReadBatchSize {
+			end := start + markReadBatchSize
+			if end > len(ids) {
+				end = len(ids)
+			}
+			if err := c.WA.MarkRead(ids[start:end], now, chat, sender); err != nil {
+				m.log.Error().Err(err).Str("session_id", c.Session.ID).
+					Str("chat_jid", m.redactor.JID(chat.String())).Msg("send read receipts")
+			}
+		}
+	}
+	return len(unread), nil
+}
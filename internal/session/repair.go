@@ -0,0 +1,44 @@
+package session
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// ErrSessionStillPaired is returned when re-pairing a session that still
+// holds credentials; callers must log out first (or pass force).
+var ErrSessionStillPaired = errors.New("session still paired, logout first")
+
+// Repair starts a fresh QR pairing for an existing session record,
+// keeping its id, API key, webhook configuration and stored history.
+// When force is set, any remaining credentials are wiped first; otherwise
+// the session must already be logged out.
+func (m *Manager) Repair(ctx context.Context, id string, force bool) error {
+	c, ok := m.Get(id)
+	if !ok {
+		return ErrSessionNotFound
+	}
+	c.lifecycle.Lock()
+	defer c.lifecycle.Unlock()
+
+	if c.Session.DeviceJID != "" {
+		if !force {
+			return ErrSessionStillPaired
+		}
+		if err := m.logoutLocked(ctx, c); err != nil {
+			return err
+		}
+	}
+
+	if c.WA != nil {
+		c.WA.Disconnect()
+		c.WA = nil
+	}
+	c.Session.Status = models.SessionStatusDisconnected
+
+	// With DeviceJID empty, Connect allocates a fresh device and surfaces
+	// the pairing QR through the usual qrcode events.
+	return m.connectLocked(ctx, c)
+}
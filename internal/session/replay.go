@@ -0,0 +1,40 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/webhook"
+)
+
+// Replay re-delivers stored events to the session's webhook and queue in
+// their original order. Envelopes and queue routing keys are identical to
+// the live delivery except for the Replayed marker, so consumer code
+// paths do not need to change. Delivery is sequential and best-effort: a
+// failed event is logged and skipped rather than aborting the run.
+func (m *Manager) Replay(s *models.Session, stored []*models.StoredEvent) {
+	ctx := context.Background()
+	for _, e := range stored {
+		if s.WantsEvent(e.Type) {
+			env := &webhook.Envelope{
+				SessionID: s.ID,
+				Event:     e.Type,
+				Timestamp: e.Timestamp,
+				Payload:   e.Payload,
+				Replayed:  true,
+			}
+			dctx, cancel := context.WithTimeout(ctx, time.Minute)
+			err := m.deliverer.Deliver(dctx, s, env)
+			cancel()
+			if err != nil {
+				m.log.Error().Err(err).Int64("event_id", e.ID).
+					Str("session_id", s.ID).Msg("replay webhook delivery failed")
+			}
+		}
+		if err := m.publisher.Publish(ctx, s.ID, e.Type, e.Payload); err != nil {
+			m.log.Error().Err(err).Int64("event_id", e.ID).Msg("replay queue publish failed")
+		}
+	}
+	m.log.Info().Str("session_id", s.ID).Int("events", len(stored)).Msg("replay finished")
+}
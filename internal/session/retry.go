@@ -0,0 +1,71 @@
+package session
+
+import (
+	"context"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// Retry receipt escalation. whatsmeow re-encrypts and resends once on
+// its own; a second retry receipt from the same recipient means that
+// attempt also failed to decrypt, so the stored payload is re-sent with
+// freshly established sessions. Past the failure threshold the message
+// is considered undeliverable to that recipient and surfaced via
+// webhook instead.
+const (
+	retryResendThreshold  = 2
+	retryFailureThreshold = 3
+)
+
+// handleRetryReceipt tracks per-recipient retry receipts for our
+// messages and escalates along the thresholds above.
+func (m *Manager) handleRetryReceipt(ctx context.Context, c *Client, e *events.Receipt) {
+	for _, id := range e.MessageIDs {
+		count, err := m.repos.Messages.IncrementRetry(ctx, c.Session.ID, id, e.Sender.String())
+		if err != nil {
+			m.log.Error().Err(err).Str("session_id", c.Session.ID).
+				Str("message_id", id).Msg("track retry receipt")
+			continue
+		}
+		switch {
+		case count == retryResendThreshold:
+			m.resendFromStore(ctx, c, e.Chat, id)
+		case count >= retryFailureThreshold:
+			m.Dispatch(ctx, c.Session, models.EventMessageRetry, map[string]any{
+				"message_id":    id,
+				"chat_jid":      e.Chat.String(),
+				"recipient_jid": e.Sender.String(),
+				"retries":       count,
+				"persistent":    true,
+			})
+		}
+	}
+}
+
+// resendFromStore replays a stored outbound message through the normal
+// send path, which establishes fresh Signal sessions as a side effect.
+// Best effort: messages without a stored raw payload are skipped.
+func (m *Manager) resendFromStore(ctx context.Context, c *Client, chat types.JID, messageID string) {
+	msg, err := m.repos.Messages.GetByMessageID(ctx, c.Session.ID, messageID)
+	if err != nil || !msg.FromMe || len(msg.Raw) == 0 {
+		return
+	}
+	var payload waE2E.Message
+	if err := proto.Unmarshal(msg.Raw, &payload); err != nil {
+		m.log.Error().Err(err).Str("session_id", c.Session.ID).
+			Str("message_id", messageID).Msg("decode stored payload for resend")
+		return
+	}
+	if _, err := m.Send(ctx, c, chat, &payload); err != nil {
+		m.log.Error().Err(err).Str("session_id", c.Session.ID).
+			Str("message_id", messageID).Msg("resend after retry receipt")
+		return
+	}
+	m.log.Info().Str("session_id", c.Session.ID).
+		Str("message_id", messageID).Msg("re-sent message after repeated retry receipts")
+}
@@ -0,0 +1,84 @@
+package session
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// presenceState tracks the last emitted presence per contact for the
+// throttle. It lives on the Client so the window resets with the session.
+type presenceState struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// sampleReceipt decides whether a receipt event passes the session's
+// sample rate. Sampling is random rather than counted so receipt types
+// stay evenly represented in the sampled stream.
+func (c *Client) sampleReceipt() bool {
+	rate := c.Session.ReceiptSampleRate
+	if rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	return rand.Float64() < rate
+}
+
+// chatPresenceDebounce suppresses repeats of the same typing/recording
+// state from the same sender in the same chat. WhatsApp re-sends
+// "composing" every few seconds while the peer keeps typing; consumers
+// only care about transitions.
+const chatPresenceDebounce = 10 * time.Second
+
+// chatPresenceState remembers the last forwarded state per chat+sender.
+type chatPresenceState struct {
+	mu   sync.Mutex
+	last map[string]chatPresenceSeen
+}
+
+type chatPresenceSeen struct {
+	state string
+	at    time.Time
+}
+
+// debounceChatPresence reports whether a chat presence update is a
+// transition worth forwarding. State changes always pass; repeats of the
+// same state pass once the debounce window lapses.
+func (c *Client) debounceChatPresence(key, state string) bool {
+	c.chatPresence.mu.Lock()
+	defer c.chatPresence.mu.Unlock()
+	if c.chatPresence.last == nil {
+		c.chatPresence.last = make(map[string]chatPresenceSeen)
+	}
+	now := time.Now()
+	if seen, ok := c.chatPresence.last[key]; ok &&
+		seen.state == state && now.Sub(seen.at) < chatPresenceDebounce {
+		return false
+	}
+	c.chatPresence.last[key] = chatPresenceSeen{state: state, at: now}
+	return true
+}
+
+// throttlePresence decides whether a presence event for jid passes the
+// session's minimum interval. The first event for a contact always
+// passes.
+func (c *Client) throttlePresence(jid string) bool {
+	interval := time.Duration(c.Session.PresenceMinInterval) * time.Second
+	if interval <= 0 {
+		return true
+	}
+	c.presence.mu.Lock()
+	defer c.presence.mu.Unlock()
+	if c.presence.seen == nil {
+		c.presence.seen = make(map[string]time.Time)
+	}
+	now := time.Now()
+	if last, ok := c.presence.seen[jid]; ok && now.Sub(last) < interval {
+		return false
+	}
+	c.presence.seen[jid] = now
+	return true
+}
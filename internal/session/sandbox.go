@@ -0,0 +1,71 @@
+package session
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// sandboxSend handles sends on sandbox sessions: nothing touches the
+// WhatsApp socket, but the message is persisted to history and echoed
+// back through the webhook as if it had been delivered, so integrations
+// see the exact production event flow without a paired number.
+func (m *Manager) sandboxSend(ctx context.Context, c *Client, to types.JID, waMsg *waE2E.Message) (whatsmeow.SendResponse, error) {
+	now := time.Now().UTC()
+	id := sandboxMessageID()
+
+	msg := &models.Message{
+		SessionID: c.Session.ID,
+		MessageID: id,
+		ChatJID:   to.String(),
+		SenderJID: c.Session.DeviceJID,
+		FromMe:    true,
+		Timestamp: now,
+	}
+	msg.Type, msg.Body = classifyMessage(&events.Message{Message: waMsg})
+	if raw, err := proto.Marshal(waMsg); err == nil {
+		msg.Raw = raw
+	}
+	m.enqueueMessage(ctx, msg)
+
+	m.Dispatch(ctx, c.Session, models.EventMessage, map[string]any{
+		"message_id": msg.MessageID,
+		"chat_jid":   msg.ChatJID,
+		"sender_jid": msg.SenderJID,
+		"from_me":    true,
+		"type":       msg.Type,
+		"body":       msg.Body,
+		"timestamp":  msg.Timestamp,
+		"sandbox":    true,
+	})
+	// Echo a delivered receipt so consumers exercising delivery tracking
+	// see the full lifecycle. Spelled out as "delivered" (the protocol
+	// encodes delivery as an empty receipt type, which would be useless
+	// in a test payload).
+	m.Dispatch(ctx, c.Session, models.EventReceipt, map[string]any{
+		"chat_jid":    msg.ChatJID,
+		"sender_jid":  msg.ChatJID,
+		"message_ids": []string{msg.MessageID},
+		"receipt":     "delivered",
+		"timestamp":   now,
+		"sandbox":     true,
+	})
+
+	m.meterMessage(ctx, c)
+	return whatsmeow.SendResponse{ID: id, Timestamp: now}, nil
+}
+
+// sandboxMessageID generates a message id for a sandbox send. The
+// SANDBOX prefix keeps fake ids recognizable in history and logs.
+func sandboxMessageID() string {
+	return "SANDBOX" + strings.ToUpper(strings.ReplaceAll(uuid.NewString(), "-", ""))
+}
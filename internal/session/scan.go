@@ -0,0 +1,42 @@
+package session
+
+import (
+	"context"
+
+	"go.mau.fi/whatsmeow/types/events"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/scan"
+)
+
+// scanInboundDocument runs the malware scanner over inbound document
+// media and records the verdict on the message. Scan failures leave the
+// verdict empty (the message is stored as unscanned) rather than
+// blocking ingestion.
+func (m *Manager) scanInboundDocument(ctx context.Context, c *Client, e *events.Message, msg *models.Message) {
+	if !m.scanner.Enabled() || msg.FromMe || msg.Type != models.MessageTypeDocument {
+		return
+	}
+	doc := e.Message.GetDocumentMessage()
+	if doc == nil {
+		return
+	}
+	data, err := c.WA.Download(doc)
+	if err != nil {
+		m.log.Warn().Err(err).Str("session_id", c.Session.ID).
+			Str("message_id", msg.MessageID).Msg("download document for scan")
+		return
+	}
+	result, err := m.scanner.Scan(ctx, data)
+	if err != nil {
+		m.log.Error().Err(err).Str("session_id", c.Session.ID).
+			Str("message_id", msg.MessageID).Msg("scan inbound document")
+		return
+	}
+	msg.ScanVerdict = result.Verdict
+	if result.Verdict == scan.VerdictInfected {
+		msg.ScanVerdict = scan.VerdictInfected + ": " + result.Signature
+		m.log.Warn().Str("session_id", c.Session.ID).Str("message_id", msg.MessageID).
+			Str("signature", result.Signature).Msg("infected inbound document")
+	}
+}
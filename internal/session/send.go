@@ -0,0 +1,101 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// SendText sends a plain text message and returns the server response.
+// The per-session duplicate guard applies here (and only to text sends,
+// where upstream retry bugs actually bite).
+func (m *Manager) SendText(ctx context.Context, c *Client, to types.JID, text string) (whatsmeow.SendResponse, error) {
+	text, err := m.policies.Apply(ctx, c.Session.ID, text)
+	if err != nil {
+		return whatsmeow.SendResponse{}, err
+	}
+	if err := m.guardDuplicateText(c, to, text); err != nil {
+		return whatsmeow.SendResponse{}, err
+	}
+	msg := &waE2E.Message{Conversation: proto.String(text)}
+	return m.Send(ctx, c, to, msg)
+}
+
+// SendLocation sends a static location pin, optionally named.
+func (m *Manager) SendLocation(ctx context.Context, c *Client, to types.JID, latitude, longitude float64, name string) (whatsmeow.SendResponse, error) {
+	msg := &waE2E.Message{LocationMessage: &waE2E.LocationMessage{
+		DegreesLatitude:  proto.Float64(latitude),
+		DegreesLongitude: proto.Float64(longitude),
+	}}
+	if name != "" {
+		msg.LocationMessage.Name = proto.String(name)
+	}
+	return m.Send(ctx, c, to, msg)
+}
+
+// Retry policy for transient send failures.
+const (
+	sendMaxAttempts      = 3
+	sendRetryBaseBackoff = time.Second
+)
+
+// Send is the lowest-level send path. Every outbound message funnels
+// through here so the pause guard, the per-session circuit breaker and
+// transient-error retries apply uniformly.
+func (m *Manager) Send(ctx context.Context, c *Client, to types.JID, msg *waE2E.Message) (whatsmeow.SendResponse, error) {
+	if err := m.EnsureSendable(c); err != nil {
+		return whatsmeow.SendResponse{}, err
+	}
+	if !c.breaker.allow() {
+		return whatsmeow.SendResponse{}, ErrCircuitOpen
+	}
+	if err := m.checkMessageQuota(ctx, c); err != nil {
+		return whatsmeow.SendResponse{}, err
+	}
+	if c.Session.Sandbox {
+		return m.sandboxSend(ctx, c, to, msg)
+	}
+
+	// One send at a time per session, released in priority order so bulk
+	// fan-outs never starve interactive traffic.
+	c.sendq.enter(c.Session.ID, priorityFrom(ctx))
+	defer c.sendq.leave()
+
+	var resp whatsmeow.SendResponse
+	var err error
+	for attempt := 1; attempt <= sendMaxAttempts; attempt++ {
+		resp, err = c.WA.SendMessage(ctx, to, msg)
+		if err == nil {
+			c.breaker.onSuccess()
+			m.meterMessage(ctx, c)
+			return resp, nil
+		}
+		countProtocolError(c.Session.ID, classifyProtocolError(err))
+		if !isTransientSendError(err) || attempt == sendMaxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			c.breaker.onFailure()
+			return resp, ctx.Err()
+		case <-time.After(time.Duration(attempt) * sendRetryBaseBackoff):
+		}
+	}
+	c.breaker.onFailure()
+	return resp, err
+}
+
+// isTransientSendError reports whether a send failure is worth retrying
+// within the same request.
+func isTransientSendError(err error) bool {
+	switch classifyProtocolError(err) {
+	case "iq_timeout", "message_timeout", "server_error":
+		return true
+	default:
+		return false
+	}
+}
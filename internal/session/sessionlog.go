@@ -0,0 +1,140 @@
+package session
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	waLog "go.mau.fi/whatsmeow/util/log"
+)
+
+// sessionLogCapacity bounds the per-session ring buffer; at debug level
+// a busy socket fills it in minutes, which is exactly the window a
+// support engineer needs.
+const sessionLogCapacity = 500
+
+// SessionLogEntry is one captured log line of a session's whatsmeow
+// client.
+type SessionLogEntry struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Module  string    `json:"module,omitempty"`
+	Message string    `json:"message"`
+}
+
+// logSeverities orders level names; entries below the session's floor
+// are dropped before they reach the ring.
+var logSeverities = map[string]int{
+	"debug": 0,
+	"info":  1,
+	"warn":  2,
+	"error": 3,
+	"off":   4,
+}
+
+// defaultSessionLogLevel keeps the ring useful without being asked:
+// warnings and errors are always captured.
+const defaultSessionLogLevel = "warn"
+
+// logState is the per-client ring buffer plus the runtime level
+// override. The zero value captures at the default level.
+type logState struct {
+	mu      sync.Mutex
+	level   string
+	entries []SessionLogEntry
+	next    int
+	full    bool
+}
+
+func (s *logState) record(e SessionLogEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	floor := s.level
+	if floor == "" {
+		floor = defaultSessionLogLevel
+	}
+	if logSeverities[e.Level] < logSeverities[floor] {
+		return
+	}
+	if s.entries == nil {
+		s.entries = make([]SessionLogEntry, sessionLogCapacity)
+	}
+	s.entries[s.next] = e
+	s.next = (s.next + 1) % sessionLogCapacity
+	if s.next == 0 {
+		s.full = true
+	}
+}
+
+// snapshot returns the buffered entries oldest first.
+func (s *logState) snapshot() []SessionLogEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries == nil {
+		return nil
+	}
+	var out []SessionLogEntry
+	if s.full {
+		out = append(out, s.entries[s.next:]...)
+	}
+	return append(out, s.entries[:s.next]...)
+}
+
+// sessionLogger adapts a client's ring buffer to the waLog.Logger
+// interface, so the whatsmeow client's own diagnostics land in the
+// per-session buffer instead of being discarded. The level floor is
+// consulted per call, which is what makes runtime overrides work
+// without reconnecting.
+type sessionLogger struct {
+	c      *Client
+	module string
+}
+
+func (l sessionLogger) logf(level, format string, args ...any) {
+	l.c.logs.record(SessionLogEntry{
+		Time:    time.Now().UTC(),
+		Level:   level,
+		Module:  l.module,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (l sessionLogger) Errorf(format string, args ...any) { l.logf("error", format, args...) }
+func (l sessionLogger) Warnf(format string, args ...any)  { l.logf("warn", format, args...) }
+func (l sessionLogger) Infof(format string, args ...any)  { l.logf("info", format, args...) }
+func (l sessionLogger) Debugf(format string, args ...any) { l.logf("debug", format, args...) }
+
+func (l sessionLogger) Sub(module string) waLog.Logger {
+	if l.module != "" {
+		module = l.module + "/" + module
+	}
+	return sessionLogger{c: l.c, module: module}
+}
+
+// SetSessionLogLevel overrides the session's capture floor at runtime.
+// The override is in-memory only: a restart falls back to the default,
+// which is the safe direction for a debugging aid.
+func (m *Manager) SetSessionLogLevel(c *Client, level string) error {
+	if _, ok := logSeverities[level]; !ok {
+		return fmt.Errorf("unknown log level %q", level)
+	}
+	c.logs.mu.Lock()
+	c.logs.level = level
+	c.logs.mu.Unlock()
+	return nil
+}
+
+// SessionLogLevel returns the session's effective capture floor.
+func (m *Manager) SessionLogLevel(c *Client) string {
+	c.logs.mu.Lock()
+	defer c.logs.mu.Unlock()
+	if c.logs.level == "" {
+		return defaultSessionLogLevel
+	}
+	return c.logs.level
+}
+
+// SessionLogs returns the buffered entries, oldest first.
+func (m *Manager) SessionLogs(c *Client) []SessionLogEntry {
+	return c.logs.snapshot()
+}
@@ -0,0 +1,83 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.mau.fi/whatsmeow/types/events"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// Keyword commands contacts can send in a direct chat to manage their
+// topic subscriptions. "stop" is the blanket opt-out.
+var (
+	subscribeRe   = regexp.MustCompile(`(?i)^\s*(?:join|subscribe)\s+([a-z0-9_-]+)\s*$`)
+	unsubscribeRe = regexp.MustCompile(`(?i)^\s*(?:leave|unsubscribe)\s+([a-z0-9_-]+)\s*$`)
+	stopRe        = regexp.MustCompile(`(?i)^\s*stop\s*$`)
+)
+
+// handleSubscriptionKeywords lets contacts join and leave topics by
+// message. Only inbound direct text messages are considered; every
+// change is confirmed in-chat and emitted as a subscription_update
+// event.
+func (m *Manager) handleSubscriptionKeywords(ctx context.Context, c *Client, e *events.Message, msg *models.Message) {
+	if msg.FromMe || e.Info.IsGroup || msg.Type != models.MessageTypeText {
+		return
+	}
+	jid := e.Info.Sender.ToNonAD().String()
+
+	switch {
+	case subscribeRe.MatchString(msg.Body):
+		topic := strings.ToLower(subscribeRe.FindStringSubmatch(msg.Body)[1])
+		if err := m.repos.Subs.Subscribe(ctx, c.Session.ID, topic, jid); err != nil {
+			m.log.Error().Err(err).Str("session_id", c.Session.ID).Msg("subscribe")
+			return
+		}
+		m.confirmSubscription(ctx, c, e, fmt.Sprintf("You are now subscribed to %q. Send \"leave %s\" to unsubscribe.", topic, topic))
+		m.dispatchSubscription(ctx, c, topic, jid, true)
+
+	case unsubscribeRe.MatchString(msg.Body):
+		topic := strings.ToLower(unsubscribeRe.FindStringSubmatch(msg.Body)[1])
+		removed, err := m.repos.Subs.Unsubscribe(ctx, c.Session.ID, topic, jid)
+		if err != nil {
+			m.log.Error().Err(err).Str("session_id", c.Session.ID).Msg("unsubscribe")
+			return
+		}
+		if !removed {
+			return
+		}
+		m.confirmSubscription(ctx, c, e, fmt.Sprintf("You have been unsubscribed from %q.", topic))
+		m.dispatchSubscription(ctx, c, topic, jid, false)
+
+	case stopRe.MatchString(msg.Body):
+		n, err := m.repos.Subs.UnsubscribeAll(ctx, c.Session.ID, jid)
+		if err != nil {
+			m.log.Error().Err(err).Str("session_id", c.Session.ID).Msg("unsubscribe all")
+			return
+		}
+		if n == 0 {
+			return
+		}
+		m.confirmSubscription(ctx, c, e, "You have been unsubscribed from all topics.")
+		m.dispatchSubscription(ctx, c, "", jid, false)
+	}
+}
+
+func (m *Manager) confirmSubscription(ctx context.Context, c *Client, e *events.Message, text string) {
+	if _, err := m.SendText(ctx, c, e.Info.Chat, text); err != nil {
+		m.log.Error().Err(err).Str("session_id", c.Session.ID).Msg("subscription confirmation")
+	}
+}
+
+// dispatchSubscription emits a subscription_update event; an empty topic
+// means the contact opted out of everything.
+func (m *Manager) dispatchSubscription(ctx context.Context, c *Client, topic, jid string, subscribed bool) {
+	m.Dispatch(ctx, c.Session, models.EventSubscription, map[string]any{
+		"topic":      topic,
+		"jid":        jid,
+		"subscribed": subscribed,
+	})
+}
@@ -0,0 +1,35 @@
+package session
+
+import (
+	"context"
+
+	"go.mau.fi/whatsmeow/types/events"
+
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// transcribeVoiceNote runs the speech-to-text provider over inbound
+// voice notes and records the transcript on the message. Regular audio
+// attachments (music files and the like) are left alone; only push-to-
+// talk recordings are transcribed. Transcription failures leave the
+// transcript empty rather than blocking ingestion.
+func (m *Manager) transcribeVoiceNote(ctx context.Context, c *Client, e *events.Message, msg *models.Message) {
+	if !m.transcriber.Enabled() || msg.FromMe || msg.Type != models.MessageTypeAudio {
+		return
+	}
+	audio := e.Message.GetAudioMessage()
+	if audio == nil || !audio.GetPTT() {
+		return
+	}
+	if limit := m.transcriber.MaxBytes(); limit > 0 && audio.GetFileLength() > uint64(limit) {
+		return
+	}
+
+	data, err := c.WA.Download(audio)
+	if err != nil {
+		m.log.Warn().Err(err).Str("session_id", c.Session.ID).
+			Str("message_id", msg.MessageID).Msg("download voice note for transcription")
+		return
+	}
+	msg.Transcript = m.transcriber.Transcribe(ctx, audio.GetMimetype(), data)
+}
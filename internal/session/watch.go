@@ -0,0 +1,68 @@
+package session
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// WatchEvent is one event relayed to an in-process watcher. Payload is
+// the same JSON document the webhook would carry.
+type WatchEvent struct {
+	Type      string
+	Payload   json.RawMessage
+	Timestamp time.Time
+}
+
+// watchBuffer bounds each watcher's channel; events beyond it are
+// dropped rather than blocking the dispatch path on a slow consumer.
+const watchBuffer = 16
+
+// watchRegistry tracks live event watchers per session. Watchers see
+// every dispatched event regardless of the session's webhook event
+// subscription: they serve local consumers like the SSE stream, not
+// deliveries.
+type watchRegistry struct {
+	mu   sync.Mutex
+	subs map[string]map[chan WatchEvent]struct{}
+}
+
+// Watch subscribes to the session's event stream. The returned cancel
+// function must be called when done; it closes the channel.
+func (m *Manager) Watch(sessionID string) (<-chan WatchEvent, func()) {
+	ch := make(chan WatchEvent, watchBuffer)
+	m.watchers.mu.Lock()
+	if m.watchers.subs == nil {
+		m.watchers.subs = make(map[string]map[chan WatchEvent]struct{})
+	}
+	if m.watchers.subs[sessionID] == nil {
+		m.watchers.subs[sessionID] = make(map[chan WatchEvent]struct{})
+	}
+	m.watchers.subs[sessionID][ch] = struct{}{}
+	m.watchers.mu.Unlock()
+
+	cancel := func() {
+		m.watchers.mu.Lock()
+		delete(m.watchers.subs[sessionID], ch)
+		if len(m.watchers.subs[sessionID]) == 0 {
+			delete(m.watchers.subs, sessionID)
+		}
+		m.watchers.mu.Unlock()
+		close(ch)
+	}
+	return ch, cancel
+}
+
+// notifyWatchers fans an event out to the session's watchers. Sends
+// happen under the registry lock, so a cancelled channel is never
+// written to.
+func (m *Manager) notifyWatchers(sessionID string, evt WatchEvent) {
+	m.watchers.mu.Lock()
+	defer m.watchers.mu.Unlock()
+	for ch := range m.watchers.subs[sessionID] {
+		select {
+		case ch <- evt:
+		default:
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatchFanOut(t *testing.T) {
+	m := &Manager{}
+	ch1, cancel1 := m.Watch("s1")
+	ch2, cancel2 := m.Watch("s1")
+	other, cancelOther := m.Watch("s2")
+	defer cancel2()
+	defer cancelOther()
+
+	evt := WatchEvent{Type: "connected", Timestamp: time.Now()}
+	m.notifyWatchers("s1", evt)
+
+	for i, ch := range []<-chan WatchEvent{ch1, ch2} {
+		select {
+		case got := <-ch:
+			if got.Type != "connected" {
+				t.Errorf("watcher %d received %q", i+1, got.Type)
+			}
+		default:
+			t.Errorf("watcher %d received nothing", i+1)
+		}
+	}
+	select {
+	case got := <-other:
+		t.Errorf("watcher of another session received %q", got.Type)
+	default:
+	}
+
+	// A cancelled watcher no longer receives; the survivor still does.
+	cancel1()
+	m.notifyWatchers("s1", evt)
+	select {
+	case <-ch2:
+	default:
+		t.Error("surviving watcher received nothing after a peer cancelled")
+	}
+}
+
+func TestWatchDropsWhenBufferFull(t *testing.T) {
+	m := &Manager{}
+	ch, cancel := m.Watch("s1")
+	defer cancel()
+
+	// A slow consumer must not block dispatch: events beyond the buffer
+	// are dropped.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < watchBuffer+5; i++ {
+			m.notifyWatchers("s1", WatchEvent{Type: "qr_code"})
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("notifyWatchers blocked on a full watcher buffer")
+	}
+	if got := len(ch); got != watchBuffer {
+		t.Errorf("buffered events = %d, want %d", got, watchBuffer)
+	}
+}
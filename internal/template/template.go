@@ -0,0 +1,170 @@
+// Package template renders per-session message templates. Variables come
+// from the caller, optionally merged with a remote variable source
+// fetched just-in-time per recipient (with caching and fallbacks), so
+// campaign sends can personalize without pre-syncing data.
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/fgsgalvaosz/zemeow/internal/egress"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+)
+
+// Remote source defaults.
+const (
+	defaultSourceTimeout  = 5 * time.Second
+	defaultSourceCacheTTL = 5 * time.Minute
+	sourceMaxBody         = 1 << 20 // 1 MiB of JSON is already generous
+)
+
+// placeholderPattern matches {{name}} with optional inner spaces.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_.]+)\s*\}\}`)
+
+type cacheEntry struct {
+	vars    map[string]string
+	expires time.Time
+}
+
+// Renderer resolves template variables and substitutes placeholders. It
+// is safe for concurrent use; fetched variables are cached per
+// (session, template, recipient).
+type Renderer struct {
+	egress *egress.Resolver
+	log    zerolog.Logger
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// NewRenderer builds a Renderer using the shared egress resolver, so
+// variable fetches honor the per-session egress proxy.
+func NewRenderer(resolver *egress.Resolver, log zerolog.Logger) *Renderer {
+	return &Renderer{
+		egress: resolver,
+		log:    log.With().Str("component", "template").Logger(),
+		cache:  make(map[string]cacheEntry),
+	}
+}
+
+// Render produces the final message body for one recipient. Variable
+// precedence: caller-supplied locals, then the remote source, then the
+// source fallbacks, then the built-in date/time variables. Unresolved
+// placeholders render as empty strings.
+func (r *Renderer) Render(ctx context.Context, sess *models.Session, tpl *models.Template,
+	recipient string, locals map[string]string) string {
+	// Built-in variables sit below every other source, so callers can
+	// still override them. Dates render in the session's timezone and
+	// locale.
+	now := time.Now()
+	vars := map[string]string{
+		"date": sess.FormatTimestamp(now),
+		"time": now.In(sess.Location()).Format("15:04"),
+	}
+	if tpl.Source != nil {
+		for k, v := range tpl.Source.Fallbacks {
+			vars[k] = v
+		}
+		remote, err := r.fetchVars(ctx, sess, tpl, recipient)
+		if err != nil {
+			r.log.Warn().Err(err).Str("session_id", sess.ID).Str("template", tpl.Name).
+				Msg("variable source failed, using fallbacks")
+		}
+		for k, v := range remote {
+			vars[k] = v
+		}
+	}
+	for k, v := range locals {
+		vars[k] = v
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(tpl.Body, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		return vars[name]
+	})
+}
+
+// fetchVars queries the template's variable source for one recipient,
+// consulting the cache first.
+func (r *Renderer) fetchVars(ctx context.Context, sess *models.Session, tpl *models.Template,
+	recipient string) (map[string]string, error) {
+	src := tpl.Source
+	ttl := defaultSourceCacheTTL
+	if src.CacheTTLSeconds > 0 {
+		ttl = time.Duration(src.CacheTTLSeconds) * time.Second
+	}
+	key := sess.ID + "\x00" + tpl.Name + "\x00" + recipient
+
+	if src.CacheTTLSeconds >= 0 {
+		r.mu.Lock()
+		entry, ok := r.cache[key]
+		r.mu.Unlock()
+		if ok && time.Now().Before(entry.expires) {
+			return entry.vars, nil
+		}
+	}
+
+	timeout := defaultSourceTimeout
+	if src.TimeoutSeconds > 0 {
+		timeout = time.Duration(src.TimeoutSeconds) * time.Second
+	}
+	fctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	reqURL, err := url.Parse(src.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parse source url: %w", err)
+	}
+	q := reqURL.Query()
+	q.Set("recipient", recipient)
+	reqURL.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(fctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	client, err := r.egress.ClientFor(sess.EgressProxyURL)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("variable source returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, sourceMaxBody))
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("decode variable source response: %w", err)
+	}
+	vars := make(map[string]string, len(raw))
+	for k, v := range raw {
+		vars[k] = fmt.Sprint(v)
+	}
+
+	if src.CacheTTLSeconds >= 0 {
+		r.mu.Lock()
+		r.cache[key] = cacheEntry{vars: vars, expires: time.Now().Add(ttl)}
+		r.mu.Unlock()
+	}
+	return vars, nil
+}
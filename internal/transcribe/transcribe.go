@@ -0,0 +1,116 @@
+// Package transcribe integrates an external speech-to-text provider
+// (a Whisper API deployment or any compatible endpoint) for inbound
+// voice notes. The provider is optional: a nil *Transcriber is valid
+// and leaves voice notes untranscribed.
+package transcribe
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/rs/zerolog"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// maxResponseBytes caps provider responses; a voice note transcript
+// should never be anywhere near this.
+const maxResponseBytes = 1 << 20
+
+// Transcriber calls a configurable provider endpoint to turn voice
+// audio into text. The provider contract is a POST with {"mime": ...,
+// "language": ..., "data": <base64>} answering {"text": ...}.
+type Transcriber struct {
+	endpoint string
+	language string
+	maxBytes int
+	client   *http.Client
+	log      zerolog.Logger
+}
+
+// NewTranscriber builds a Transcriber from configuration; returns nil
+// when no provider endpoint is configured.
+func NewTranscriber(cfg config.TranscribeConfig, log zerolog.Logger) *Transcriber {
+	if cfg.Endpoint == "" {
+		return nil
+	}
+	return &Transcriber{
+		endpoint: cfg.Endpoint,
+		language: cfg.Language,
+		maxBytes: cfg.MaxBytes,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		log:      log.With().Str("component", "transcribe").Logger(),
+	}
+}
+
+// Enabled reports whether a provider is configured, nil-safe.
+func (t *Transcriber) Enabled() bool {
+	return t != nil && t.endpoint != ""
+}
+
+// MaxBytes is the largest audio blob the transcriber will accept;
+// larger voice notes are skipped rather than truncated.
+func (t *Transcriber) MaxBytes() int {
+	if t == nil {
+		return 0
+	}
+	return t.maxBytes
+}
+
+// Transcribe returns the transcript of the audio in data, or "" when
+// the provider is unavailable or produced nothing — callers treat ""
+// as "no transcript".
+func (t *Transcriber) Transcribe(ctx context.Context, mimeType string, data []byte) string {
+	if !t.Enabled() || len(data) == 0 {
+		return ""
+	}
+	if t.maxBytes > 0 && len(data) > t.maxBytes {
+		return ""
+	}
+	text, err := t.transcribe(ctx, mimeType, data)
+	if err != nil {
+		t.log.Warn().Err(err).Msg("transcription failed")
+		return ""
+	}
+	return text
+}
+
+func (t *Transcriber) transcribe(ctx context.Context, mimeType string, data []byte) (string, error) {
+	payload, _ := json.Marshal(map[string]string{
+		"mime":     mimeType,
+		"language": t.language,
+		"data":     base64.StdEncoding.EncodeToString(data),
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("provider returned %s", resp.Status)
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return "", err
+	}
+	var out struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return "", fmt.Errorf("decode provider response: %w", err)
+	}
+	return out.Text, nil
+}
@@ -0,0 +1,101 @@
+package translate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/rs/zerolog"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+)
+
+// maxResponseBytes caps provider responses; translations should never be
+// anywhere near this.
+const maxResponseBytes = 1 << 20
+
+// Translator calls a configurable provider endpoint to translate message
+// text. The provider contract is a POST with {"text": ..., "target": ...}
+// answering {"text": ...}. A nil Translator (no endpoint configured) is
+// valid and disables translation.
+type Translator struct {
+	endpoint string
+	target   string
+	client   *http.Client
+	log      zerolog.Logger
+}
+
+// NewTranslator builds a Translator from configuration; returns nil when
+// no provider endpoint is configured.
+func NewTranslator(cfg config.TranslationConfig, log zerolog.Logger) *Translator {
+	if cfg.Endpoint == "" {
+		return nil
+	}
+	return &Translator{
+		endpoint: cfg.Endpoint,
+		target:   cfg.TargetLang,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		log:      log.With().Str("component", "translate").Logger(),
+	}
+}
+
+// Enabled reports whether a provider is configured.
+func (t *Translator) Enabled() bool {
+	return t != nil && t.endpoint != ""
+}
+
+// TargetLang is the default language inbound messages are annotated in.
+func (t *Translator) TargetLang() string {
+	if t == nil {
+		return ""
+	}
+	return t.target
+}
+
+// Translate returns text translated into target, or "" when the provider
+// is unavailable or declined — callers treat "" as "leave untranslated".
+func (t *Translator) Translate(ctx context.Context, text, target string) string {
+	if !t.Enabled() || text == "" || target == "" {
+		return ""
+	}
+	body, err := t.translate(ctx, text, target)
+	if err != nil {
+		t.log.Warn().Err(err).Str("target", target).Msg("translation failed")
+		return ""
+	}
+	return body
+}
+
+func (t *Translator) translate(ctx context.Context, text, target string) (string, error) {
+	payload, _ := json.Marshal(map[string]string{"text": text, "target": target})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("provider returned %s", resp.Status)
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return "", err
+	}
+	var out struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return "", fmt.Errorf("decode provider response: %w", err)
+	}
+	return out.Text, nil
+}
@@ -0,0 +1,328 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+	"github.com/fgsgalvaosz/zemeow/internal/egress"
+	"github.com/fgsgalvaosz/zemeow/internal/models"
+	"github.com/fgsgalvaosz/zemeow/internal/privacy"
+)
+
+// Envelope is the JSON document posted to session webhooks.
+type Envelope struct {
+	SessionID string          `json:"session_id"`
+	Event     string          `json:"event"`
+	Timestamp time.Time       `json:"timestamp"`
+	Payload   json.RawMessage `json:"payload"`
+	// Replayed is set when the envelope is re-delivered through the event
+	// replay API rather than emitted live.
+	Replayed bool `json:"replayed,omitempty"`
+}
+
+// Deliverer posts event envelopes to session webhook URLs with bounded
+// retries. Clients come from the egress resolver so deliveries honor the
+// configured egress proxy. It is safe for concurrent use.
+type Deliverer struct {
+	cfg      config.WebhookConfig
+	egress   *egress.Resolver
+	redactor *privacy.Redactor
+	log      zerolog.Logger
+
+	// Rolling window of recent successful delivery latencies, feeding the
+	// status page average.
+	latMu     sync.Mutex
+	latencies []time.Duration
+	latNext   int
+}
+
+// latencyWindow is how many recent deliveries the average covers.
+const latencyWindow = 256
+
+// NewDeliverer creates a Deliverer using cfg and the egress resolver.
+// The redactor applies JID hashing to payloads when enabled.
+func NewDeliverer(cfg config.WebhookConfig, resolver *egress.Resolver,
+	redactor *privacy.Redactor, log zerolog.Logger) *Deliverer {
+	return &Deliverer{
+		cfg:      cfg,
+		egress:   resolver,
+		redactor: redactor,
+		log:      log.With().Str("component", "webhook").Logger(),
+	}
+}
+
+// Deliver posts the envelope to the session webhook, retrying transient
+// failures with exponential backoff. It returns the last error if every
+// attempt fails.
+func (d *Deliverer) Deliver(ctx context.Context, session *models.Session, env *Envelope) error {
+	if session.WebhookURL == "" {
+		return nil
+	}
+	env.Payload = d.redactor.HashPayloadJIDs(env.Payload)
+	body, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("marshal envelope: %w", err)
+	}
+	client, err := d.egress.ClientFor(session.EgressProxyURL)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= d.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(1<<uint(attempt-1)) * time.Second):
+			}
+		}
+		lastErr = d.post(ctx, client, session.WebhookURL, body)
+		if lastErr == nil {
+			return nil
+		}
+		d.log.Warn().Err(lastErr).
+			Str("session_id", session.ID).
+			Str("event", env.Event).
+			Int("attempt", attempt+1).
+			Msg("webhook delivery failed")
+	}
+	return lastErr
+}
+
+// AdminEnvelope is the JSON document posted to the operator-level
+// admin webhook for platform events.
+type AdminEnvelope struct {
+	Event     string    `json:"event"`
+	SessionID string    `json:"session_id,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	Payload   any       `json:"payload"`
+}
+
+// AdminEnabled reports whether an admin webhook is configured.
+func (d *Deliverer) AdminEnabled() bool {
+	return d.cfg.AdminURL != ""
+}
+
+// DeliverAdmin posts a platform event to the admin webhook with the
+// same retry policy as session deliveries. A no-op when no admin URL is
+// configured.
+func (d *Deliverer) DeliverAdmin(ctx context.Context, env *AdminEnvelope) error {
+	if d.cfg.AdminURL == "" {
+		return nil
+	}
+	body, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("marshal admin envelope: %w", err)
+	}
+	client, err := d.egress.ClientFor("")
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= d.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(1<<uint(attempt-1)) * time.Second):
+			}
+		}
+		lastErr = d.post(ctx, client, d.cfg.AdminURL, body)
+		if lastErr == nil {
+			return nil
+		}
+		d.log.Warn().Err(lastErr).
+			Str("event", env.Event).
+			Int("attempt", attempt+1).
+			Msg("admin webhook delivery failed")
+	}
+	return lastErr
+}
+
+// TestResult captures how the receiver answered a test-fire delivery.
+type TestResult struct {
+	Status    int   `json:"status"`
+	LatencyMs int64 `json:"latency_ms"`
+	// BodySnippet is the start of the response body, for debugging
+	// receivers that answer with error details.
+	BodySnippet string `json:"body_snippet,omitempty"`
+}
+
+// testBodySnippetLimit bounds how much of the receiver's response body
+// is echoed back.
+const testBodySnippetLimit = 512
+
+// TestFire posts the envelope once, without retries, and reports the
+// receiver's response so integrators can validate their endpoint.
+func (d *Deliverer) TestFire(ctx context.Context, session *models.Session, env *Envelope) (*TestResult, error) {
+	if session.WebhookURL == "" {
+		return nil, fmt.Errorf("no webhook url configured")
+	}
+	env.Payload = d.redactor.HashPayloadJIDs(env.Payload)
+	body, err := json.Marshal(env)
+	if err != nil {
+		return nil, fmt.Errorf("marshal envelope: %w", err)
+	}
+	client, err := d.egress.ClientFor(session.EgressProxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, session.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "zemeow-webhook/1.0")
+	if sig := d.sign(body); sig != "" {
+		req.Header.Set("X-Zemeow-Signature", sig)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	snippet := make([]byte, testBodySnippetLimit)
+	n, _ := io.ReadFull(resp.Body, snippet)
+	return &TestResult{
+		Status:      resp.StatusCode,
+		LatencyMs:   latency.Milliseconds(),
+		BodySnippet: string(snippet[:n]),
+	}, nil
+}
+
+// verificationBodyLimit bounds how much of the receiver's response body
+// is searched for the echoed challenge.
+const verificationBodyLimit = 4096
+
+// Verify performs the webhook verification handshake: it posts a
+// "webhook_verification" envelope carrying a random challenge to url and
+// requires a 2xx response whose body echoes the challenge back. This
+// proves the receiver actually parses zemeow envelopes, not merely that
+// something answers on the URL.
+func (d *Deliverer) Verify(ctx context.Context, url, egressProxyURL string) error {
+	challenge := make([]byte, 16)
+	if _, err := rand.Read(challenge); err != nil {
+		return fmt.Errorf("generate challenge: %w", err)
+	}
+	token := hex.EncodeToString(challenge)
+
+	payload, _ := json.Marshal(map[string]string{"challenge": token})
+	body, err := json.Marshal(&Envelope{
+		Event:     "webhook_verification",
+		Timestamp: time.Now().UTC(),
+		Payload:   payload,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal verification envelope: %w", err)
+	}
+	client, err := d.egress.ClientFor(egressProxyURL)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "zemeow-webhook/1.0")
+	if sig := d.sign(body); sig != "" {
+		req.Header.Set("X-Zemeow-Signature", sig)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("receiver returned status %d", resp.StatusCode)
+	}
+	answer, _ := io.ReadAll(io.LimitReader(resp.Body, verificationBodyLimit))
+	if !bytes.Contains(answer, []byte(token)) {
+		return fmt.Errorf("receiver did not echo the challenge")
+	}
+	return nil
+}
+
+// recordLatency folds a successful delivery's latency into the rolling
+// window.
+func (d *Deliverer) recordLatency(latency time.Duration) {
+	d.latMu.Lock()
+	defer d.latMu.Unlock()
+	if len(d.latencies) < latencyWindow {
+		d.latencies = append(d.latencies, latency)
+		return
+	}
+	d.latencies[d.latNext] = latency
+	d.latNext = (d.latNext + 1) % latencyWindow
+}
+
+// AvgLatency returns the average latency of recent successful
+// deliveries, or 0 when none have happened yet.
+func (d *Deliverer) AvgLatency() time.Duration {
+	d.latMu.Lock()
+	defer d.latMu.Unlock()
+	if len(d.latencies) == 0 {
+		return 0
+	}
+	var sum time.Duration
+	for _, l := range d.latencies {
+		sum += l
+	}
+	return sum / time.Duration(len(d.latencies))
+}
+
+// sign returns the X-Zemeow-Signature value for body, or "" when no
+// webhook secret is configured.
+func (d *Deliverer) sign(body []byte) string {
+	if d.cfg.Secret == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(d.cfg.Secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (d *Deliverer) post(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "zemeow-webhook/1.0")
+	if sig := d.sign(body); sig != "" {
+		req.Header.Set("X-Zemeow-Signature", sig)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	d.recordLatency(time.Since(start))
+	return nil
+}
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/fgsgalvaosz/zemeow/internal/config"
+	"github.com/fgsgalvaosz/zemeow/internal/egress"
+)
+
+func testDeliverer(t *testing.T) *Deliverer {
+	t.Helper()
+	resolver := egress.NewResolver("", 5*time.Second)
+	return NewDeliverer(config.WebhookConfig{Timeout: 5 * time.Second}, resolver, nil, zerolog.Nop())
+}
+
+// challengeFrom decodes the verification envelope a receiver gets and
+// returns the challenge token inside it.
+func challengeFrom(t *testing.T, r *http.Request) string {
+	t.Helper()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatalf("read verification body: %v", err)
+	}
+	var env Envelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		t.Fatalf("decode verification envelope: %v", err)
+	}
+	if env.Event != "webhook_verification" {
+		t.Errorf("verification event = %q", env.Event)
+	}
+	var payload struct {
+		Challenge string `json:"challenge"`
+	}
+	if err := json.Unmarshal(env.Payload, &payload); err != nil {
+		t.Fatalf("decode challenge payload: %v", err)
+	}
+	return payload.Challenge
+}
+
+func TestVerifyAcceptsEchoedChallenge(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"challenge":"`+challengeFrom(t, r)+`"}`)
+	}))
+	defer srv.Close()
+
+	if err := testDeliverer(t).Verify(context.Background(), srv.URL, ""); err != nil {
+		t.Errorf("echoing receiver rejected: %v", err)
+	}
+}
+
+func TestVerifyRejectsMissingEcho(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		challengeFrom(t, r)
+		io.WriteString(w, `{"ok":true}`)
+	}))
+	defer srv.Close()
+
+	err := testDeliverer(t).Verify(context.Background(), srv.URL, "")
+	if err == nil || !strings.Contains(err.Error(), "echo") {
+		t.Errorf("receiver without echo accepted: %v", err)
+	}
+}
+
+func TestVerifyRejectsErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	err := testDeliverer(t).Verify(context.Background(), srv.URL, "")
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("erroring receiver accepted: %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+// Package client is the official Go SDK for the zemeow HTTP API. It
+// mirrors the server's request and response shapes with plain structs so
+// integrators do not hand-roll HTTP calls, and ships webhook signature
+// verification helpers for receivers.
+//
+// The package depends only on the standard library; it is kept in sync
+// with the handlers in internal/api by hand — endpoint coverage grows
+// alongside the API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Client talks to a zemeow server. AdminKey authorizes the session
+// management endpoints; per-session calls use each session's own API
+// key, passed per method. The zero Client is not usable; use New.
+type Client struct {
+	baseURL    string
+	adminKey   string
+	httpClient *http.Client
+}
+
+// Option customizes a Client.
+type Option func(*Client)
+
+// WithHTTPClient replaces the underlying HTTP client (for custom
+// timeouts, proxies or instrumentation).
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// New creates a Client for the server at baseURL. adminKey may be empty
+// when only per-session endpoints are used.
+func New(baseURL, adminKey string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    baseURL,
+		adminKey:   adminKey,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// APIError is a non-2xx response decoded from the server's standard
+// error envelope. Code is the stable machine-readable identifier the
+// server documents per endpoint.
+type APIError struct {
+	Status  int    `json:"-"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("zemeow: %s (%s, HTTP %d)", e.Message, e.Code, e.Status)
+}
+
+// envelope is the server's standard response wrapper.
+type envelope struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Error   *APIError       `json:"error"`
+}
+
+// do sends a request and decodes the response envelope into out (which
+// may be nil to discard the data). authKey is the Authorization header
+// value: the admin key or a session API key depending on the endpoint.
+func (c *Client) do(ctx context.Context, method, path, authKey string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("zemeow: encode request: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authKey != "" {
+		req.Header.Set("Authorization", authKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return fmt.Errorf("zemeow: decode response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if !env.Success {
+		if env.Error == nil {
+			return &APIError{Status: resp.StatusCode, Code: "UNKNOWN", Message: "request failed"}
+		}
+		env.Error.Status = resp.StatusCode
+		return env.Error
+	}
+	if out != nil && len(env.Data) > 0 {
+		if err := json.Unmarshal(env.Data, out); err != nil {
+			return fmt.Errorf("zemeow: decode data: %w", err)
+		}
+	}
+	return nil
+}
+
+// sessionPath builds a /sessions/:id sub-path with the id escaped.
+func sessionPath(sessionID, suffix string) string {
+	return "/sessions/" + url.PathEscape(sessionID) + suffix
+}
+
+// pageQuery builds the cursor/limit query string shared by the paginated
+// listing endpoints; both parameters are optional.
+func pageQuery(cursor string, limit int) string {
+	q := url.Values{}
+	if cursor != "" {
+		q.Set("cursor", cursor)
+	}
+	if limit > 0 {
+		q.Set("limit", fmt.Sprintf("%d", limit))
+	}
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
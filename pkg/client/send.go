@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"time"
+)
+
+// SendResult is the common response of the send endpoints.
+type SendResult struct {
+	MessageID string    `json:"message_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// SendTextRequest mirrors the text send endpoint's body. Priority is
+// "high", "normal" (default) or "bulk"; TrackLinks rewrites URLs in the
+// body into tracked short links labelled with Campaign.
+type SendTextRequest struct {
+	To         string `json:"to"`
+	Body       string `json:"body"`
+	TrackLinks bool   `json:"track_links,omitempty"`
+	Campaign   string `json:"campaign,omitempty"`
+	Priority   string `json:"priority,omitempty"`
+}
+
+// SendText sends a plain text message.
+func (c *Client) SendText(ctx context.Context, sessionID, apiKey string, req *SendTextRequest) (*SendResult, error) {
+	var out SendResult
+	if err := c.do(ctx, http.MethodPost, sessionPath(sessionID, "/send/text"), apiKey, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SendImageRequest mirrors the image send endpoint's body. Exactly one
+// of URL or Data must be provided; Data is sent base64-encoded.
+type SendImageRequest struct {
+	To       string `json:"to"`
+	Caption  string `json:"caption,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Data     []byte `json:"-"`
+	MimeType string `json:"mime_type,omitempty"`
+}
+
+// sendImageBody is the wire shape; Data travels in the base64 field.
+type sendImageBody struct {
+	To       string `json:"to"`
+	Caption  string `json:"caption,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Base64   string `json:"base64,omitempty"`
+	MimeType string `json:"mime_type,omitempty"`
+}
+
+// SendImage sends an image from raw bytes or a fetchable URL.
+func (c *Client) SendImage(ctx context.Context, sessionID, apiKey string, req *SendImageRequest) (*SendResult, error) {
+	body := sendImageBody{
+		To:       req.To,
+		Caption:  req.Caption,
+		URL:      req.URL,
+		MimeType: req.MimeType,
+	}
+	if len(req.Data) > 0 {
+		body.Base64 = base64.StdEncoding.EncodeToString(req.Data)
+	}
+	var out SendResult
+	if err := c.do(ctx, http.MethodPost, sessionPath(sessionID, "/send/image"), apiKey, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SendReactionRequest mirrors the reaction endpoint's body. Remove
+// withdraws an earlier reaction instead of adding one.
+type SendReactionRequest struct {
+	To        string `json:"to"`
+	MessageID string `json:"message_id"`
+	Emoji     string `json:"emoji,omitempty"`
+	Remove    bool   `json:"remove,omitempty"`
+}
+
+// SendReaction reacts to (or removes a reaction from) a message.
+func (c *Client) SendReaction(ctx context.Context, sessionID, apiKey string, req *SendReactionRequest) (*SendResult, error) {
+	var out SendResult
+	if err := c.do(ctx, http.MethodPost, sessionPath(sessionID, "/send/reaction"), apiKey, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
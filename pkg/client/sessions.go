@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Session mirrors the server's session resource. Nested policy documents
+// (business hours, filters, moderation, media policy, quota) are kept as
+// raw JSON so the SDK does not chase their schemas.
+type Session struct {
+	ID                       string          `json:"id"`
+	Name                     string          `json:"name"`
+	DeviceJID                string          `json:"device_jid,omitempty"`
+	Status                   string          `json:"status"`
+	APIKey                   string          `json:"api_key,omitempty"`
+	Sandbox                  bool            `json:"sandbox,omitempty"`
+	WebhookURL               string          `json:"webhook_url,omitempty"`
+	Events                   []string        `json:"events"`
+	ProxyURL                 string          `json:"proxy_url,omitempty"`
+	EgressProxyURL           string          `json:"egress_proxy_url,omitempty"`
+	ReceiptSampleRate        float64         `json:"receipt_sample_rate"`
+	PresenceMinInterval      int             `json:"presence_min_interval"`
+	PresenceHeartbeatSeconds int             `json:"presence_heartbeat_seconds"`
+	InlineMediaMaxBytes      int             `json:"inline_media_max_bytes"`
+	Locale                   string          `json:"locale,omitempty"`
+	Timezone                 string          `json:"timezone,omitempty"`
+	RetentionDays            int             `json:"retention_days"`
+	DedupeWindowSeconds      int             `json:"dedupe_window_seconds"`
+	BusinessHours            json.RawMessage `json:"business_hours,omitempty"`
+	InboundFilters           json.RawMessage `json:"inbound_filters,omitempty"`
+	GroupModeration          json.RawMessage `json:"group_moderation,omitempty"`
+	MediaPolicy              json.RawMessage `json:"media_policy,omitempty"`
+	Quota                    json.RawMessage `json:"quota,omitempty"`
+	APIVersion               string          `json:"api_version,omitempty"`
+	Metadata                 map[string]any  `json:"metadata,omitempty"`
+	CreatedAt                time.Time       `json:"created_at"`
+	UpdatedAt                time.Time       `json:"updated_at"`
+	SendQueue                map[string]int  `json:"send_queue,omitempty"`
+}
+
+// CreateSessionRequest mirrors the session creation endpoint's body.
+// Zero values take the server defaults documented on each field there.
+type CreateSessionRequest struct {
+	Name                     string   `json:"name"`
+	WebhookURL               string   `json:"webhook_url,omitempty"`
+	Events                   []string `json:"events,omitempty"`
+	ProxyURL                 string   `json:"proxy_url,omitempty"`
+	EgressProxyURL           string   `json:"egress_proxy_url,omitempty"`
+	ReceiptSampleRate        *float64 `json:"receipt_sample_rate,omitempty"`
+	PresenceMinInterval      int      `json:"presence_min_interval,omitempty"`
+	PresenceHeartbeatSeconds int      `json:"presence_heartbeat_seconds,omitempty"`
+	InlineMediaMaxBytes      int      `json:"inline_media_max_bytes,omitempty"`
+	Locale                   string   `json:"locale,omitempty"`
+	Timezone                 string   `json:"timezone,omitempty"`
+	RetentionDays            *int     `json:"retention_days,omitempty"`
+	DedupeWindowSeconds      int      `json:"dedupe_window_seconds,omitempty"`
+	Sandbox                  bool     `json:"sandbox,omitempty"`
+	// VerifyWebhook makes the server run a challenge handshake against
+	// WebhookURL before creating the session; the receiver must echo the
+	// challenge from the "webhook_verification" envelope in its response.
+	VerifyWebhook bool `json:"verify_webhook,omitempty"`
+}
+
+// CreateSession creates a session. Requires the admin key; the returned
+// session carries its freshly minted API key.
+func (c *Client) CreateSession(ctx context.Context, req *CreateSessionRequest) (*Session, error) {
+	var out Session
+	if err := c.do(ctx, http.MethodPost, "/sessions/", c.adminKey, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SessionPage is one page of the session listing. NextCursor is empty on
+// the last page.
+type SessionPage struct {
+	Items      []*Session `json:"items"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+// ListSessions returns one page of sessions. Requires the admin key.
+// Pass an empty cursor to start from the beginning and limit 0 for the
+// server default page size.
+func (c *Client) ListSessions(ctx context.Context, cursor string, limit int) (*SessionPage, error) {
+	path := "/sessions/" + pageQuery(cursor, limit)
+	var out SessionPage
+	if err := c.do(ctx, http.MethodGet, path, c.adminKey, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSession returns one session, authorized by its own API key.
+func (c *Client) GetSession(ctx context.Context, sessionID, apiKey string) (*Session, error) {
+	var out Session
+	if err := c.do(ctx, http.MethodGet, sessionPath(sessionID, "/"), apiKey, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteSession removes a session and its stored data.
+func (c *Client) DeleteSession(ctx context.Context, sessionID, apiKey string) error {
+	return c.do(ctx, http.MethodDelete, sessionPath(sessionID, "/"), apiKey, nil, nil)
+}
+
+// ConnectSession starts (or resumes) the WhatsApp connection.
+func (c *Client) ConnectSession(ctx context.Context, sessionID, apiKey string) error {
+	return c.do(ctx, http.MethodPost, sessionPath(sessionID, "/connect"), apiKey, nil, nil)
+}
+
+// DisconnectSession closes the WhatsApp connection, keeping credentials.
+func (c *Client) DisconnectSession(ctx context.Context, sessionID, apiKey string) error {
+	return c.do(ctx, http.MethodPost, sessionPath(sessionID, "/disconnect"), apiKey, nil, nil)
+}
+
+// PauseSession pauses the session: the socket closes and sends are
+// refused with code PAUSED until ResumeSession.
+func (c *Client) PauseSession(ctx context.Context, sessionID, apiKey string) error {
+	return c.do(ctx, http.MethodPost, sessionPath(sessionID, "/pause"), apiKey, nil, nil)
+}
+
+// ResumeSession lifts a pause and reconnects.
+func (c *Client) ResumeSession(ctx context.Context, sessionID, apiKey string) error {
+	return c.do(ctx, http.MethodPost, sessionPath(sessionID, "/resume"), apiKey, nil, nil)
+}
+
+// CloneSessionResult is the clone endpoint's response: the new session
+// plus counts of the copied templates and canned responses.
+type CloneSessionResult struct {
+	Session *Session       `json:"session"`
+	Copied  map[string]int `json:"copied"`
+}
+
+// CloneSession creates a new session copying the source's configuration,
+// templates and canned responses; the clone gets a fresh API key and
+// starts unpaired.
+func (c *Client) CloneSession(ctx context.Context, sessionID, apiKey, name string) (*CloneSessionResult, error) {
+	var out CloneSessionResult
+	req := map[string]string{"name": name}
+	if err := c.do(ctx, http.MethodPost, sessionPath(sessionID, "/clone"), apiKey, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
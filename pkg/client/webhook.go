@@ -0,0 +1,59 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// SignatureHeader is the HTTP header carrying the delivery signature
+// when the server is configured with a webhook secret.
+const SignatureHeader = "X-Zemeow-Signature"
+
+// WebhookEnvelope is the JSON document posted to session webhooks.
+type WebhookEnvelope struct {
+	SessionID string          `json:"session_id"`
+	Event     string          `json:"event"`
+	Timestamp time.Time       `json:"timestamp"`
+	Payload   json.RawMessage `json:"payload"`
+	// Replayed is set when the envelope was re-delivered through the
+	// event replay API rather than emitted live.
+	Replayed bool `json:"replayed,omitempty"`
+}
+
+// ErrInvalidSignature is returned when a delivery's signature does not
+// match the shared webhook secret.
+var ErrInvalidSignature = errors.New("zemeow: invalid webhook signature")
+
+// SignBody computes the signature header value for a webhook body, as
+// the server does: "sha256=" followed by the hex HMAC-SHA256 of the raw
+// body under the shared secret.
+func SignBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks a delivery's signature header against the raw
+// request body. The comparison is constant-time.
+func VerifySignature(secret, header string, body []byte) bool {
+	return hmac.Equal([]byte(SignBody(secret, body)), []byte(header))
+}
+
+// ParseWebhook verifies the signature and decodes the envelope in one
+// step — the shape most receivers want in their HTTP handler. Pass the
+// raw request body (before any decoding) and the SignatureHeader value.
+// An empty secret skips verification, for servers without one configured.
+func ParseWebhook(secret, header string, body []byte) (*WebhookEnvelope, error) {
+	if secret != "" && !VerifySignature(secret, header, body) {
+		return nil, ErrInvalidSignature
+	}
+	var env WebhookEnvelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return nil, err
+	}
+	return &env, nil
+}
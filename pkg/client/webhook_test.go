@@ -0,0 +1,59 @@
+package client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSignBody(t *testing.T) {
+	// Fixed vector: HMAC-SHA256 of the body under "shared-secret".
+	got := SignBody("shared-secret", []byte(`{"event":"message"}`))
+	want := "sha256=a6b568cb886c46e967e9e04250a5768ff5e059356c4fe4c49e17e6f9aecde6f4"
+	if got != want {
+		t.Errorf("SignBody = %q, want %q", got, want)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"event":"message"}`)
+	header := SignBody("shared-secret", body)
+
+	if !VerifySignature("shared-secret", header, body) {
+		t.Error("valid signature rejected")
+	}
+	if VerifySignature("wrong-secret", header, body) {
+		t.Error("signature accepted under the wrong secret")
+	}
+	if VerifySignature("shared-secret", header, []byte(`{"event":"tampered"}`)) {
+		t.Error("signature accepted for a tampered body")
+	}
+	if VerifySignature("shared-secret", "", body) {
+		t.Error("empty header accepted")
+	}
+}
+
+func TestParseWebhook(t *testing.T) {
+	body := []byte(`{"session_id":"s1","event":"message","payload":{"body":"hi"}}`)
+	header := SignBody("shared-secret", body)
+
+	env, err := ParseWebhook("shared-secret", header, body)
+	if err != nil {
+		t.Fatalf("valid delivery: %v", err)
+	}
+	if env.SessionID != "s1" || env.Event != "message" {
+		t.Errorf("envelope = %+v", env)
+	}
+
+	if _, err := ParseWebhook("shared-secret", "sha256=bad", body); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("bad signature: got %v, want ErrInvalidSignature", err)
+	}
+
+	// An empty secret skips verification entirely.
+	if _, err := ParseWebhook("", "", body); err != nil {
+		t.Errorf("unsigned delivery without secret: %v", err)
+	}
+
+	if _, err := ParseWebhook("shared-secret", SignBody("shared-secret", []byte("{broken")), []byte("{broken")); err == nil {
+		t.Error("malformed body: expected decode error")
+	}
+}